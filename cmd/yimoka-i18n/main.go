@@ -0,0 +1,257 @@
+// yimoka-i18n 消息键的提取与翻译覆盖率检查
+//
+// 扫描 Go 源码中 MsgKey 类型的常量、map[MsgKey]*i18n.Message 的消息表与 go-i18n 的消息文件,
+// 输出各语言的覆盖率、缺失与未使用的消息键, 生成翻译模板, 并在 CI 中检查相对基线的回退
+//
+//	yimoka-i18n coverage ./lang
+//	yimoka-i18n template -lang ja -o i18n/ja.toml ./...
+//	yimoka-i18n check -baseline i18n-baseline.json ./...
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/text/language"
+)
+
+const usage = `Usage: yimoka-i18n <command> [flags] [dir ...]
+
+Commands:
+  extract   输出消息键 (JSON)
+  coverage  输出各语言的覆盖率
+  missing   输出各语言缺少翻译的消息 ID
+  unused    输出未使用的消息 ID
+  template  生成语言的翻译模板
+  check     检查相对基线的回退 有回退时退出码为 1
+
+dir 为扫描的目录 包括子目录, 默认为当前目录
+运行 yimoka-i18n <command> -h 查看命令的参数
+`
+
+// listFlag 可重复的参数
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+// common 各命令共用的参数
+type common struct {
+	messages      listFlag
+	defaultLocale string
+	locales       string
+}
+
+func (c *common) register(fs *flag.FlagSet) {
+	fs.Var(&c.messages, "messages", "消息文件(TOML/YAML/JSON)的目录 可重复")
+	fs.StringVar(&c.defaultLocale, "default", "en", "默认语言 其消息为翻译的来源")
+	fs.StringVar(&c.locales, "locales", "", "检查的语言 以逗号分隔, 默认为扫描到的所有语言")
+}
+
+// report 扫描目录并生成报告
+func (c *common) report(dirs []string) (*Scan, *Report, error) {
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+	s := newScan()
+	if err := scanDirs(s, dirs); err != nil {
+		return nil, nil, err
+	}
+	if err := scanMessageFiles(s, c.messages); err != nil {
+		return nil, nil, err
+	}
+	defaultLocale, err := normalizeLocale(c.defaultLocale)
+	if err != nil {
+		return nil, nil, err
+	}
+	var locales []string
+	for _, l := range strings.Split(c.locales, ",") {
+		if l = strings.TrimSpace(l); l == "" {
+			continue
+		}
+		if l, err = normalizeLocale(l); err != nil {
+			return nil, nil, err
+		}
+		locales = append(locales, l)
+	}
+	return s, newReport(s, defaultLocale, locales), nil
+}
+
+func normalizeLocale(l string) (string, error) {
+	tag, err := language.Parse(l)
+	if err != nil {
+		return "", fmt.Errorf("invalid locale %q: %w", l, err)
+	}
+	return tag.String(), nil
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run 执行命令 返回退出码: 0 成功, 1 检查未通过, 2 参数或执行错误
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "help" {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	cmd, args := args[0], args[1:]
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	c := &common{}
+	c.register(fs)
+
+	var (
+		asJSON      bool
+		locale      string
+		format      string
+		output      string
+		all         bool
+		baseline    string
+		update      bool
+		minCoverage float64
+	)
+	switch cmd {
+	case "extract", "missing", "unused":
+	case "coverage":
+		fs.BoolVar(&asJSON, "json", false, "以 JSON 输出完整的报告")
+	case "template":
+		fs.StringVar(&locale, "lang", "", "语言 必填")
+		fs.StringVar(&format, "format", "", "格式 toml、yaml、json, 默认由 -o 的扩展名确定, 否则为 toml")
+		fs.StringVar(&output, "o", "", "输出的文件 默认为标准输出")
+		fs.BoolVar(&all, "all", false, "包括已翻译的消息")
+	case "check":
+		fs.StringVar(&baseline, "baseline", "", "基线文件 不存在时视为空基线")
+		fs.BoolVar(&update, "update", false, "以当前的结果更新基线")
+		fs.Float64Var(&minCoverage, "min-coverage", 0, "各语言的最低覆盖率(百分比)")
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", cmd, usage)
+		return 2
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	s, r, err := c.report(fs.Args())
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	switch cmd {
+	case "extract":
+		return writeJSON(stdout, stderr, r.Keys)
+	case "coverage":
+		if asJSON {
+			return writeJSON(stdout, stderr, r)
+		}
+		w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "LOCALE\tTRANSLATED\tTOTAL\tCOVERAGE\tMISSING\tEXTRA")
+		for _, l := range r.Locales {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%.1f%%\t%d\t%d\n", l.Locale, l.Translated, l.Total, l.Percent, len(l.Missing), len(l.Extra))
+		}
+		_ = w.Flush()
+		fmt.Fprintf(stdout, "unused: %d\n", len(r.Unused))
+	case "missing":
+		for _, l := range r.Locales {
+			for _, id := range l.Missing {
+				fmt.Fprintf(stdout, "%s\t%s\n", l.Locale, id)
+			}
+		}
+	case "unused":
+		keys := map[string]*Key{}
+		for _, k := range r.Keys {
+			keys[k.ID] = k
+		}
+		for _, id := range r.Unused {
+			fmt.Fprintf(stdout, "%s\t%s\t%s\n", id, keys[id].Const, keys[id].Pos)
+		}
+	case "template":
+		return writeTemplate(s, r, locale, format, output, all, stdout, stderr)
+	case "check":
+		return check(r, baseline, update, minCoverage, stdout, stderr)
+	}
+	return 0
+}
+
+func writeJSON(stdout, stderr io.Writer, v any) int {
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	return 0
+}
+
+func writeTemplate(s *Scan, r *Report, locale, format, output string, all bool, stdout, stderr io.Writer) int {
+	if locale == "" {
+		fmt.Fprintln(stderr, "-lang is required")
+		return 2
+	}
+	locale, err := normalizeLocale(locale)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(output), ".")
+	}
+	if format == "" {
+		format = "toml"
+	}
+	buf, err := marshal(format, template(s, r, locale, all))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if output == "" {
+		_, _ = stdout.Write(buf)
+		return 0
+	}
+	if err := os.WriteFile(output, buf, 0o644); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	return 0
+}
+
+func check(r *Report, baseline string, update bool, minCoverage float64, stdout, stderr io.Writer) int {
+	b := &Baseline{}
+	if baseline != "" {
+		if update {
+			if err := writeBaseline(baseline, newBaseline(r)); err != nil {
+				fmt.Fprintln(stderr, err)
+				return 2
+			}
+			fmt.Fprintf(stdout, "baseline %s updated\n", baseline)
+			return 0
+		}
+		var err error
+		if b, err = readBaseline(baseline); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+	}
+	problems := regressions(r, b, minCoverage)
+	for _, p := range problems {
+		fmt.Fprintln(stdout, p)
+	}
+	if len(problems) > 0 {
+		fmt.Fprintf(stdout, "FAIL: %d regressions\n", len(problems))
+		return 1
+	}
+	fmt.Fprintln(stdout, "ok")
+	return 0
+}
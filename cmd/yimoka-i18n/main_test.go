@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/lang"
+)
+
+const testSource = `package msg
+
+import (
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/yimoka/go/lang"
+	"golang.org/x/text/language"
+)
+
+const (
+	prefix    lang.MsgKey = "order_"
+	createdKey lang.MsgKey = prefix + "created"
+	helloKey   lang.MsgKey = "hello"
+	unusedKey  lang.MsgKey = "unused"
+	byeKey     lang.MsgKey = "bye"
+)
+
+var en = map[lang.MsgKey]*i18n.Message{
+	helloKey:   {ID: helloKey.String(), Other: "Hello"},
+	byeKey:     {ID: byeKey.String(), Other: "Bye"},
+	unusedKey:  {ID: unusedKey.String(), Other: "Unused"},
+	createdKey: {ID: createdKey.String(), Other: "Created"},
+}
+
+var messages = map[language.Tag]map[lang.MsgKey]*i18n.Message{
+	language.English: en,
+	language.Make("zh"): {
+		helloKey: {ID: helloKey.String(), Other: "你好"},
+		"stale":  {ID: "stale", Other: "旧的"},
+	},
+}
+
+func Hello() string { return helloKey.String() + byeKey.String() }
+
+func Order(status string) lang.MsgKey { return prefix + lang.MsgKey(status) }
+`
+
+func runCmd(args ...string) (int, string, string) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := run(args, stdout, stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "msg"), 0o700))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "msg", "msg.go"), []byte(testSource), 0o600))
+	files := filepath.Join(dir, "i18n")
+	assert.NoError(t, os.MkdirAll(files, 0o700))
+	assert.NoError(t, os.WriteFile(filepath.Join(files, "en.toml"), []byte("welcome = \"Welcome\"\n"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(files, "ja.yaml"), []byte("hello: こんにちは\nwelcome: ようこそ\n"), 0o600))
+
+	code, out, _ := runCmd("coverage", "-json", "-messages", files, dir)
+	assert.Equal(t, 0, code)
+	r := &Report{}
+	assert.NoError(t, json.Unmarshal([]byte(out), r))
+	assert.Len(t, r.Keys, 5)
+	assert.Equal(t, []string{"en", "ja", "zh"}, []string{r.Locales[0].Locale, r.Locales[1].Locale, r.Locales[2].Locale})
+	assert.Equal(t, 5, r.Locale("en").Translated)
+	assert.Equal(t, []string{"bye", "order_created", "unused", "welcome"}, r.Locale("zh").Missing)
+	assert.Equal(t, []string{"stale"}, r.Locale("zh").Extra)
+	// 以使用的前缀开头的消息键视为动态使用
+	assert.Equal(t, []string{"unused"}, r.Unused)
+
+	code, out, _ = runCmd("missing", "-locales", "ja", "-messages", files, dir)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "ja\tbye\nja\torder_created\nja\tunused\n", out)
+
+	code, out, _ = runCmd("unused", dir)
+	assert.Equal(t, 0, code)
+	assert.True(t, strings.HasPrefix(out, "unused\tunusedKey\t"))
+
+	// 生成的模板可作为消息文件加载
+	code, _, _ = runCmd("template", "-format", "yaml", dir)
+	assert.Equal(t, 2, code)
+	code, out, _ = runCmd("template", "-lang", "zh", dir)
+	assert.Equal(t, 0, code)
+	mfs, err := lang.ParseMessageFiles(fstest.MapFS{"zh.toml": {Data: []byte(out)}}, ".")
+	assert.NoError(t, err)
+	assert.Len(t, mfs[0].Messages, 3)
+	code, out, _ = runCmd("template", "-lang", "zh", "-all", "-format", "json", dir)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out, `"hello": "你好"`)
+
+	// 基线
+	baseline := filepath.Join(dir, "baseline.json")
+	code, out, _ = runCmd("check", "-baseline", baseline, dir)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, out, "missing zh: bye")
+	assert.Contains(t, out, "unused: unused")
+	code, _, _ = runCmd("check", "-baseline", baseline, "-update", dir)
+	assert.Equal(t, 0, code)
+	code, _, _ = runCmd("check", "-baseline", baseline, dir)
+	assert.Equal(t, 0, code)
+	// 新增的缺失为回退
+	code, out, _ = runCmd("check", "-baseline", baseline, "-locales", "zh", "-messages", files, dir)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "missing zh: welcome\nFAIL: 1 regressions\n", out)
+	code, out, _ = runCmd("check", "-baseline", baseline, "-min-coverage", "50", dir)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, out, "coverage zh: 25.0% < 50.0%")
+
+	code, _, _ = runCmd("unknown")
+	assert.Equal(t, 2, code)
+}
+
+// 业务模块的消息键类型 消息表与消息键在不同的包中
+const catalogKeySource = `package order
+
+import "github.com/yimoka/go/lang"
+
+type Key lang.MsgKey
+
+const (
+	paidKey   Key = "order_paid"
+	closedKey Key = "order_closed"
+)
+
+func Paid() string { return string(paidKey) }
+`
+
+const catalogSource = `package service
+
+import (
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/yimoka/go/lang"
+	"golang.org/x/text/language"
+
+	"example.com/order"
+)
+
+var orderCatalog *lang.Catalog[order.Key]
+
+var messages = map[language.Tag]map[order.Key]*i18n.Message{
+	language.English: {"order_paid": {ID: "order_paid", Other: "Paid"}},
+}
+`
+
+func TestCatalogKeys(t *testing.T) {
+	dir := t.TempDir()
+	for name, src := range map[string]string{"order/key.go": catalogKeySource, "service/catalog.go": catalogSource} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o700))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(src), 0o600))
+	}
+
+	code, out, _ := runCmd("coverage", "-json", dir)
+	assert.Equal(t, 0, code)
+	r := &Report{}
+	assert.NoError(t, json.Unmarshal([]byte(out), r))
+	ids := []string{}
+	for _, k := range r.Keys {
+		ids = append(ids, k.ID)
+	}
+	assert.Equal(t, []string{"order_closed", "order_paid"}, ids)
+	assert.Equal(t, []string{"order_closed"}, r.Locale("en").Missing)
+	assert.Equal(t, []string{"order_closed"}, r.Unused)
+}
+
+// TestLangCoverage 公共语言包的各语言须完整翻译
+func TestLangCoverage(t *testing.T) {
+	code, out, _ := runCmd("check", "-min-coverage", "100", "../../lang")
+	assert.Equal(t, 0, code, out)
+}
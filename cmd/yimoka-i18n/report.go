@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Coverage 语言的翻译覆盖率
+type Coverage struct {
+	Locale     string  `json:"locale"`
+	Total      int     `json:"total"`
+	Translated int     `json:"translated"`
+	Percent    float64 `json:"percent"`
+	// Missing 缺少翻译的消息 ID
+	Missing []string `json:"missing,omitempty"`
+	// Extra 已不存在的消息 ID 的翻译
+	Extra []string `json:"extra,omitempty"`
+}
+
+// Report 扫描报告
+type Report struct {
+	Default string `json:"default"`
+	Keys    []*Key `json:"keys"`
+	// Locales 各语言的覆盖率 默认语言在前
+	Locales []*Coverage `json:"locales"`
+	// Unused 未使用的消息 ID
+	Unused []string `json:"unused,omitempty"`
+}
+
+// newReport 生成报告 消息键为声明的常量与默认语言的消息, locales 为空时使用扫描到的所有语言
+func newReport(s *Scan, defaultLocale string, locales []string) *Report {
+	r := &Report{Default: defaultLocale}
+	keys := map[string]*Key{}
+	for id, k := range s.Keys {
+		keys[id] = k
+	}
+	for id := range s.Catalog[defaultLocale] {
+		if _, ok := keys[id]; !ok {
+			keys[id] = &Key{ID: id}
+		}
+	}
+	ids := make([]string, 0, len(keys))
+	for id := range keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		k := keys[id]
+		k.Default = s.Catalog[defaultLocale][id]
+		r.Keys = append(r.Keys, k)
+		if !s.Used(k) {
+			r.Unused = append(r.Unused, id)
+		}
+	}
+
+	if len(locales) == 0 {
+		for locale := range s.Catalog {
+			if locale != defaultLocale {
+				locales = append(locales, locale)
+			}
+		}
+		sort.Strings(locales)
+		locales = append([]string{defaultLocale}, locales...)
+	}
+	for _, locale := range locales {
+		msgs := s.Catalog[locale]
+		c := &Coverage{Locale: locale, Total: len(ids)}
+		for _, id := range ids {
+			if _, ok := msgs[id]; ok {
+				c.Translated++
+			} else {
+				c.Missing = append(c.Missing, id)
+			}
+		}
+		for id := range msgs {
+			if _, ok := keys[id]; !ok {
+				c.Extra = append(c.Extra, id)
+			}
+		}
+		sort.Strings(c.Extra)
+		if c.Total > 0 {
+			c.Percent = float64(c.Translated) * 100 / float64(c.Total)
+		} else {
+			c.Percent = 100
+		}
+		r.Locales = append(r.Locales, c)
+	}
+	return r
+}
+
+// Locale 获取语言的覆盖率
+func (r *Report) Locale(locale string) *Coverage {
+	for _, c := range r.Locales {
+		if c.Locale == locale {
+			return c
+		}
+	}
+	return nil
+}
+
+// template 生成语言的翻译模板 消息 ID -> 默认语言的消息, all 为 true 时包括已翻译的消息(值为已有的翻译)
+func template(s *Scan, r *Report, locale string, all bool) map[string]string {
+	res := map[string]string{}
+	for _, k := range r.Keys {
+		v, ok := s.Catalog[locale][k.ID]
+		switch {
+		case !ok:
+			res[k.ID] = k.Default
+		case all:
+			res[k.ID] = v
+		}
+	}
+	return res
+}
+
+// marshal 按格式编码为 go-i18n 的消息文件
+func marshal(format string, v map[string]string) ([]byte, error) {
+	switch format {
+	case "toml":
+		buf := &bytes.Buffer{}
+		err := toml.NewEncoder(buf).Encode(v)
+		return buf.Bytes(), err
+	case "yaml", "yml":
+		return yaml.Marshal(v)
+	case "json":
+		buf, err := json.MarshalIndent(v, "", "  ")
+		return append(buf, '\n'), err
+	}
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+// Baseline 基线 记录已知的缺失与未使用的消息 ID, check 时仅新增的问题视为回退
+type Baseline struct {
+	Missing map[string][]string `json:"missing"`
+	Unused  []string            `json:"unused"`
+}
+
+func newBaseline(r *Report) *Baseline {
+	b := &Baseline{Missing: map[string][]string{}, Unused: r.Unused}
+	for _, c := range r.Locales {
+		if len(c.Missing) > 0 {
+			b.Missing[c.Locale] = c.Missing
+		}
+	}
+	return b
+}
+
+func readBaseline(path string) (*Baseline, error) {
+	buf, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Baseline{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	b := &Baseline{}
+	if err := json.Unmarshal(buf, b); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return b, nil
+}
+
+func writeBaseline(path string, b *Baseline) error {
+	buf, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(buf, '\n'), 0o644)
+}
+
+// regressions 相对基线新增的问题与低于最低覆盖率的语言
+func regressions(r *Report, b *Baseline, minCoverage float64) []string {
+	res := []string{}
+	for _, c := range r.Locales {
+		known := toSet(b.Missing[c.Locale])
+		for _, id := range c.Missing {
+			if !known[id] {
+				res = append(res, fmt.Sprintf("missing %s: %s", c.Locale, id))
+			}
+		}
+		if c.Percent < minCoverage {
+			res = append(res, fmt.Sprintf("coverage %s: %.1f%% < %.1f%%", c.Locale, c.Percent, minCoverage))
+		}
+	}
+	known := toSet(b.Unused)
+	for _, id := range r.Unused {
+		if !known[id] {
+			res = append(res, fmt.Sprintf("unused: %s", id))
+		}
+	}
+	return res
+}
+
+func toSet(ids []string) map[string]bool {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
@@ -0,0 +1,604 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yimoka/go/lang"
+	"golang.org/x/text/language"
+)
+
+// Key 消息键
+type Key struct {
+	ID string `json:"id"`
+	// Const 声明的常量名 来自消息文件时为空
+	Const string `json:"const,omitempty"`
+	// Pos 声明的位置
+	Pos string `json:"pos,omitempty"`
+	// Default 默认语言的消息
+	Default string `json:"default,omitempty"`
+
+	pkg      string
+	exported bool
+}
+
+// Catalog 各语言的消息 语言 -> 消息 ID -> 消息
+type Catalog map[string]map[string]string
+
+func (c Catalog) add(locale, id, other string) {
+	if c[locale] == nil {
+		c[locale] = map[string]string{}
+	}
+	c[locale][id] = other
+}
+
+// Scan 扫描的结果
+type Scan struct {
+	// Keys 声明的消息键 key 为消息 ID
+	Keys map[string]*Key
+	// Prefixes 动态拼接的消息键前缀 值为前缀是否被使用
+	Prefixes map[string]bool
+	Catalog  Catalog
+	// used 使用的标识符 包目录 -> 名称
+	used map[string]map[string]bool
+	// usedExported 通过包名引用的标识符
+	usedExported map[string]bool
+	// keyTypes 消息键的类型名 MsgKey 与业务模块的消息键类型
+	keyTypes map[string]bool
+}
+
+func newScan() *Scan {
+	return &Scan{
+		Keys:         map[string]*Key{},
+		Prefixes:     map[string]bool{},
+		Catalog:      Catalog{},
+		used:         map[string]map[string]bool{},
+		usedExported: map[string]bool{},
+		keyTypes:     map[string]bool{"MsgKey": true},
+	}
+}
+
+// Used 消息键是否被使用 以已使用的前缀开头的消息键视为动态使用
+func (s *Scan) Used(k *Key) bool {
+	if k.Const == "" {
+		return true
+	}
+	if s.used[k.pkg][k.Const] || (k.exported && s.usedExported[k.Const]) {
+		return true
+	}
+	for prefix, used := range s.Prefixes {
+		if used && strings.HasPrefix(k.ID, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// scanDirs 扫描目录及其子目录中的 Go 文件 跳过测试文件、vendor、testdata 与隐藏目录
+// 先收集所有包的消息键类型 再收集消息键与消息表
+func scanDirs(s *Scan, dirs []string) error {
+	pkgs := []*pkgScanner{}
+	for _, root := range dirs {
+		root = strings.TrimSuffix(root, "/...")
+		err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || !d.IsDir() {
+				return err
+			}
+			name := d.Name()
+			if p != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			ps, err := parsePackage(s, p)
+			if ps != nil {
+				pkgs = append(pkgs, ps)
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, ps := range pkgs {
+		ps.collectKeyTypes()
+	}
+	for _, ps := range pkgs {
+		ps.collectConsts()
+		ps.collectKeys()
+		ps.collectCatalogs()
+		ps.collectUsage()
+	}
+	return nil
+}
+
+// scanMessageFiles 扫描目录中的消息文件
+func scanMessageFiles(s *Scan, dirs []string) error {
+	for _, dir := range dirs {
+		files, err := lang.ParseMessageFiles(os.DirFS(dir), ".")
+		if err != nil {
+			return err
+		}
+		for _, mf := range files {
+			for _, m := range mf.Messages {
+				s.Catalog.add(mf.Tag.String(), m.ID, m.Other)
+			}
+		}
+	}
+	return nil
+}
+
+// pkgScanner 扫描一个包
+type pkgScanner struct {
+	*Scan
+	fset  *token.FileSet
+	dir   string
+	files []*ast.File
+	// consts 包内常量的值表达式
+	consts map[string]ast.Expr
+	// catalogs 消息表变量 变量名 -> 消息 ID -> 消息
+	catalogs map[string]map[string]string
+}
+
+// parsePackage 解析目录中的 Go 文件 没有 Go 文件时返回 nil
+func parsePackage(s *Scan, dir string) (*pkgScanner, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	ps := &pkgScanner{Scan: s, fset: token.NewFileSet(), dir: dir, consts: map[string]ast.Expr{}, catalogs: map[string]map[string]string{}}
+	for _, name := range matches {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(ps.fset, name, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		ps.files = append(ps.files, f)
+	}
+	if len(ps.files) == 0 {
+		return nil, nil
+	}
+	return ps, nil
+}
+
+// collectKeyTypes 收集业务模块的消息键类型
+// 包括 type X lang.MsgKey、Catalog[X] 与 NewCatalog[X] 的类型参数、map[language.Tag]map[X]*i18n.Message 的字面量与变量的 X
+func (ps *pkgScanner) collectKeyTypes() {
+	for _, f := range ps.files {
+		params := typeParams(f)
+		add := func(expr ast.Expr) {
+			if id, ok := expr.(*ast.Ident); ok && params[id.Name] {
+				return
+			}
+			ps.addKeyType(expr)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.TypeSpec:
+				if isSelector(n.Type, "MsgKey") {
+					ps.keyTypes[n.Name.Name] = true
+				}
+			case *ast.IndexExpr:
+				if isSelector(n.X, "Catalog") || isSelector(n.X, "NewCatalog") {
+					add(n.Index)
+				}
+			case *ast.CompositeLit:
+				if key, ok := localeMapKey(n.Type); ok {
+					add(key)
+				}
+			case *ast.ValueSpec:
+				if key, ok := localeMapKey(n.Type); ok {
+					add(key)
+				}
+			}
+			return true
+		})
+	}
+}
+
+// typeParams 文件中泛型的类型参数名
+func typeParams(f *ast.File) map[string]bool {
+	params := map[string]bool{}
+	addFields := func(fl *ast.FieldList) {
+		if fl == nil {
+			return
+		}
+		for _, field := range fl.List {
+			for _, name := range field.Names {
+				params[name.Name] = true
+			}
+		}
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.TypeSpec:
+			addFields(n.TypeParams)
+		case *ast.FuncType:
+			addFields(n.TypeParams)
+		case *ast.FuncDecl:
+			// 方法的接收者 如 (c *Catalog[K])
+			if n.Recv != nil {
+				for _, field := range n.Recv.List {
+					ast.Inspect(field.Type, func(n ast.Node) bool {
+						if ix, ok := n.(*ast.IndexExpr); ok {
+							if id, ok := ix.Index.(*ast.Ident); ok {
+								params[id.Name] = true
+							}
+						}
+						return true
+					})
+				}
+			}
+		}
+		return true
+	})
+	return params
+}
+
+// localeMapKey map[language.Tag]map[X]*i18n.Message 的 X
+func localeMapKey(expr ast.Expr) (ast.Expr, bool) {
+	mt, ok := expr.(*ast.MapType)
+	if !ok || !isSelector(mt.Key, "Tag") {
+		return nil, false
+	}
+	inner, ok := mt.Value.(*ast.MapType)
+	if !ok || !isMessageType(inner.Value) {
+		return nil, false
+	}
+	return inner.Key, true
+}
+
+func (ps *pkgScanner) addKeyType(expr ast.Expr) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if e.Name != "string" {
+			ps.keyTypes[e.Name] = true
+		}
+	case *ast.SelectorExpr:
+		ps.keyTypes[e.Sel.Name] = true
+	}
+}
+
+// collectConsts 收集包内常量 用于计算消息键的值
+func (ps *pkgScanner) collectConsts() {
+	ps.eachValueSpec(token.CONST, func(vs *ast.ValueSpec) {
+		for i, name := range vs.Names {
+			if i < len(vs.Values) {
+				ps.consts[name.Name] = vs.Values[i]
+			}
+		}
+	})
+}
+
+// collectKeys 收集消息键类型的常量 以 _ 或 . 结尾的视为前缀
+func (ps *pkgScanner) collectKeys() {
+	ps.eachValueSpec(token.CONST, func(vs *ast.ValueSpec) {
+		if !ps.isKeyType(vs.Type) {
+			return
+		}
+		for i, name := range vs.Names {
+			if i >= len(vs.Values) || name.Name == "_" {
+				continue
+			}
+			id, ok := ps.eval(vs.Values[i], 0)
+			if !ok || id == "" {
+				continue
+			}
+			if strings.HasSuffix(id, "_") || strings.HasSuffix(id, ".") {
+				if _, exists := ps.Prefixes[id]; !exists {
+					ps.Prefixes[id] = false
+				}
+				continue
+			}
+			if _, exists := ps.Keys[id]; exists {
+				continue
+			}
+			pos := ps.fset.Position(name.Pos())
+			ps.Keys[id] = &Key{
+				ID:       id,
+				Const:    name.Name,
+				Pos:      fmt.Sprintf("%s:%d", filepath.ToSlash(pos.Filename), pos.Line),
+				pkg:      ps.dir,
+				exported: name.IsExported(),
+			}
+		}
+	})
+}
+
+// collectCatalogs 收集 map[MsgKey]*i18n.Message 的消息表, 语言由 map[language.Tag]map[MsgKey]*i18n.Message 确定
+func (ps *pkgScanner) collectCatalogs() {
+	ps.eachValueSpec(token.VAR, func(vs *ast.ValueSpec) {
+		for i, name := range vs.Names {
+			if i >= len(vs.Values) {
+				continue
+			}
+			if lit, ok := vs.Values[i].(*ast.CompositeLit); ok && ps.isMessageMapType(lit.Type) {
+				ps.catalogs[name.Name] = ps.messages(lit)
+			}
+		}
+	})
+	ps.eachValueSpec(token.VAR, func(vs *ast.ValueSpec) {
+		for _, v := range vs.Values {
+			lit, ok := v.(*ast.CompositeLit)
+			if !ok || !ps.isLocaleMapType(lit.Type) {
+				continue
+			}
+			for _, el := range lit.Elts {
+				kv, ok := el.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				tag, ok := evalTag(kv.Key)
+				if !ok {
+					continue
+				}
+				var msgs map[string]string
+				switch val := kv.Value.(type) {
+				case *ast.Ident:
+					msgs = ps.catalogs[val.Name]
+				case *ast.CompositeLit:
+					msgs = ps.messages(val)
+				}
+				for id, other := range msgs {
+					ps.Catalog.add(tag.String(), id, other)
+				}
+			}
+		}
+	})
+}
+
+// messages 解析消息表的字面量
+func (ps *pkgScanner) messages(lit *ast.CompositeLit) map[string]string {
+	msgs := map[string]string{}
+	for _, el := range lit.Elts {
+		kv, ok := el.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		id, ok := ps.eval(kv.Key, 0)
+		if !ok {
+			continue
+		}
+		msgLit, ok := kv.Value.(*ast.CompositeLit)
+		if u, isUnary := kv.Value.(*ast.UnaryExpr); isUnary {
+			msgLit, ok = u.X.(*ast.CompositeLit)
+		}
+		if !ok {
+			msgs[id] = ""
+			continue
+		}
+		other := ""
+		for _, f := range msgLit.Elts {
+			if fkv, ok := f.(*ast.KeyValueExpr); ok && isIdent(fkv.Key, "Other") {
+				other, _ = ps.eval(fkv.Value, 0)
+			}
+		}
+		msgs[id] = other
+	}
+	return msgs
+}
+
+// collectUsage 收集使用的标识符 跳过消息键的声明与消息表
+func (ps *pkgScanner) collectUsage() {
+	used := ps.used[ps.dir]
+	if used == nil {
+		used = map[string]bool{}
+		ps.used[ps.dir] = used
+	}
+	for _, f := range ps.files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.ValueSpec:
+				if ps.isKeyType(n.Type) {
+					return false
+				}
+				// 只检查值 跳过声明的名称
+				for _, v := range n.Values {
+					ast.Inspect(v, ps.inspectUsage(used))
+				}
+				return false
+			}
+			return ps.inspectUsage(used)(n)
+		})
+	}
+	// 前缀在声明之外被使用时 以该前缀开头的消息键视为动态使用
+	for prefix := range ps.Prefixes {
+		for _, k := range ps.constsWithValue(prefix) {
+			if used[k] {
+				ps.Prefixes[prefix] = true
+			}
+		}
+	}
+}
+
+func (ps *pkgScanner) inspectUsage(used map[string]bool) func(ast.Node) bool {
+	return func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CompositeLit:
+			if ps.isMessageMapType(n.Type) || ps.isLocaleMapType(n.Type) {
+				return false
+			}
+		case *ast.SelectorExpr:
+			ps.usedExported[n.Sel.Name] = true
+			ast.Inspect(n.X, ps.inspectUsage(used))
+			return false
+		case *ast.Ident:
+			used[n.Name] = true
+		}
+		return true
+	}
+}
+
+// constsWithValue 值为 value 的常量名
+func (ps *pkgScanner) constsWithValue(value string) []string {
+	names := []string{}
+	for name, expr := range ps.consts {
+		if v, ok := ps.eval(expr, 0); ok && v == value {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (ps *pkgScanner) eachValueSpec(tok token.Token, fn func(*ast.ValueSpec)) {
+	for _, f := range ps.files {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != tok {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				if vs, ok := spec.(*ast.ValueSpec); ok {
+					fn(vs)
+				}
+			}
+		}
+	}
+}
+
+// eval 计算字符串常量表达式 支持字面量、包内常量、+ 拼接与类型转换
+func (ps *pkgScanner) eval(expr ast.Expr, depth int) (string, bool) {
+	if depth > 32 {
+		return "", false
+	}
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		s, err := strconv.Unquote(e.Value)
+		return s, err == nil
+	case *ast.Ident:
+		if v, ok := ps.consts[e.Name]; ok {
+			return ps.eval(v, depth+1)
+		}
+	case *ast.ParenExpr:
+		return ps.eval(e.X, depth+1)
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		x, ok := ps.eval(e.X, depth+1)
+		if !ok {
+			return "", false
+		}
+		y, ok := ps.eval(e.Y, depth+1)
+		return x + y, ok
+	case *ast.CallExpr:
+		// MsgKey("...")、lang.MsgKey("...")
+		if len(e.Args) == 1 && ps.isKeyType(e.Fun) {
+			return ps.eval(e.Args[0], depth+1)
+		}
+		// key.String()
+		if sel, ok := e.Fun.(*ast.SelectorExpr); ok && len(e.Args) == 0 && sel.Sel.Name == "String" {
+			return ps.eval(sel.X, depth+1)
+		}
+	}
+	return "", false
+}
+
+func isIdent(expr ast.Expr, name string) bool {
+	id, ok := expr.(*ast.Ident)
+	return ok && id.Name == name
+}
+
+// isSelector 是否为 name 或 pkg.name
+func isSelector(expr ast.Expr, name string) bool {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name == name
+	case *ast.SelectorExpr:
+		return e.Sel.Name == name
+	}
+	return false
+}
+
+// isKeyType 是否为消息键类型
+func (ps *pkgScanner) isKeyType(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return ps.keyTypes[e.Name]
+	case *ast.SelectorExpr:
+		return ps.keyTypes[e.Sel.Name]
+	}
+	return false
+}
+
+// isMessageType 是否为 *i18n.Message
+func isMessageType(expr ast.Expr) bool {
+	star, ok := expr.(*ast.StarExpr)
+	return ok && isSelector(star.X, "Message")
+}
+
+// isMessageMapType 是否为 map[MsgKey]*i18n.Message
+func (ps *pkgScanner) isMessageMapType(expr ast.Expr) bool {
+	mt, ok := expr.(*ast.MapType)
+	return ok && ps.isKeyType(mt.Key) && isMessageType(mt.Value)
+}
+
+// isLocaleMapType 是否为 map[language.Tag]map[MsgKey]*i18n.Message
+func (ps *pkgScanner) isLocaleMapType(expr ast.Expr) bool {
+	mt, ok := expr.(*ast.MapType)
+	return ok && isSelector(mt.Key, "Tag") && ps.isMessageMapType(mt.Value)
+}
+
+// evalTag 解析 language.English、language.Make("ja")、language.MustParse("ja")
+func evalTag(expr ast.Expr) (language.Tag, bool) {
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		tag, ok := namedTags[e.Sel.Name]
+		return tag, ok
+	case *ast.CallExpr:
+		if len(e.Args) != 1 || !(isSelector(e.Fun, "Make") || isSelector(e.Fun, "MustParse")) {
+			return language.Und, false
+		}
+		lit, ok := e.Args[0].(*ast.BasicLit)
+		if !ok {
+			return language.Und, false
+		}
+		s, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return language.Und, false
+		}
+		tag, err := language.Parse(s)
+		return tag, err == nil
+	}
+	return language.Und, false
+}
+
+// namedTags golang.org/x/text/language 中预定义的语言
+var namedTags = map[string]language.Tag{
+	"Afrikaans": language.Afrikaans, "Amharic": language.Amharic, "Arabic": language.Arabic,
+	"ModernStandardArabic": language.ModernStandardArabic, "Azerbaijani": language.Azerbaijani,
+	"Bulgarian": language.Bulgarian, "Bengali": language.Bengali, "Catalan": language.Catalan,
+	"Czech": language.Czech, "Danish": language.Danish, "German": language.German, "Greek": language.Greek,
+	"English": language.English, "AmericanEnglish": language.AmericanEnglish, "BritishEnglish": language.BritishEnglish,
+	"Spanish": language.Spanish, "EuropeanSpanish": language.EuropeanSpanish, "LatinAmericanSpanish": language.LatinAmericanSpanish,
+	"Estonian": language.Estonian, "Persian": language.Persian, "Finnish": language.Finnish, "Filipino": language.Filipino,
+	"French": language.French, "CanadianFrench": language.CanadianFrench, "Gujarati": language.Gujarati,
+	"Hebrew": language.Hebrew, "Hindi": language.Hindi, "Croatian": language.Croatian, "Hungarian": language.Hungarian,
+	"Armenian": language.Armenian, "Indonesian": language.Indonesian, "Icelandic": language.Icelandic,
+	"Italian": language.Italian, "Japanese": language.Japanese, "Georgian": language.Georgian, "Kazakh": language.Kazakh,
+	"Khmer": language.Khmer, "Kannada": language.Kannada, "Korean": language.Korean, "Kirghiz": language.Kirghiz,
+	"Lao": language.Lao, "Lithuanian": language.Lithuanian, "Latvian": language.Latvian, "Macedonian": language.Macedonian,
+	"Malayalam": language.Malayalam, "Mongolian": language.Mongolian, "Marathi": language.Marathi, "Malay": language.Malay,
+	"Burmese": language.Burmese, "Nepali": language.Nepali, "Dutch": language.Dutch, "Norwegian": language.Norwegian,
+	"Punjabi": language.Punjabi, "Polish": language.Polish, "Portuguese": language.Portuguese,
+	"BrazilianPortuguese": language.BrazilianPortuguese, "EuropeanPortuguese": language.EuropeanPortuguese,
+	"Romanian": language.Romanian, "Russian": language.Russian, "Sinhala": language.Sinhala, "Slovak": language.Slovak,
+	"Slovenian": language.Slovenian, "Albanian": language.Albanian, "Serbian": language.Serbian,
+	"SerbianLatin": language.SerbianLatin, "Swedish": language.Swedish, "Swahili": language.Swahili,
+	"Tamil": language.Tamil, "Telugu": language.Telugu, "Thai": language.Thai, "Turkish": language.Turkish,
+	"Ukrainian": language.Ukrainian, "Urdu": language.Urdu, "Uzbek": language.Uzbek, "Vietnamese": language.Vietnamese,
+	"Chinese": language.Chinese, "SimplifiedChinese": language.SimplifiedChinese,
+	"TraditionalChinese": language.TraditionalChinese, "Zulu": language.Zulu,
+}
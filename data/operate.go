@@ -3,7 +3,12 @@ package data
 
 /* cSpell:disable */
 
-import "github.com/yimoka/go/lang"
+import (
+	"context"
+
+	"github.com/yimoka/go/lang"
+	"golang.org/x/text/language"
+)
 
 // OpType 数据操作类型
 type OpType string
@@ -55,9 +60,13 @@ func OpTypeLabels() map[string]string {
 	}
 }
 
-// 多语言支持
-var opTypeLangMap = map[string]map[string]string{
-	"zh": {
+// OpTypeDictNamespace 操作类型的字典命名空间 可在配置的 dict 中覆盖
+const OpTypeDictNamespace = "opType"
+
+// 多语言支持 注册为内置字典
+func init() {
+	lang.RegisterDict(language.English, OpTypeDictNamespace, OpTypeLabels())
+	lang.RegisterDict(language.Chinese, OpTypeDictNamespace, map[string]string{
 		string(OpAdd):     "添加",
 		string(OpDel):     "删除",
 		string(OpSoftDel): "软删除",
@@ -66,8 +75,8 @@ var opTypeLangMap = map[string]map[string]string{
 		string(OpDisable): "禁用",
 		string(OpRecover): "恢复",
 		string(OpUnknown): "未知",
-	},
-	"ru": {
+	})
+	lang.RegisterDict(language.Russian, OpTypeDictNamespace, map[string]string{
 		string(OpAdd):     "добавить",
 		string(OpDel):     "удалить",
 		string(OpSoftDel): "мягкое удаление",
@@ -76,12 +85,17 @@ var opTypeLangMap = map[string]map[string]string{
 		string(OpDisable): "запретить",
 		string(OpRecover): "восстановить",
 		string(OpUnknown): "неизвестный",
-	},
+	})
 }
 
-// OpTypeLangLabels _
+// OpTypeLangLabels 内置字典的操作类型名称
 func OpTypeLangLabels(langs ...string) (map[string]string, bool) {
-	return lang.MatchContent(opTypeLangMap, langs)
+	return lang.GetDefaultDictData(OpTypeDictNamespace, langs...)
+}
+
+// OpTypeDictLabels 操作类型名称 使用请求的语言, 配置的字典优先于内置字典
+func OpTypeDictLabels(ctx context.Context, l *lang.CommonLang, langs ...string) (map[string]string, bool) {
+	return l.GetDictData(ctx, OpTypeDictNamespace, langs...)
 }
 
 // String _
@@ -103,6 +117,14 @@ func (o OpType) LangLabel(langs ...string) string {
 	return labels[string(o)]
 }
 
+// DictLabel 使用请求的语言获取名称 配置的字典优先于内置字典
+func (o OpType) DictLabel(ctx context.Context, l *lang.CommonLang, langs ...string) string {
+	if label, ok := l.GetDict(ctx, OpTypeDictNamespace, string(o), langs...); ok {
+		return label
+	}
+	return o.Label()
+}
+
 // Values _
 func (o OpType) Values() []string {
 	return OpTypeValues()
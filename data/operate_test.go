@@ -1,9 +1,13 @@
 package data
 
 import (
+	"context"
 	"testing"
 
+	"github.com/go-kratos/kratos/v2/log"
 	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/config"
+	"github.com/yimoka/go/lang"
 )
 
 func TestOpType(t *testing.T) {
@@ -12,3 +16,22 @@ func TestOpType(t *testing.T) {
 	assert.Equal(t, OpType("add").Label(), "添加")
 	assert.Equal(t, OpAdd.Label(), "添加")
 }
+
+func TestOpTypeDict(t *testing.T) {
+	assert.Equal(t, "删除", OpDel.LangLabel("zh-CN"))
+	assert.Equal(t, "delete", OpDel.LangLabel("fr"))
+	labels, ok := OpTypeLangLabels("ru")
+	assert.True(t, ok)
+	assert.Equal(t, "удалить", labels[string(OpDel)])
+
+	l := lang.NewCommonLang(map[string]*config.Lang{
+		"zh": {Dict: map[string]*config.DictData{OpTypeDictNamespace: {Data: map[string]string{string(OpDel): "移除"}}}},
+	}, log.DefaultLogger)
+	ctx := context.Background()
+	assert.Equal(t, "移除", OpDel.DictLabel(ctx, l, "zh"))
+	assert.Equal(t, "添加", OpAdd.DictLabel(ctx, l, "zh"))
+	assert.Equal(t, "unknown", OpType("unknown").DictLabel(ctx, l))
+	labels, _ = OpTypeDictLabels(ctx, l, "zh")
+	assert.Equal(t, "移除", labels[string(OpDel)])
+	assert.Equal(t, "编辑", labels[string(OpEdit)])
+}
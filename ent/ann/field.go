@@ -81,9 +81,15 @@ type Field struct {
 	// 请确保有 字段名 + Nonce 的字段用于存储随机生成的 nonce 并确保 onlyData 为 true
 	// 请确保有 字段名 + Cipher 的字段用于存储加密后的数据 并确保 onlyData 为 true
 	RowIrreversibleEncrypt bool
+	// 盲索引的长度(十六进制字符数) 大于 0 时为 Encrypt、MaskEncrypt 字段维护盲索引, 支持对明文的等值与 IN 查询
+	// 请确保有 字段名 + Bidx 的字段用于存储盲索引 并确保 onlyData 为 true, 建议为该字段建立索引
+	// 长度越短泄露的信息越少但误匹配越多, 查询结果需解密后再次比对
+	BlindIndex int
 
 	// 是否需要 xss 过滤
 	XSSFilter bool
+	// xss 过滤使用的净化配置 为空时使用 utils.XSSProfileArticle, 设置后无需再设置 XSSFilter
+	XSSProfile utils.XSSProfile
 
 	// 多语言字段，为空则不支持多语言，不为空则表示该字段为值字段的多语言值
 	// 不为空则必须有对应的默认内容字段，必有为 json 类型 且值与值字段相同
@@ -255,3 +261,37 @@ func GetFieldsConfig(node *gen.Type) map[string]*Field {
 	}
 	return fields
 }
+
+// GetXSSFields 获取需要 xss 过滤的字段及其净化配置 用于生成 xss.Rule
+func GetXSSFields(node *gen.Type) map[string]utils.XSSProfile {
+	fields := map[string]utils.XSSProfile{}
+	for _, field := range node.Fields {
+		config := GetFieldConfig(field)
+		switch {
+		case config.XSSProfile != "":
+			fields[field.Name] = config.XSSProfile
+		case config.XSSFilter:
+			fields[field.Name] = utils.XSSProfileArticle
+		}
+	}
+	return fields
+}
+
+// GetOpLogMaskFields 获取操作记录中需脱敏的字段 用于生成 oplog.WithSensitiveFields 与 oplog.WithMaskFields 的参数
+// Encrypt、RowIrreversibleEncrypt 及 ent 的 Sensitive 字段为敏感字段, MaskEncrypt 字段为掩码字段
+// 返回的字段名为 Table.column, 与操作记录中 Mutation 的字段名即数据库列名一致, 如 User.secret_key
+func GetOpLogMaskFields(node *gen.Type) ([]string, map[string]utils.MaskType) {
+	sensitive := []string{}
+	mask := map[string]utils.MaskType{}
+	for _, field := range node.Fields {
+		config := GetFieldConfig(field)
+		name := node.Name + "." + field.StorageKey()
+		switch {
+		case config.Encrypt || config.RowIrreversibleEncrypt || field.Sensitive():
+			sensitive = append(sensitive, name)
+		case config.MaskEncrypt != "":
+			mask[name] = config.MaskEncrypt
+		}
+	}
+	return sensitive, mask
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"log"
 
+	"entgo.io/ent"
 	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/entc/load"
 	"github.com/yimoka/api/common"
 )
 
@@ -88,6 +90,19 @@ func (Table) Name() string {
 	return tableNameKey
 }
 
+// LoadType 在运行时从 ent 的 Schema 加载代码生成使用的类型 用于读取 Schema 及其 Mixin 的注解
+func LoadType(schema ent.Interface) (*gen.Type, error) {
+	b, err := load.MarshalSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	s, err := load.UnmarshalSchema(b)
+	if err != nil {
+		return nil, err
+	}
+	return gen.NewType(&gen.Config{}, s)
+}
+
 // GetTableConfig 获取表配置
 func GetTableConfig(node *gen.Type) *Table {
 	ann := node.Annotations[tableNameKey]
@@ -2,11 +2,14 @@
 package customsql
 
 import (
+	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 
 	"entgo.io/ent/dialect/sql"
 	"github.com/samber/lo"
+	"github.com/yimoka/go/secret"
 )
 
 // GetStrSliceAndQuery 获取字符串切片的并查询
@@ -122,6 +125,49 @@ func getPgJSONIntQuery(field string, value int) *sql.Predicate {
 	})
 }
 
+// WhereP 为生成的 Query 或 Mutation 添加存储层的条件 用于 Schema 或 Mixin 的 Interceptor 与 Hook
+// 生成的 Mutation 有 WhereP 方法, Query 只有参数为 predicate.Xxx(即 func(*sql.Selector))的 Where 方法, 所以通过反射调用
+func WhereP(v any, ps ...func(*sql.Selector)) error {
+	if w, ok := v.(interface{ WhereP(...func(*sql.Selector)) }); ok {
+		w.WhereP(ps...)
+		return nil
+	}
+	method := reflect.ValueOf(v).MethodByName("Where")
+	if !method.IsValid() || method.Type().NumIn() != 1 || !method.Type().IsVariadic() {
+		return fmt.Errorf("customsql: %T has no Where method", v)
+	}
+	typ := method.Type().In(0).Elem()
+	args := make([]reflect.Value, 0, len(ps))
+	for _, p := range ps {
+		pv := reflect.ValueOf(p)
+		if !pv.Type().ConvertibleTo(typ) {
+			return fmt.Errorf("customsql: unexpected predicate type %s of %T", typ, v)
+		}
+		args = append(args, pv.Convert(typ))
+	}
+	method.Call(args)
+	return nil
+}
+
 func isPostgres(s *sql.Selector) bool {
 	return s.Dialect() == "postgres"
 }
+
+// GetBlindIndexQuery 获取加密字段的盲索引查询 values 为明文 一个值时为等值查询 多个值时为 IN 查询
+// 没有值时与空的 IN 查询相同不匹配任何数据
+// column 为盲索引的列名, key 与 length 须与写入时一致, 截断的盲索引存在误匹配 查询结果需解密后再次比对
+func GetBlindIndexQuery(column string, key string, length int, values ...string) func(s *sql.Selector) {
+	if len(values) == 0 {
+		return func(s *sql.Selector) {
+			s.Where(sql.False())
+		}
+	}
+	indexes := lo.Uniq(lo.Map(values, func(val string, _ int) string { return secret.BlindIndex(val, key, length) }))
+	return func(s *sql.Selector) {
+		if len(indexes) == 1 {
+			s.Where(sql.EQ(s.C(column), indexes[0]))
+			return
+		}
+		s.Where(sql.In(s.C(column), lo.ToAnySlice(indexes)...))
+	}
+}
@@ -0,0 +1,320 @@
+// Package encrypt 字段加解密
+// 根据 ann.Field 的 Encrypt、MaskEncrypt、RowIrreversibleEncrypt 配置, 通过 ent 的 Hook 与 Interceptor 在写入时加密, 读取时解密
+package encrypt
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/yimoka/go/config"
+	"github.com/yimoka/go/ent/ann"
+	"github.com/yimoka/go/ent/customsql"
+	"github.com/yimoka/go/secret"
+	"github.com/yimoka/go/utils"
+)
+
+const (
+	// CipherSuffix MaskEncrypt 字段存储加密数据的字段后缀
+	CipherSuffix = "Cipher"
+	// NonceSuffix RowIrreversibleEncrypt 字段存储随机 nonce 的字段后缀
+	NonceSuffix = "Nonce"
+	// BidxSuffix 存储盲索引的字段后缀
+	BidxSuffix = "Bidx"
+)
+
+// Table 表的加密配置 字段名为 Schema 中的字段名 如 phoneCipher, 数据库列名通过 Columns 获取
+type Table struct {
+	// 加密的密钥 Key 即密钥环中的密钥 ID 为空或不可用时使用 active 密钥
+	SecretKey string
+	// 加密存储的字段
+	Encrypt []string
+	// 掩码存储的字段 原字段存储掩码 字段名 + Cipher 存储加密数据
+	MaskEncrypt map[string]utils.MaskType
+	// 不可逆加密的字段 原字段存储密码哈希 字段名 + Nonce 存储旧方案的 nonce
+	RowIrreversibleEncrypt []string
+	// 维护盲索引的字段及其长度 字段名 + Bidx 存储盲索引
+	BlindIndex map[string]int
+
+	// 数据库的表名 用于重新加密任务
+	DBTable string
+	// 字段名对应的数据库列名 即生成的 Mutation 的字段名, 用于 Hook 与重新加密任务 不存在时使用字段名
+	// WithTable 注册时需设置, 如 phoneCipher -> phone_cipher
+	Columns map[string]string
+}
+
+// IsEmpty 是否没有需要加密的字段
+func (t *Table) IsEmpty() bool {
+	return len(t.Encrypt) == 0 && len(t.MaskEncrypt) == 0 && len(t.RowIrreversibleEncrypt) == 0 && len(t.BlindIndex) == 0
+}
+
+// CipherFields 存储密文的字段 即 Encrypt 字段与 MaskEncrypt 的 Cipher 字段
+func (t *Table) CipherFields() []string {
+	fields := append([]string{}, t.Encrypt...)
+	for name := range t.MaskEncrypt {
+		fields = append(fields, name+CipherSuffix)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Column 获取字段的数据库列名
+func (t *Table) Column(field string) string {
+	if c, ok := t.Columns[field]; ok {
+		return c
+	}
+	return field
+}
+
+// FromSchema 从 ent 的 Schema 及其 Mixin 的注解中读取表的加密配置
+func FromSchema(schema ent.Interface) (*Table, error) {
+	node, err := ann.LoadType(schema)
+	if err != nil {
+		return nil, err
+	}
+	t := &Table{
+		SecretKey:   ann.GetTableConfig(node).SecretKey,
+		MaskEncrypt: map[string]utils.MaskType{},
+		BlindIndex:  map[string]int{},
+		DBTable:     node.Table(),
+		Columns:     map[string]string{"id": node.ID.StorageKey()},
+	}
+	for _, f := range node.Fields {
+		t.Columns[f.Name] = f.StorageKey()
+		conf := ann.GetFieldConfig(f)
+		switch {
+		case conf.Encrypt:
+			t.Encrypt = append(t.Encrypt, f.Name)
+		case conf.MaskEncrypt != "":
+			t.MaskEncrypt[f.Name] = conf.MaskEncrypt
+		case conf.RowIrreversibleEncrypt:
+			t.RowIrreversibleEncrypt = append(t.RowIrreversibleEncrypt, f.Name)
+		}
+		if conf.BlindIndex > 0 && (conf.Encrypt || conf.MaskEncrypt != "") {
+			t.BlindIndex[f.Name] = conf.BlindIndex
+		}
+	}
+	return t, nil
+}
+
+// Option 配置
+type Option func(*Encryptor)
+
+// WithSchemas 注册 ent 的 Schema 表名为 Schema 的类型名 即 Mutation.Type()
+func WithSchemas(schemas ...ent.Interface) Option {
+	return func(e *Encryptor) {
+		for _, s := range schemas {
+			t := reflect.TypeOf(s)
+			if t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			table, err := FromSchema(s)
+			if err != nil {
+				e.err = fmt.Errorf("encrypt: load schema %s: %w", t.Name(), err)
+				return
+			}
+			if !table.IsEmpty() {
+				e.tables[t.Name()] = table
+			}
+		}
+	}
+}
+
+// WithTable 注册表的加密配置
+func WithTable(name string, table *Table) Option {
+	return func(e *Encryptor) {
+		e.tables[name] = table
+	}
+}
+
+// WithPasswordHasher 设置 RowIrreversibleEncrypt 字段的哈希 默认使用 argon2id
+func WithPasswordHasher(hasher *secret.PasswordHasher) Option {
+	return func(e *Encryptor) {
+		e.hasher = hasher
+	}
+}
+
+// WithBlindIndexKey 设置盲索引的密钥 默认使用 secret.DeriveBlindIndexKey 从表的旧密钥(见 Encryptor.Key)派生
+// 盲索引不记录密钥 ID, 更换密钥后需重建已有数据的盲索引, 建议单独设置不随加密密钥轮换
+func WithBlindIndexKey(key string) Option {
+	return func(e *Encryptor) {
+		e.bidxKey = key
+	}
+}
+
+// WithKeyring 设置密钥环 默认使用 Data.secret 与 Data.secrets 创建
+func WithKeyring(keyring *secret.Keyring) Option {
+	return func(e *Encryptor) {
+		e.keyring = keyring
+	}
+}
+
+// Encryptor 字段加解密器
+type Encryptor struct {
+	keyring *secret.Keyring
+	tables  map[string]*Table
+	hasher  *secret.PasswordHasher
+	bidxKey string
+	log     *log.Helper
+	err     error
+}
+
+// New 创建字段加解密器 密钥来自 Data.secrets 与 Data.secret
+func New(data *config.Data, logger log.Logger, opts ...Option) *Encryptor {
+	e := &Encryptor{
+		tables: map[string]*Table{},
+		hasher: secret.NewPasswordHasher(),
+		log:    log.NewHelper(log.With(logger, "layer", "encrypt")),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.err != nil {
+		e.log.Fatal(e.err)
+	}
+	if e.keyring == nil {
+		keyring, err := secret.NewKeyring(context.Background(), []secret.KeySource{secret.FromConfig(data)})
+		if err != nil {
+			e.log.Fatalf("加载密钥失败: %v", err)
+		}
+		e.keyring = keyring
+	}
+	return e
+}
+
+// Table 获取表的加密配置
+func (e *Encryptor) Table(name string) (*Table, bool) {
+	t, ok := e.tables[name]
+	return t, ok
+}
+
+// Tables 获取所有表的加密配置
+func (e *Encryptor) Tables() map[string]*Table {
+	return e.tables
+}
+
+// Key 获取表的旧密钥 用于未记录密钥 ID 的旧 CBC 密文与旧的密码哈希
+// 优先使用 ann.Table.SecretKey 对应的密钥, 否则使用 DefaultKeyID 即 Data.secret
+func (e *Encryptor) Key(table string) (string, error) {
+	if t, ok := e.tables[table]; ok && t.SecretKey != "" {
+		if key, ok := e.keyring.Key(t.SecretKey); ok && key.Status != secret.KeyRetired {
+			return key.Secret, nil
+		}
+	}
+	if key, ok := e.keyring.Key(secret.DefaultKeyID); ok && key.Status != secret.KeyRetired {
+		return key.Secret, nil
+	}
+	return "", fmt.Errorf("encrypt: secret of table %s is not configured", table)
+}
+
+// keyID 表用于加密的密钥 ID ann.Table.SecretKey 对应的密钥为 active 时使用该密钥, 否则使用密钥环的 active 密钥
+func (e *Encryptor) keyID(table string) string {
+	if t, ok := e.tables[table]; ok && t.SecretKey != "" {
+		if key, ok := e.keyring.Key(t.SecretKey); ok && key.Status == secret.KeyActive {
+			return t.SecretKey
+		}
+	}
+	return e.keyring.ActiveID()
+}
+
+// Encrypt 使用表的密钥加密 密文中记录密钥 ID
+func (e *Encryptor) Encrypt(table, str string) (string, error) {
+	return e.keyring.EncryptWithKey(e.keyID(table), str)
+}
+
+// Decrypt 解密 Envelope 格式的密文使用其记录的密钥 ID 对应的密钥, 旧的 CBC 密文使用表的旧密钥
+func (e *Encryptor) Decrypt(table, str string) (string, error) {
+	if secret.IsEnvelope(str) {
+		return e.keyring.Decrypt(str)
+	}
+	key, err := e.Key(table)
+	if err != nil {
+		return "", err
+	}
+	return secret.Decrypt(str, key)
+}
+
+// NeedReEncrypt 判断密文是否需要使用表当前的密钥重新加密
+func (e *Encryptor) NeedReEncrypt(table, str string) bool {
+	return e.keyring.NeedReEncrypt(str, e.keyID(table))
+}
+
+// ReEncrypt 使用表当前的密钥重新加密
+func (e *Encryptor) ReEncrypt(table, str string) (string, error) {
+	plain, err := e.Decrypt(table, str)
+	if err != nil {
+		return "", err
+	}
+	return e.Encrypt(table, plain)
+}
+
+// BlindIndex 计算字段明文的盲索引 字段未配置盲索引时返回错误
+func (e *Encryptor) BlindIndex(table, field, str string) (string, error) {
+	t, ok := e.tables[table]
+	if !ok || t.BlindIndex[field] <= 0 {
+		return "", fmt.Errorf("encrypt: %s.%s has no blind index", table, field)
+	}
+	key, err := e.blindIndexKey(table)
+	if err != nil {
+		return "", err
+	}
+	return secret.BlindIndex(str, key, t.BlindIndex[field]), nil
+}
+
+// BlindIndexQuery 获取字段明文的等值或 IN 查询 转换为对盲索引列的查询
+// 截断的盲索引存在误匹配, 查询结果需解密后再次比对
+func (e *Encryptor) BlindIndexQuery(table, field string, values ...string) (func(s *sql.Selector), error) {
+	t, ok := e.tables[table]
+	if !ok || t.BlindIndex[field] <= 0 {
+		return nil, fmt.Errorf("encrypt: %s.%s has no blind index", table, field)
+	}
+	key, err := e.blindIndexKey(table)
+	if err != nil {
+		return nil, err
+	}
+	return customsql.GetBlindIndexQuery(t.Column(field+BidxSuffix), key, t.BlindIndex[field], values...), nil
+}
+
+// blindIndexKey 盲索引的密钥 未设置 WithBlindIndexKey 时从表的旧密钥派生, 不直接使用加密的密钥
+func (e *Encryptor) blindIndexKey(table string) (string, error) {
+	if e.bidxKey != "" {
+		return e.bidxKey, nil
+	}
+	key, err := e.Key(table)
+	if err != nil {
+		return "", err
+	}
+	return secret.DeriveBlindIndexKey(key), nil
+}
+
+// Verify 验证不可逆加密的字段 如密码
+// nonce 不为空时为旧的 nonce/sha256 方案, 验证成功后应通过 NeedsRehash 判断并重新设置明文以升级哈希
+func (e *Encryptor) Verify(table, str, cipher, nonce string) bool {
+	if nonce == "" {
+		return e.hasher.Verify(str, cipher, "")
+	}
+	key, err := e.Key(table)
+	if err != nil {
+		return false
+	}
+	return secret.VerifyIrreversible(cipher, str, nonce, key)
+}
+
+// NeedsRehash 判断不可逆加密字段的哈希是否需要升级
+func (e *Encryptor) NeedsRehash(cipher string) bool {
+	return e.hasher.NeedsRehash(cipher)
+}
+
+// structField 获取 ent 生成的实体中字段名对应的值 生成的字段名为大驼峰 如 secretKey -> SecretKey
+func structField(v reflect.Value, name string) (reflect.Value, bool) {
+	f := v.FieldByNameFunc(func(n string) bool { return strings.EqualFold(n, name) })
+	if !f.IsValid() || !f.CanSet() {
+		return f, false
+	}
+	return f, true
+}
@@ -0,0 +1,188 @@
+package encrypt
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/config"
+	"github.com/yimoka/go/ent/ann"
+	"github.com/yimoka/go/ent/mixin"
+	"github.com/yimoka/go/internal/entfixture"
+	fixture "github.com/yimoka/go/internal/entfixture/schema"
+	"github.com/yimoka/go/secret"
+	"github.com/yimoka/go/tenancy"
+	"github.com/yimoka/go/utils"
+)
+
+type User struct {
+	ent.Schema
+}
+
+func (User) Fields() []ent.Field {
+	return []ent.Field{field.String("name")}
+}
+
+func (User) Mixin() []ent.Mixin {
+	return []ent.Mixin{mixin.Phone{}, mixin.Password{}, mixin.Secret{}}
+}
+
+func (User) Annotations() []schema.Annotation {
+	return []schema.Annotation{ann.Table{SecretKey: "user"}}
+}
+
+const (
+	defaultSecret = "0123456789abcdef"
+	userSecret    = "fedcba9876543210"
+	appSecret     = "0011223344556677"
+)
+
+func newEncryptor() *Encryptor {
+	data := &config.Data{Secret: defaultSecret, Secrets: map[string]string{"user": userSecret}}
+	hasher := secret.NewPasswordHasher(secret.WithArgon2Params(1, 1024, 1))
+	return New(data, log.DefaultLogger, WithSchemas(User{}), WithPasswordHasher(hasher))
+}
+
+func TestFromSchema(t *testing.T) {
+	table, err := FromSchema(User{})
+	assert.NoError(t, err)
+	assert.Equal(t, "users", table.DBTable)
+	assert.Equal(t, []string{"phoneCipher", "secretKey"}, table.CipherFields())
+	assert.Equal(t, "user", table.SecretKey)
+	assert.Equal(t, []string{"secretKey"}, table.Encrypt)
+	assert.Equal(t, map[string]utils.MaskType{"phone": utils.MaskTypePhone}, table.MaskEncrypt)
+	assert.Equal(t, []string{"password"}, table.RowIrreversibleEncrypt)
+	assert.Equal(t, "phone_cipher", table.Column("phoneCipher"))
+	assert.Equal(t, "secret_key", table.Column("secretKey"))
+
+	e := newEncryptor()
+	key, err := e.Key("User")
+	assert.NoError(t, err)
+	assert.Equal(t, userSecret, key)
+	key, err = e.Key("Other")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultSecret, key)
+}
+
+func TestHookAndInterceptor(t *testing.T) {
+	data := &config.Data{Secret: defaultSecret, Secrets: map[string]string{"user": userSecret, "app": appSecret}}
+	hasher := secret.NewPasswordHasher(secret.WithArgon2Params(1, 1024, 1))
+	e := New(data, log.DefaultLogger, WithSchemas(fixture.User{}, fixture.App{}), WithPasswordHasher(hasher))
+	client, drv := entfixture.NewClient()
+	client.Use(e.Hook())
+	client.Intercept(e.Interceptor())
+	ctx := tenancy.NewContext(context.Background(), "t1")
+
+	assert.NoError(t, client.User.Create().SetName("n").SetPhone("13800138000").SetPassword("123456").Exec(ctx))
+	stmt, _ := drv.Last("INSERT")
+	values := stmt.Values()
+	assert.Equal(t, "n", values["name"])
+	assert.Equal(t, "138****8000", values["phone"])
+	assert.Equal(t, "", values["password_nonce"])
+	password := values["password"].(string)
+	assert.True(t, e.Verify("User", "123456", password, ""))
+	assert.False(t, e.NeedsRehash(password))
+	nonce, legacy := secret.IrreversibleEncrypt("123456", userSecret)
+	assert.True(t, e.Verify("User", "123456", legacy, nonce))
+	assert.True(t, e.NeedsRehash(legacy))
+	phoneCipher := values["phone_cipher"].(string)
+	plain, err := secret.Decrypt(phoneCipher, userSecret)
+	assert.NoError(t, err)
+	assert.Equal(t, "13800138000", plain)
+	assert.False(t, secret.NeedReEncrypt(phoneCipher, secret.AlgAES256GCM, "user"))
+
+	assert.NoError(t, client.App.Create().SetSecretID("si").SetSecretKey("sk").Exec(ctx))
+	stmt, _ = drv.Last("INSERT")
+	secretKey := stmt.Values()["secret_key"].(string)
+	assert.NotEqual(t, "sk", secretKey)
+	plain, err = secret.Decrypt(secretKey, appSecret)
+	assert.NoError(t, err)
+	assert.Equal(t, "sk", plain)
+
+	// 回传的掩码不更新密文
+	drv.AddRows([]string{"id"}, []any{int64(1)})
+	assert.NoError(t, client.User.UpdateOneID(1).SetPhone("138****8000").Exec(ctx))
+	stmt, _ = drv.Last("UPDATE")
+	_, ok := stmt.Values()["phone_cipher"]
+	assert.False(t, ok)
+
+	drv.AddRows([]string{"id", "phone", "phone_cipher"}, []any{int64(1), "138****8000", phoneCipher})
+	users, err := client.User.Query().All(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "138****8000", users[0].Phone)
+	assert.Equal(t, "13800138000", users[0].PhoneCipher)
+	drv.AddRows([]string{"id", "secret_key"}, []any{int64(1), secretKey})
+	app, err := client.App.Query().First(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "sk", app.SecretKey)
+
+	// 兼容旧的 CBC 密文
+	legacyCipher, err := secret.Encrypt("sk", userSecret)
+	assert.NoError(t, err)
+	plain, err = e.Decrypt("User", legacyCipher)
+	assert.NoError(t, err)
+	assert.Equal(t, "sk", plain)
+}
+
+func TestBlindIndex(t *testing.T) {
+	e := New(&config.Data{Secret: defaultSecret}, log.DefaultLogger, WithSchemas(fixture.Contact{}))
+	table, ok := e.Table("Contact")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]int{"phone": 16}, table.BlindIndex)
+	client, drv := entfixture.NewClient()
+	client.Use(e.Hook())
+	ctx := context.Background()
+
+	// 盲索引的密钥由加密的密钥派生
+	bidxKey := secret.DeriveBlindIndexKey(defaultSecret)
+	assert.NoError(t, client.Contact.Create().SetTenantID("t1").SetPhone("13800138000").Exec(ctx))
+	stmt, _ := drv.Last("INSERT")
+	index := stmt.Values()["phone_bidx"]
+	assert.Equal(t, secret.BlindIndex("13800138000", bidxKey, 16), index)
+	assert.NotEqual(t, secret.BlindIndex("13800138000", defaultSecret, 16), index)
+	assert.Len(t, index, 16)
+
+	// 回传的掩码不更新盲索引
+	drv.AddRows([]string{"id"}, []any{int64(1)})
+	assert.NoError(t, client.Contact.UpdateOneID(1).SetPhone("138****8000").Exec(ctx))
+	stmt, _ = drv.Last("UPDATE")
+	_, ok = stmt.Values()["phone_bidx"]
+	assert.False(t, ok)
+
+	where, err := e.BlindIndexQuery("Contact", "phone", "13800138000")
+	assert.NoError(t, err)
+	s := sql.Select("*").From(sql.Table("contacts"))
+	where(s)
+	query, args := s.Query()
+	assert.Equal(t, "SELECT * FROM `contacts` WHERE `contacts`.`phone_bidx` = ?", query)
+	assert.Equal(t, []any{index}, args)
+
+	where, err = e.BlindIndexQuery("Contact", "phone", "13800138000", "13800138001")
+	assert.NoError(t, err)
+	s = sql.Select("*").From(sql.Table("contacts"))
+	where(s)
+	query, args = s.Query()
+	assert.Equal(t, "SELECT * FROM `contacts` WHERE `contacts`.`phone_bidx` IN (?, ?)", query)
+	assert.Len(t, args, 2)
+
+	// 没有值时不匹配任何数据
+	where, err = e.BlindIndexQuery("Contact", "phone")
+	assert.NoError(t, err)
+	s = sql.Select("*").From(sql.Table("contacts"))
+	where(s)
+	query, _ = s.Query()
+	assert.Equal(t, "SELECT * FROM `contacts` WHERE FALSE", query)
+
+	_, err = e.BlindIndexQuery("Contact", "phoneCipher", "x")
+	assert.Error(t, err)
+
+	k := New(&config.Data{Secret: defaultSecret}, log.DefaultLogger, WithSchemas(fixture.Contact{}), WithBlindIndexKey("bidx"))
+	index, err = k.BlindIndex("Contact", "phone", "13800138000")
+	assert.NoError(t, err)
+	assert.Equal(t, secret.BlindIndex("13800138000", "bidx", 16), index)
+}
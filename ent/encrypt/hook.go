@@ -0,0 +1,188 @@
+// Package encrypt hook
+package encrypt
+
+import (
+	"context"
+	"reflect"
+
+	"entgo.io/ent"
+	"github.com/yimoka/go/utils"
+)
+
+// Hook 写入时加密字段
+// Encrypt 字段存储密文; MaskEncrypt 字段存储掩码, 密文存入字段名 + Cipher; RowIrreversibleEncrypt 字段存储哈希, nonce 存入字段名 + Nonce
+// 配置了盲索引的字段 盲索引存入字段名 + Bidx
+// 生成的 Mutation 的 Field 与 SetField 使用数据库列名, 字段名通过 Table.Column 转换 如 phoneCipher -> phone_cipher
+func (e *Encryptor) Hook() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			table, ok := e.tables[m.Type()]
+			if !ok || !m.Op().Is(ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne) {
+				return next.Mutate(ctx, m)
+			}
+			if err := e.encryptMutation(m, table); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+}
+
+func (e *Encryptor) encryptMutation(m ent.Mutation, table *Table) error {
+	// 盲索引需在加密前使用明文计算
+	if err := e.blindIndexMutation(m, table); err != nil {
+		return err
+	}
+	for _, name := range table.Encrypt {
+		str, ok := stringField(m, table.Column(name))
+		if !ok || str == "" {
+			continue
+		}
+		cipher, err := e.Encrypt(m.Type(), str)
+		if err != nil {
+			return err
+		}
+		if err := m.SetField(table.Column(name), cipher); err != nil {
+			return err
+		}
+	}
+	for name, maskType := range table.MaskEncrypt {
+		str, ok := stringField(m, table.Column(name))
+		if !ok {
+			continue
+		}
+		// 值为掩码时为未修改的回传值 不更新密文
+		masked := utils.Mask(str, maskType)
+		if str != "" && masked == str {
+			continue
+		}
+		cipher := ""
+		if str != "" {
+			var err error
+			if cipher, err = e.Encrypt(m.Type(), str); err != nil {
+				return err
+			}
+		}
+		if err := m.SetField(table.Column(name+CipherSuffix), cipher); err != nil {
+			return err
+		}
+		if err := m.SetField(table.Column(name), masked); err != nil {
+			return err
+		}
+	}
+	for _, name := range table.RowIrreversibleEncrypt {
+		str, ok := stringField(m, table.Column(name))
+		if !ok || str == "" {
+			continue
+		}
+		hash, err := e.hasher.Hash(str)
+		if err != nil {
+			return err
+		}
+		if err := m.SetField(table.Column(name), hash); err != nil {
+			return err
+		}
+		// PHC 格式的哈希自带盐 清空旧方案的 nonce
+		if err := m.SetField(table.Column(name+NonceSuffix), ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Encryptor) blindIndexMutation(m ent.Mutation, table *Table) error {
+	for name := range table.BlindIndex {
+		str, ok := stringField(m, table.Column(name))
+		if !ok {
+			continue
+		}
+		// MaskEncrypt 字段的值为掩码时为未修改的回传值 不更新盲索引
+		if maskType, ok := table.MaskEncrypt[name]; ok && str != "" && utils.Mask(str, maskType) == str {
+			continue
+		}
+		index := ""
+		if str != "" {
+			var err error
+			if index, err = e.BlindIndex(m.Type(), name, str); err != nil {
+				return err
+			}
+		}
+		if err := m.SetField(table.Column(name+BidxSuffix), index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func stringField(m ent.Mutation, name string) (string, bool) {
+	v, ok := m.Field(name)
+	if !ok {
+		return "", false
+	}
+	str, ok := v.(string)
+	return str, ok
+}
+
+// Interceptor 读取时解密字段 按字段名查找实体的字段
+// Encrypt 字段解密为原文; MaskEncrypt 字段保持掩码, 字段名 + Cipher 解密为原文; RowIrreversibleEncrypt 字段不处理
+// 解密失败(如历史的明文数据)时保留原值并记录日志
+func (e *Encryptor) Interceptor() ent.Interceptor {
+	return ent.InterceptFunc(func(next ent.Querier) ent.Querier {
+		return ent.QuerierFunc(func(ctx context.Context, q ent.Query) (ent.Value, error) {
+			v, err := next.Query(ctx, q)
+			if err != nil {
+				return v, err
+			}
+			qc := ent.QueryFromContext(ctx)
+			if qc == nil {
+				return v, nil
+			}
+			if table, ok := e.tables[qc.Type]; ok {
+				e.decryptValue(ctx, qc.Type, table, reflect.ValueOf(v))
+			}
+			return v, nil
+		})
+	})
+}
+
+// decryptValue 解密查询结果 支持 *T 与 []*T
+func (e *Encryptor) decryptValue(ctx context.Context, tableName string, table *Table, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			e.decryptValue(ctx, tableName, table, v.Index(i))
+		}
+	case reflect.Ptr:
+		if v.IsNil() || v.Elem().Kind() != reflect.Struct {
+			return
+		}
+		for _, name := range table.Encrypt {
+			e.decryptField(ctx, tableName, v.Elem(), name)
+		}
+		for name := range table.MaskEncrypt {
+			e.decryptField(ctx, tableName, v.Elem(), name+CipherSuffix)
+		}
+	}
+}
+
+func (e *Encryptor) decryptField(ctx context.Context, tableName string, v reflect.Value, name string) {
+	f, ok := structField(v, name)
+	if !ok {
+		return
+	}
+	if f.Kind() == reflect.Ptr {
+		if f.IsNil() {
+			return
+		}
+		f = f.Elem()
+	}
+	if f.Kind() != reflect.String || f.String() == "" {
+		return
+	}
+	plain, err := e.Decrypt(tableName, f.String())
+	if err != nil {
+		e.log.WithContext(ctx).Warnf("encrypt: decrypt %s.%s error: %v", tableName, name, err)
+		return
+	}
+	f.SetString(plain)
+}
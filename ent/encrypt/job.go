@@ -0,0 +1,211 @@
+// Package encrypt job
+package encrypt
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+)
+
+// JobOption 重新加密任务的配置
+type JobOption func(*ReEncryptJob)
+
+// WithBatchSize 每批处理的行数 默认 200
+func WithBatchSize(size int) JobOption {
+	return func(j *ReEncryptJob) {
+		if size > 0 {
+			j.batchSize = size
+		}
+	}
+}
+
+// WithInterval 后台运行时每轮的间隔 默认 1 小时
+func WithInterval(interval time.Duration) JobOption {
+	return func(j *ReEncryptJob) {
+		if interval > 0 {
+			j.interval = interval
+		}
+	}
+}
+
+// ReEncryptJob 重新加密任务 遍历已注册的表, 将非当前密钥加密的密文使用当前密钥重新加密
+// 直接通过 SQL 读写, 不经过 Hook, 更新时比较原密文, 避免覆盖并发写入的数据
+// 实现了 kratos 的 transport.Server 可注册到 kratos.App 中后台运行
+type ReEncryptJob struct {
+	encryptor *Encryptor
+	drv       dialect.Driver
+	batchSize int
+	interval  time.Duration
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewReEncryptJob 创建重新加密任务
+func (e *Encryptor) NewReEncryptJob(drv dialect.Driver, opts ...JobOption) *ReEncryptJob {
+	j := &ReEncryptJob{
+		encryptor: e,
+		drv:       drv,
+		batchSize: 200,
+		interval:  time.Hour,
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+	return j
+}
+
+// Start 在后台按间隔运行 直到调用 Stop
+func (j *ReEncryptJob) Start(ctx context.Context) error {
+	j.mu.Lock()
+	ctx, j.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	j.done = make(chan struct{})
+	j.mu.Unlock()
+	go func() {
+		defer close(j.done)
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+		for {
+			if _, err := j.Run(ctx); err != nil && ctx.Err() == nil {
+				j.encryptor.log.WithContext(ctx).Errorf("encrypt: re-encrypt error: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop 停止后台运行并等待当前批次完成
+func (j *ReEncryptJob) Stop(_ context.Context) error {
+	j.mu.Lock()
+	cancel, done := j.cancel, j.done
+	j.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	<-done
+	return nil
+}
+
+// Run 执行一轮 返回重新加密的字段数
+func (j *ReEncryptJob) Run(ctx context.Context) (int, error) {
+	names := make([]string, 0, len(j.encryptor.tables))
+	for name := range j.encryptor.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	total := 0
+	for _, name := range names {
+		n, err := j.runTable(ctx, name, j.encryptor.tables[name])
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// runTable 按 ID 顺序分批处理一张表
+func (j *ReEncryptJob) runTable(ctx context.Context, name string, table *Table) (int, error) {
+	fields := table.CipherFields()
+	if len(fields) == 0 || table.DBTable == "" {
+		return 0, nil
+	}
+	idColumn := table.Column("id")
+	columns := []string{idColumn}
+	for _, f := range fields {
+		columns = append(columns, table.Column(f))
+	}
+	total := 0
+	var lastID interface{}
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		batch, err := j.queryBatch(ctx, table.DBTable, columns, lastID)
+		if err != nil {
+			return total, err
+		}
+		for _, row := range batch {
+			lastID = row[0]
+			for i, f := range fields {
+				old, ok := row[i+1].(string)
+				if !ok || old == "" || !j.encryptor.NeedReEncrypt(name, old) {
+					continue
+				}
+				cipher, err := j.encryptor.ReEncrypt(name, old)
+				if err != nil {
+					j.encryptor.log.WithContext(ctx).Warnf("encrypt: re-encrypt %s.%s of %v error: %v", name, f, lastID, err)
+					continue
+				}
+				query, args := sql.Dialect(j.drv.Dialect()).
+					Update(table.DBTable).
+					Set(columns[i+1], cipher).
+					Where(sql.And(sql.EQ(idColumn, lastID), sql.EQ(columns[i+1], old))).
+					Query()
+				var res sql.Result
+				if err := j.drv.Exec(ctx, query, args, &res); err != nil {
+					return total, err
+				}
+				total++
+			}
+		}
+		if len(batch) < j.batchSize {
+			return total, nil
+		}
+	}
+}
+
+// queryBatch 查询 ID 大于 lastID 的一批数据 ID 为原值, 密文为 string 或 nil
+func (j *ReEncryptJob) queryBatch(ctx context.Context, dbTable string, columns []string, lastID interface{}) ([][]interface{}, error) {
+	selector := sql.Dialect(j.drv.Dialect()).
+		Select(columns...).
+		From(sql.Table(dbTable)).
+		OrderBy(columns[0]).
+		Limit(j.batchSize)
+	if lastID != nil {
+		selector.Where(sql.GT(columns[0], lastID))
+	}
+	query, args := selector.Query()
+	rows := &sql.Rows{}
+	if err := j.drv.Query(ctx, query, args, rows); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	batch := [][]interface{}{}
+	for rows.Next() {
+		var id interface{}
+		values := make([]sql.NullString, len(columns)-1)
+		dest := []interface{}{&id}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		// 部分驱动以 []byte 返回字符串类型的 ID
+		if b, ok := id.([]byte); ok {
+			id = string(b)
+		}
+		row := []interface{}{id}
+		for _, v := range values {
+			if v.Valid {
+				row = append(row, v.String)
+			} else {
+				row = append(row, nil)
+			}
+		}
+		batch = append(batch, row)
+	}
+	return batch, rows.Err()
+}
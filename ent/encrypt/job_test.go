@@ -0,0 +1,99 @@
+package encrypt
+
+import (
+	"context"
+	stdsql "database/sql"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/config"
+	"github.com/yimoka/go/secret"
+)
+
+// memDriver 以内存模拟 users 表 只支持重新加密任务的查询与更新
+type memDriver struct {
+	dialect.Driver
+	ids     []int64
+	ciphers map[int64]string
+}
+
+func (d *memDriver) Dialect() string { return dialect.MySQL }
+
+func (d *memDriver) Query(_ context.Context, _ string, args, v any) error {
+	var lastID int64
+	if a := args.([]any); len(a) > 0 {
+		lastID = a[0].(int64)
+	}
+	rows := &memRows{i: -1}
+	for _, id := range d.ids {
+		if id > lastID && len(rows.data) < 2 {
+			rows.data = append(rows.data, []any{id, d.ciphers[id]})
+		}
+	}
+	*v.(*sql.Rows) = sql.Rows{ColumnScanner: rows}
+	return nil
+}
+
+func (d *memDriver) Exec(_ context.Context, _ string, args, _ any) error {
+	a := args.([]any)
+	if id := a[1].(int64); d.ciphers[id] == a[2] {
+		d.ciphers[id] = a[0].(string)
+	}
+	return nil
+}
+
+type memRows struct {
+	sql.ColumnScanner
+	data [][]any
+	i    int
+}
+
+func (r *memRows) Next() bool   { r.i++; return r.i < len(r.data) }
+func (r *memRows) Close() error { return nil }
+func (r *memRows) Err() error   { return nil }
+func (r *memRows) Scan(dest ...any) error {
+	*dest[0].(*any) = r.data[r.i][0]
+	// 只查询 phoneCipher 与 secretKey 两列 secretKey 为空
+	*dest[1].(*stdsql.NullString) = stdsql.NullString{String: r.data[r.i][1].(string), Valid: true}
+	*dest[2].(*stdsql.NullString) = stdsql.NullString{}
+	return nil
+}
+
+func TestReEncryptJob(t *testing.T) {
+	ctx := context.Background()
+	data := &config.Data{Secret: defaultSecret, Secrets: map[string]string{"user": userSecret, "user2": "another-user-secret"}}
+	old := New(data, log.DefaultLogger, WithSchemas(User{}))
+
+	drv := &memDriver{ids: []int64{1, 2, 3}, ciphers: map[int64]string{}}
+	for _, id := range drv.ids {
+		cipher, err := old.Encrypt("User", "1380013800"+string(rune('0'+id)))
+		assert.NoError(t, err)
+		drv.ciphers[id] = cipher
+	}
+	legacy, err := secret.Encrypt("13800138003", userSecret)
+	assert.NoError(t, err)
+	drv.ciphers[3] = legacy
+
+	// 轮换 user 密钥为仅解密 使用 user2 加密
+	keyring, err := secret.NewKeyring(ctx, []secret.KeySource{secret.FromConfig(data)},
+		secret.WithKeyStatus("user", secret.KeyDecryptOnly), secret.WithActiveKey("user2"))
+	assert.NoError(t, err)
+	e := New(data, log.DefaultLogger, WithSchemas(User{}), WithKeyring(keyring))
+
+	n, err := e.NewReEncryptJob(drv, WithBatchSize(2)).Run(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	for _, id := range drv.ids {
+		assert.False(t, e.NeedReEncrypt("User", drv.ciphers[id]))
+		plain, err := e.Decrypt("User", drv.ciphers[id])
+		assert.NoError(t, err)
+		assert.Equal(t, "1380013800"+string(rune('0'+id)), plain)
+	}
+
+	n, err = e.NewReEncryptJob(drv, WithBatchSize(2)).Run(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
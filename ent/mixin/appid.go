@@ -7,6 +7,7 @@ import (
 	"entgo.io/ent/schema/index"
 	"entgo.io/ent/schema/mixin"
 	"github.com/yimoka/go/ent/ann"
+	"github.com/yimoka/go/tenancy"
 )
 
 // Appid mixin 通常用于 sass 场景中标识不同的 app
@@ -36,7 +37,24 @@ func (Appid) Index() []ent.Index {
 	}
 }
 
+// AppidColumn appid 字段的数据库列名 即生成代码中的 FieldAppid
+const AppidColumn = "appid"
+
+// Interceptors 查询时自动添加应用条件, 应用与租户相互独立, 由 tenancy.WithAppResolvers 解析或 tenancy.NewAppContext 设置
+func (Appid) Interceptors() []ent.Interceptor {
+	return []ent.Interceptor{tenancy.AppInterceptor(AppidColumn)}
+}
+
+// Hooks 创建时自动设置应用, 更新与删除时自动添加应用条件
+func (Appid) Hooks() []ent.Hook {
+	return []ent.Hook{tenancy.AppHook(AppidColumn)}
+}
+
 // AppidOnlyPortal mixin 通常用于 sass 场景中标识不同的 app
+// 与 TenantIDOnlyPortal 相同不注册应用隔离的 Interceptor 与 Hook, portal 服务需在 Client 上自行注册 如:
+//
+//	client.Article.Intercept(tenancy.AppInterceptor(mixin.AppidColumn))
+//	client.Article.Use(tenancy.AppHook(mixin.AppidColumn))
 type AppidOnlyPortal struct {
 	mixin.Schema
 }
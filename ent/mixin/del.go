@@ -2,21 +2,35 @@
 package mixin
 
 import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
 	"entgo.io/ent/schema/mixin"
 	"github.com/yimoka/go/ent/ann"
+	"github.com/yimoka/go/ent/customsql"
+	"github.com/yimoka/go/middleware/meta"
 )
 
 // Del mixin 软删除
+// 查询与更新时自动过滤已删除的数据, 删除时转为将 del 设为 true 的更新
+// 恢复数据(data.OpRecover)与物理删除(data.OpDel)需使用 SkipSoftDelete 的 ctx
 type Del struct {
 	mixin.Schema
+	// 删除时记录删除时间 deleteTime
+	WithTime bool
+	// 删除时记录删除人 deleter 优先取员工 ID 其次取用户 ID
+	WithOperator bool
 }
 
 // Fields _
-func (Del) Fields() []ent.Field {
-	return []ent.Field{
+func (d Del) Fields() []ent.Field {
+	fields := []ent.Field{
 		field.Bool("del").
 			Default(false).
 			Comment("软删除").
@@ -26,6 +40,29 @@ func (Del) Fields() []ent.Field {
 				OnlyData:        true,
 			}),
 	}
+	if d.WithTime {
+		fields = append(fields, field.Time("deleteTime").
+			Comment("删除时间").
+			SchemaType(map[string]string{"mysql": "datetime"}).
+			Optional().
+			Nillable().
+			Annotations(ann.Field{
+				PbIndex:      223,
+				OnlyData:     true,
+				PBTimeToType: ann.PBTimeTypeSecond,
+			}))
+	}
+	if d.WithOperator {
+		fields = append(fields, field.String("deleter").
+			Comment("删除人").
+			MaxLen(15).
+			Optional().
+			Annotations(ann.Field{
+				PbIndex:  224,
+				OnlyData: true,
+			}))
+	}
+	return fields
 }
 
 // Index _
@@ -34,3 +71,102 @@ func (Del) Index() []ent.Index {
 		index.Fields("del"),
 	}
 }
+
+// Interceptors 查询时自动过滤已删除的数据
+func (Del) Interceptors() []ent.Interceptor {
+	return []ent.Interceptor{
+		ent.TraverseFunc(func(ctx context.Context, q ent.Query) error {
+			if IsSkipSoftDelete(ctx) {
+				return nil
+			}
+			return customsql.WhereP(q, sql.FieldEQ("del", false))
+		}),
+	}
+}
+
+// Hooks 删除时转为软删除 更新时过滤已删除的数据
+func (d Del) Hooks() []ent.Hook {
+	return []ent.Hook{
+		func(next ent.Mutator) ent.Mutator {
+			return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+				if IsSkipSoftDelete(ctx) || m.Op().Is(ent.OpCreate) {
+					return next.Mutate(ctx, m)
+				}
+				mx, ok := m.(softDelMutation)
+				if !ok {
+					return nil, fmt.Errorf("mixin: unexpected mutation type %T", m)
+				}
+				mx.WhereP(sql.FieldEQ("del", false))
+				if !m.Op().Is(ent.OpDelete | ent.OpDeleteOne) {
+					return next.Mutate(ctx, m)
+				}
+				if err := d.setDeleted(ctx, mx); err != nil {
+					return nil, err
+				}
+				mx.SetOp(ent.OpUpdate)
+				return mutateByClient(ctx, mx)
+			})
+		},
+	}
+}
+
+// setDeleted 设置软删除相关的字段 生成的 Mutation 的 SetField 使用数据库列名
+func (d Del) setDeleted(ctx context.Context, m ent.Mutation) error {
+	if err := m.SetField("del", true); err != nil {
+		return err
+	}
+	if d.WithTime {
+		if err := m.SetField("delete_time", time.Now()); err != nil {
+			return err
+		}
+	}
+	if d.WithOperator {
+		operator, _ := meta.GetStaffID(ctx)
+		if operator == "" {
+			operator, _ = meta.GetUserID(ctx)
+		}
+		if operator != "" {
+			if err := m.SetField("deleter", operator); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// softDelMutation 生成的 Mutation 均实现了该接口
+type softDelMutation interface {
+	ent.Mutation
+	SetOp(ent.Op)
+	WhereP(...func(*sql.Selector))
+}
+
+// mutateByClient 通过 Mutation 所属的 Client 重新执行 Mutation
+// 生成的 Mutation 的 Client 方法返回具体的 *Client 类型 所以只能通过反射获取
+func mutateByClient(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	method := reflect.ValueOf(m).MethodByName("Client")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil, fmt.Errorf("mixin: mutation %T has no Client method", m)
+	}
+	client, ok := method.Call(nil)[0].Interface().(interface {
+		Mutate(context.Context, ent.Mutation) (ent.Value, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("mixin: client of mutation %T has no Mutate method", m)
+	}
+	return client.Mutate(ctx, m)
+}
+
+type skipSoftDeleteKey struct{}
+
+// SkipSoftDelete 返回跳过软删除处理的 ctx
+// 查询包含已删除的数据 删除为物理删除 用于恢复数据等管理场景
+func SkipSoftDelete(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipSoftDeleteKey{}, true)
+}
+
+// IsSkipSoftDelete 判断 ctx 是否跳过软删除处理
+func IsSkipSoftDelete(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipSoftDeleteKey{}).(bool)
+	return skip
+}
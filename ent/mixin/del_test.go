@@ -0,0 +1,57 @@
+package mixin_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/ent/mixin"
+	"github.com/yimoka/go/internal/entfixture"
+	"github.com/yimoka/go/internal/entfixture/ent/user"
+	"github.com/yimoka/go/middleware/meta"
+	"github.com/yimoka/go/tenancy"
+)
+
+func TestDel(t *testing.T) {
+	client, drv := entfixture.NewClient()
+	ctx := metadata.NewServerContext(tenancy.NewContext(context.Background(), "t1"), metadata.New())
+	ctx = meta.SetUserID(ctx, "u1")
+
+	// 查询与更新时过滤已删除的数据
+	_, err := client.User.Query().All(ctx)
+	assert.NoError(t, err)
+	stmt, _ := drv.Last("SELECT")
+	assert.Contains(t, stmt.Query, "NOT `users`.`del`")
+	_, err = client.User.Update().SetName("n").Save(ctx)
+	assert.NoError(t, err)
+	stmt, _ = drv.Last("UPDATE")
+	assert.Contains(t, stmt.Query, "NOT `users`.`del`")
+
+	// 删除转为软删除
+	n, err := client.User.Delete().Where(user.Name("n")).Exec(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	_, ok := drv.Last("DELETE")
+	assert.False(t, ok)
+	stmt, _ = drv.Last("UPDATE")
+	assert.Contains(t, stmt.Query, "UPDATE `users` SET `del` = ?, `delete_time` = ?, `deleter` = ?,")
+	assert.Equal(t, true, stmt.Args[0])
+	assert.WithinDuration(t, time.Now(), stmt.Args[1].(time.Time), time.Minute)
+	assert.Equal(t, "u1", stmt.Args[2])
+	assert.NoError(t, client.User.DeleteOneID(1).Exec(ctx))
+	_, ok = drv.Last("DELETE")
+	assert.False(t, ok)
+
+	// 跳过软删除 查询包含已删除的数据 删除为物理删除
+	skip := mixin.SkipSoftDelete(ctx)
+	_, err = client.User.Query().All(skip)
+	assert.NoError(t, err)
+	stmt, _ = drv.Last("SELECT")
+	assert.NotContains(t, stmt.Query, "NOT `users`.`del`")
+	_, err = client.User.Delete().Exec(skip)
+	assert.NoError(t, err)
+	stmt, _ = drv.Last("DELETE")
+	assert.Equal(t, "DELETE FROM `users` WHERE `users`.`tenant_id` = ?", stmt.Query)
+}
@@ -0,0 +1,90 @@
+// Package mixin oplog
+package mixin
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"entgo.io/ent/schema/mixin"
+	"github.com/yimoka/go/data"
+	"github.com/yimoka/go/ent/ann"
+	"github.com/yimoka/go/ent/oplog"
+)
+
+// OpLog mixin 操作记录表 字段与 oplog.Entry 对应
+// 配合 CreateTime 使用, 表的注解需设置 ann.Table{IsOpLogTable: true}
+type OpLog struct {
+	mixin.Schema
+}
+
+// Fields _
+func (OpLog) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("table").
+			Comment("表名").
+			MaxLen(64).
+			Immutable().
+			Annotations(ann.Field{
+				PbIndex: 226,
+				Query:   ann.FieldQuery{In: true},
+			}),
+		field.String("rowID").
+			Comment("行 ID").
+			MaxLen(64).
+			Immutable().
+			Annotations(ann.Field{
+				PbIndex: 227,
+				Query:   ann.FieldQuery{In: true},
+			}),
+		field.Enum("opType").
+			Comment("操作类型").
+			Values(data.OpTypeValues()...).
+			Immutable().
+			Annotations(ann.Field{
+				PbIndex: 228,
+				Query:   ann.FieldQuery{In: true, NotIn: true},
+			}),
+		field.String("operator").
+			Comment("操作人").
+			MaxLen(15).
+			Optional().
+			Immutable().
+			Annotations(ann.Field{
+				PbIndex: 229,
+				Query:   ann.FieldQuery{In: true},
+			}),
+		field.String("clientIP").
+			Comment("客户端 IP").
+			MaxLen(45).
+			Optional().
+			Immutable().
+			Annotations(ann.Field{
+				PbIndex: 230,
+			}),
+		field.String("traceID").
+			Comment("链路追踪 ID").
+			MaxLen(32).
+			Optional().
+			Immutable().
+			Annotations(ann.Field{
+				PbIndex: 231,
+			}),
+		field.JSON("changes", []oplog.FieldChange{}).
+			Comment("字段变更 敏感字段已脱敏").
+			Optional().
+			Immutable().
+			Annotations(ann.Field{
+				PbIndex: 232,
+				Query:   ann.FieldQuery{Disabled: true},
+			}),
+	}
+}
+
+// Index _
+func (OpLog) Index() []ent.Index {
+	return []ent.Index{
+		index.Fields("table", "rowID"),
+		index.Fields("operator"),
+		index.Fields("traceID"),
+	}
+}
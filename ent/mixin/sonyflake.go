@@ -2,8 +2,10 @@
 package mixin
 
 import (
+	"crypto/rand"
 	"encoding/base64"
 	"encoding/binary"
+	"fmt"
 	"sync"
 	"time"
 
@@ -13,7 +15,6 @@ import (
 	"entgo.io/ent/schema/mixin"
 	"github.com/sony/sonyflake"
 	"github.com/yimoka/go/ent/ann"
-	"github.com/yimoka/go/utils"
 )
 
 var (
@@ -25,15 +26,38 @@ var (
 )
 
 // getSonyflake 返回单例的 Sonyflake 实例
+// 默认以私有 IP 的低 16 位作为机器 ID, 无法获取私有 IP 时(如容器未分配私有网段)使用随机的机器 ID
 func getSonyflake() *sonyflake.Sonyflake {
 	once.Do(func() {
 		globalSonyflake = sonyflake.NewSonyflake(sonyflake.Settings{
 			StartTime: defaultStartTime,
 		})
+		if globalSonyflake == nil {
+			globalSonyflake = sonyflake.NewSonyflake(sonyflake.Settings{
+				StartTime: defaultStartTime,
+				MachineID: randomMachineID,
+			})
+		}
 	})
 	return globalSonyflake
 }
 
+// randomMachineID 随机的机器 ID
+func randomMachineID() (uint16, error) {
+	buf := make([]byte, 2)
+	if _, err := rand.Read(buf); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint16(buf), nil
+}
+
+// encodeID 将 ID 编码为 8 字节大端序的 base64url(无填充)
+func encodeID(id uint64) string {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, id)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
 // SonyflakeID 实现了基于 Sonyflake 算法的 ID 生成器 mixin.
 type SonyflakeID struct {
 	mixin.Schema
@@ -41,17 +65,18 @@ type SonyflakeID struct {
 
 // GenerateID 生成一个新的 ID。
 // 该函数包含重试机制，在生成失败时最多重试 3 次。
-// 如果所有重试都失败，将返回一个随机字符串作为降级策略。
+// 如果所有重试都失败，将返回一个最高位为 0 的随机 ID 作为降级策略，格式与 Sonyflake ID 相同，可由 utils.IsSonyflakeID 校验。
 func GenerateID() string {
-	sf := getSonyflake()
-	for i := 0; i < 3; i++ {
-		if id, err := sf.NextID(); err == nil {
-			buf := make([]byte, 8)
-			binary.BigEndian.PutUint64(buf, id)
-			return base64.RawURLEncoding.EncodeToString(buf)
+	if sf := getSonyflake(); sf != nil {
+		for i := 0; i < 3; i++ {
+			if id, err := sf.NextID(); err == nil {
+				return encodeID(id)
+			}
 		}
 	}
-	return utils.RandomStr(15)
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	return encodeID(binary.BigEndian.Uint64(buf) &^ (1 << 63))
 }
 
 // Fields 返回 SonyflakeID mixin 的字段定义。
@@ -89,6 +114,9 @@ func ParseID(id string) (time.Time, uint16, uint16, error) {
 	if err != nil {
 		return time.Time{}, 0, 0, err
 	}
+	if len(data) != 8 {
+		return time.Time{}, 0, 0, fmt.Errorf("mixin: %q is not a sonyflake id", id)
+	}
 	sfID := binary.BigEndian.Uint64(data)
 
 	// Sonyflake 位分配:
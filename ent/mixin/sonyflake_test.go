@@ -5,6 +5,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/yimoka/go/utils"
 )
 
 func TestGenerateID(t *testing.T) {
@@ -122,3 +124,15 @@ func TestParseID(t *testing.T) {
 	// 验证序列号范围（移除了多余的范围检查，因为 uint16 类型已经保证了范围）
 	t.Logf("解析结果 - 时间: %v, 机器ID: %d, 序列号: %d", timestamp, machineID, sequence)
 }
+
+func TestIDFormat(t *testing.T) {
+	// 生成的 ID 与降级的随机 ID 均可由 utils.IsSonyflakeID 校验
+	for _, id := range []string{GenerateID(), encodeID(1<<63 - 1)} {
+		if !utils.IsSonyflakeID(id) {
+			t.Errorf("ID 格式错误: %s", id)
+		}
+	}
+	if _, _, _, err := ParseID("AAAA"); err == nil {
+		t.Error("解析长度错误的 ID 应返回错误")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"entgo.io/ent/schema/index"
 	"entgo.io/ent/schema/mixin"
 	"github.com/yimoka/go/ent/ann"
+	"github.com/yimoka/go/tenancy"
 )
 
 // TenantID mixin 通常用于 sass 场景中标识不同的 租户
@@ -36,7 +37,25 @@ func (TenantID) Index() []ent.Index {
 	}
 }
 
+// TenantIDColumn 租户字段的数据库列名 即生成代码中的 FieldTenantID
+const TenantIDColumn = "tenant_id"
+
+// Interceptors 查询时自动添加租户条件, 租户由 tenancy 中间件解析
+func (TenantID) Interceptors() []ent.Interceptor {
+	return []ent.Interceptor{tenancy.Interceptor(TenantIDColumn)}
+}
+
+// Hooks 创建时自动设置租户, 更新与删除时自动添加租户条件
+func (TenantID) Hooks() []ent.Hook {
+	return []ent.Hook{tenancy.Hook(TenantIDColumn)}
+}
+
 // TenantIDOnlyPortal mixin 通常用于 sass 场景中标识不同的 租户
+// 租户只在 portal 服务中使用, 管理服务需跨租户访问, 所以不注册租户隔离的 Interceptor 与 Hook
+// portal 服务需在 Client 上自行注册 如:
+//
+//	client.Article.Intercept(tenancy.Interceptor(mixin.TenantIDColumn))
+//	client.Article.Use(tenancy.Hook(mixin.TenantIDColumn))
 type TenantIDOnlyPortal struct {
 	mixin.Schema
 }
@@ -0,0 +1,123 @@
+// Package mixin version
+package mixin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/yimoka/go/ent/ann"
+	"github.com/yimoka/go/lang"
+)
+
+// VersionConflictReason 版本冲突的错误原因
+const VersionConflictReason = "DATA_CONFLICT"
+
+// Version mixin 乐观锁版本号
+// 更新时若设置了 version (即客户端读取到的版本) 则只更新版本一致的数据并将版本加 1, 版本不一致时返回 VersionConflictError
+// 未设置 version 时不做检查, 仅将版本加 1
+type Version struct {
+	mixin.Schema
+}
+
+// Fields _
+func (Version) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("version").
+			Default(1).
+			NonNegative().
+			Comment("版本号").
+			Annotations(ann.Field{
+				PbIndex:      225,
+				AutoCreate:   true,
+				NotPortalAdd: true,
+				Query:        ann.FieldQuery{Disabled: true},
+			}),
+	}
+}
+
+// Hooks 更新时检查并递增版本号
+func (Version) Hooks() []ent.Hook {
+	return []ent.Hook{
+		func(next ent.Mutator) ent.Mutator {
+			return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+				if !m.Op().Is(ent.OpUpdate | ent.OpUpdateOne) {
+					return next.Mutate(ctx, m)
+				}
+				v, ok := m.Field("version")
+				if !ok {
+					if err := m.AddField("version", 1); err != nil {
+						return nil, err
+					}
+					return next.Mutate(ctx, m)
+				}
+				expected, ok := v.(int)
+				if !ok {
+					return nil, fmt.Errorf("mixin: unexpected version type %T", v)
+				}
+				mx, ok := m.(interface{ WhereP(...func(*sql.Selector)) })
+				if !ok {
+					return nil, fmt.Errorf("mixin: unexpected mutation type %T", m)
+				}
+				mx.WhereP(sql.FieldEQ("version", expected))
+				if err := m.SetField("version", expected+1); err != nil {
+					return nil, err
+				}
+				value, err := next.Mutate(ctx, m)
+				conflict := &VersionConflictError{Table: m.Type(), Version: expected}
+				if m.Op().Is(ent.OpUpdateOne) && isNotFoundError(err) {
+					return nil, conflict
+				}
+				if affected, isInt := value.(int); err == nil && isInt && affected == 0 {
+					return nil, conflict
+				}
+				return value, err
+			})
+		},
+	}
+}
+
+// VersionConflictError 版本冲突 数据已被修改或删除 客户端需重新获取后重试
+type VersionConflictError struct {
+	Table   string
+	Version int
+}
+
+// Error _
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("mixin: %s version %d conflict", e.Table, e.Version)
+}
+
+// IsVersionConflict 判断是否为版本冲突
+func IsVersionConflict(err error) bool {
+	var e *VersionConflictError
+	return errors.As(err, &e)
+}
+
+// HandleVersionConflict 将版本冲突转换为多语言的 409 错误 其他错误原样返回
+func HandleVersionConflict(ctx context.Context, commonLang *lang.CommonLang, err error, langs ...string) error {
+	if !IsVersionConflict(err) {
+		return err
+	}
+	return kerrors.Conflict(VersionConflictReason, commonLang.GetDataConflictMsg(ctx, langs...)).WithCause(err)
+}
+
+// isNotFoundError 判断是否为生成代码中的 NotFoundError 其类型位于生成的包中 只能通过类型名判断
+func isNotFoundError(err error) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		t := reflect.TypeOf(err)
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Name() == "NotFoundError" {
+			return true
+		}
+	}
+	return false
+}
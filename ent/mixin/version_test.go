@@ -0,0 +1,87 @@
+package mixin
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/lang"
+)
+
+// NotFoundError 模拟生成代码中的 NotFoundError
+type NotFoundError struct{}
+
+func (*NotFoundError) Error() string { return "ent: not found" }
+
+// versionMutation 只实现测试需要的方法
+type versionMutation struct {
+	ent.Mutation
+	op         ent.Op
+	fields     map[string]ent.Value
+	added      map[string]ent.Value
+	predicates int
+}
+
+func (m *versionMutation) Op() ent.Op                          { return m.op }
+func (m *versionMutation) Type() string                        { return "Article" }
+func (m *versionMutation) WhereP(ps ...func(*sql.Selector))    { m.predicates += len(ps) }
+func (m *versionMutation) Field(name string) (ent.Value, bool) { v, ok := m.fields[name]; return v, ok }
+func (m *versionMutation) SetField(name string, v ent.Value) error {
+	m.fields[name] = v
+	return nil
+}
+func (m *versionMutation) AddField(name string, v ent.Value) error {
+	m.added[name] = v
+	return nil
+}
+
+func TestVersionHooks(t *testing.T) {
+	mutate := func(m *versionMutation, value ent.Value, err error) (ent.Value, error) {
+		next := ent.MutateFunc(func(context.Context, ent.Mutation) (ent.Value, error) { return value, err })
+		return Version{}.Hooks()[0](next).Mutate(context.Background(), m)
+	}
+	newMutation := func(op ent.Op, fields map[string]ent.Value) *versionMutation {
+		return &versionMutation{op: op, fields: fields, added: map[string]ent.Value{}}
+	}
+
+	// 未设置版本号时只递增
+	m := newMutation(ent.OpUpdateOne, map[string]ent.Value{})
+	_, err := mutate(m, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, m.added["version"])
+	assert.Equal(t, 0, m.predicates)
+
+	// 设置版本号时检查并递增
+	m = newMutation(ent.OpUpdateOne, map[string]ent.Value{"version": 3})
+	_, err = mutate(m, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, m.fields["version"])
+	assert.Equal(t, 1, m.predicates)
+
+	_, err = mutate(newMutation(ent.OpUpdateOne, map[string]ent.Value{"version": 3}), nil, fmt.Errorf("wrap: %w", &NotFoundError{}))
+	assert.True(t, IsVersionConflict(err))
+
+	_, err = mutate(newMutation(ent.OpUpdate, map[string]ent.Value{"version": 3}), 0, nil)
+	assert.True(t, IsVersionConflict(err))
+
+	// 创建不处理
+	m = newMutation(ent.OpCreate, map[string]ent.Value{"version": 1})
+	_, err = mutate(m, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, m.fields["version"])
+}
+
+func TestHandleVersionConflict(t *testing.T) {
+	commonLang := lang.NewCommonLang(nil, log.DefaultLogger)
+	err := HandleVersionConflict(context.Background(), commonLang, &VersionConflictError{Table: "Article", Version: 1}, "en-US")
+	assert.True(t, errors.IsConflict(err))
+	assert.Equal(t, VersionConflictReason, errors.Reason(err))
+
+	other := fmt.Errorf("other")
+	assert.Equal(t, other, HandleVersionConflict(context.Background(), commonLang, other))
+}
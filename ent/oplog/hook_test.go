@@ -0,0 +1,86 @@
+package oplog_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/data"
+	"github.com/yimoka/go/ent/oplog"
+	"github.com/yimoka/go/internal/entfixture"
+	"github.com/yimoka/go/internal/entfixture/schema"
+	"github.com/yimoka/go/middleware/meta"
+	"github.com/yimoka/go/tenancy"
+)
+
+func TestHook(t *testing.T) {
+	var entries []*oplog.Entry
+	writer := oplog.WriterFunc(func(_ context.Context, es []*oplog.Entry) error {
+		entries = append(entries, es...)
+		return nil
+	})
+	r := oplog.NewRecorder(writer, log.DefaultLogger, oplog.WithSchemas(schema.User{}, schema.Contact{}), oplog.WithIgnoreFields("update_time"))
+	client, drv := entfixture.NewClient()
+	client.Use(r.Hook())
+	ctx := metadata.NewServerContext(tenancy.NewContext(context.Background(), "t1"), metadata.New())
+	ctx = meta.SetUserID(meta.SetStaffID(ctx, "s1"), "u1")
+	changes := func(e *oplog.Entry) map[string]oplog.FieldChange {
+		res := map[string]oplog.FieldChange{}
+		for _, c := range e.Changes {
+			res[c.Field] = c
+		}
+		return res
+	}
+
+	err := client.User.Create().SetName("n").SetPhone("13800138000").SetPhoneCipher("cipher").SetPassword("hash").Exec(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	e := entries[0]
+	assert.Equal(t, "User", e.Table)
+	assert.Equal(t, "user_op_log", e.LogTable)
+	assert.Equal(t, "1", e.RowID)
+	assert.Equal(t, data.OpAdd, e.OpType)
+	// OperatorCode 为 meta.GetUserID
+	assert.Equal(t, "u1", e.Operator)
+	c := changes(e)
+	assert.Equal(t, "t1", c["tenant_id"].After)
+	assert.Equal(t, "138****8000", c["phone"].After)
+	assert.Equal(t, oplog.MaskedValue, c["phone_cipher"].After)
+	assert.Equal(t, oplog.MaskedValue, c["password"].After)
+	_, ok := c["update_time"]
+	assert.False(t, ok)
+
+	// 未设置 OpLogTable 的表不记录
+	assert.NoError(t, client.Contact.Create().SetTenantID("t1").Exec(ctx))
+	assert.Len(t, entries, 1)
+
+	// 只修改开关为启用 更新前的值来自 OldField 的查询, 更新后重新查询实体
+	drv.AddRows([]string{"id", "switch"}, []any{int64(1), false})
+	drv.AddRows([]string{"id", "switch"}, []any{int64(1), true})
+	assert.NoError(t, client.User.UpdateOneID(1).SetSwitch(true).Exec(ctx))
+	e = entries[1]
+	assert.Equal(t, data.OpEnable, e.OpType)
+	assert.Equal(t, oplog.FieldChange{Field: "switch", Before: false, After: true}, changes(e)["switch"])
+
+	// 同时修改其他字段为编辑
+	drv.AddRows([]string{"id", "switch"}, []any{int64(1), true})
+	drv.AddRows([]string{"id", "switch"}, []any{int64(1), false})
+	assert.NoError(t, client.User.UpdateOneID(1).SetSwitch(false).SetName("m").Exec(ctx))
+	assert.Equal(t, data.OpEdit, entries[2].OpType)
+
+	// 软删除由转换后的更新记录
+	drv.AddRows([]string{"id"}, []any{int64(1)})
+	drv.AddRows([]string{"id"}, []any{int64(1)})
+	_, err = client.User.Delete().Exec(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 4)
+	e = entries[3]
+	assert.Equal(t, data.OpSoftDel, e.OpType)
+	assert.Equal(t, "1", e.RowID)
+	assert.Equal(t, "u1", e.Operator)
+	assert.Equal(t, true, changes(e)["del"].After)
+	// Del 的删除人优先取员工 ID
+	assert.Equal(t, "s1", changes(e)["deleter"].After)
+}
@@ -0,0 +1,84 @@
+// Package oplog operator
+package oplog
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/yimoka/go/middleware/meta"
+)
+
+// metaGetters OperatorCode 可调用的 meta 方法
+var metaGetters = map[string]func(ctx context.Context) (string, *errors.Error){
+	"GetUserID":   meta.GetUserID,
+	"GetStaffID":  meta.GetStaffID,
+	"GetClientID": meta.GetClientID,
+	"GetUserType": meta.GetUserType,
+	"GetBusiness": meta.GetBusiness,
+	"GetPlatform": meta.GetPlatform,
+	"GetChannel":  meta.GetChannel,
+}
+
+// metaKeyGetters OperatorCode 可调用的按 key 获取元数据的 meta 方法
+var metaKeyGetters = map[string]func(ctx context.Context, key string) (string, *errors.Error){
+	"GetValue":      meta.GetValue,
+	"GetLocalValue": meta.GetLocalValue,
+}
+
+// operatorFromCode 将 ann.MutationConfig.OperatorCode 转换为获取操作人的方法
+// OperatorCode 为代码生成使用的语句, 运行时只支持 operator, _ := meta.GetXxx(ctx) 与 operator, _ := meta.GetValue(ctx, "key") 的形式
+func operatorFromCode(code string) (func(ctx context.Context) string, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), "", "package p\nfunc _() {\n"+code+"\n}", 0)
+	if err != nil {
+		return nil, err
+	}
+	body := f.Decls[0].(*ast.FuncDecl).Body.List
+	if len(body) != 1 {
+		return nil, fmt.Errorf("unsupported code %q", code)
+	}
+	assign, ok := body[0].(*ast.AssignStmt)
+	if !ok || len(assign.Rhs) != 1 {
+		return nil, fmt.Errorf("unsupported code %q", code)
+	}
+	if ident, isIdent := assign.Lhs[0].(*ast.Ident); !isIdent || ident.Name != "operator" {
+		return nil, fmt.Errorf("code %q must assign to operator", code)
+	}
+	call, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok {
+		return nil, fmt.Errorf("unsupported code %q", code)
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, fmt.Errorf("unsupported code %q", code)
+	}
+	if pkg, isIdent := sel.X.(*ast.Ident); !isIdent || pkg.Name != "meta" {
+		return nil, fmt.Errorf("code %q must call the meta package", code)
+	}
+	if getter, exists := metaGetters[sel.Sel.Name]; exists && len(call.Args) == 1 {
+		return func(ctx context.Context) string {
+			operator, _ := getter(ctx)
+			return operator
+		}, nil
+	}
+	getter, exists := metaKeyGetters[sel.Sel.Name]
+	if !exists || len(call.Args) != 2 {
+		return nil, fmt.Errorf("unsupported meta method %s in %q", sel.Sel.Name, code)
+	}
+	lit, ok := call.Args[1].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return nil, fmt.Errorf("key of %s must be a string literal in %q", sel.Sel.Name, code)
+	}
+	key, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context) string {
+		operator, _ := getter(ctx, key)
+		return operator
+	}, nil
+}
@@ -0,0 +1,64 @@
+// Package oplog 操作记录
+// 通过 ent 的 Hook 记录数据的变更, 配合 mixin.OpLog 创建操作记录表
+package oplog
+
+import (
+	"context"
+	"time"
+
+	"github.com/yimoka/go/data"
+)
+
+// Entry 一条操作记录 对应一行数据的一次变更
+type Entry struct {
+	// 表名 即 ent 的类型名
+	Table string `json:"table"`
+	// 写入的操作记录表 来自 ann.Table 的 MutationConfig.OpLogTable, 见 WithSchemas
+	LogTable string `json:"-"`
+	// 行 ID
+	RowID string `json:"rowID"`
+	// 操作类型
+	OpType data.OpType `json:"opType"`
+	// 操作人
+	Operator string `json:"operator"`
+	// 客户端 IP
+	ClientIP string `json:"clientIP"`
+	// 链路追踪 ID
+	TraceID string `json:"traceID"`
+	// 字段变更
+	Changes []FieldChange `json:"changes"`
+	// 操作时间
+	Time time.Time `json:"time"`
+}
+
+// FieldChange 字段的变更 敏感字段已脱敏
+type FieldChange struct {
+	Field string `json:"field"`
+	// 变更前的值 仅单行更新时可获取
+	Before interface{} `json:"before,omitempty"`
+	// 变更后的值 清空字段时为空
+	After interface{} `json:"after,omitempty"`
+}
+
+// Writer 操作记录的写入
+type Writer interface {
+	Write(ctx context.Context, entries []*Entry) error
+}
+
+// WriterFunc 以函数实现 Writer
+type WriterFunc func(ctx context.Context, entries []*Entry) error
+
+// Write _
+func (f WriterFunc) Write(ctx context.Context, entries []*Entry) error {
+	return f(ctx, entries)
+}
+
+type clientKey struct{}
+
+// ClientFromContext 获取触发操作记录的 Mutation 所属的 Client
+// 同步写入时 Writer 可通过它写入操作记录表, Mutation 在事务中时该 Client 也属于该事务
+// 生成的 Client 为具体类型, 需自行断言 如 oplog.ClientFromContext(ctx).(*ent.Client)
+func ClientFromContext(ctx context.Context) (interface{}, bool) {
+	client := ctx.Value(clientKey{})
+	return client, client != nil
+}
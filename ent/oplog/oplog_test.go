@@ -0,0 +1,132 @@
+package oplog
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"entgo.io/ent"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/data"
+	"github.com/yimoka/go/middleware/meta"
+)
+
+// testMutation 只实现测试需要的方法
+type testMutation struct {
+	ent.Mutation
+	op      ent.Op
+	fields  map[string]interface{}
+	cleared []string
+}
+
+func (m *testMutation) Op() ent.Op              { return m.op }
+func (m *testMutation) Type() string            { return "User" }
+func (m *testMutation) ClearedFields() []string { return m.cleared }
+func (m *testMutation) Fields() []string {
+	fields := make([]string, 0, len(m.fields))
+	for name := range m.fields {
+		fields = append(fields, name)
+	}
+	return fields
+}
+func (m *testMutation) Field(name string) (ent.Value, bool) {
+	v, ok := m.fields[name]
+	return v, ok
+}
+
+func TestGetOpType(t *testing.T) {
+	for _, c := range []struct {
+		m      *testMutation
+		opType data.OpType
+	}{
+		{&testMutation{op: ent.OpCreate}, data.OpAdd},
+		{&testMutation{op: ent.OpDeleteOne}, data.OpDel},
+		{&testMutation{op: ent.OpUpdate, fields: map[string]interface{}{"del": true}}, data.OpSoftDel},
+		{&testMutation{op: ent.OpUpdate, fields: map[string]interface{}{"del": false}}, data.OpRecover},
+		{&testMutation{op: ent.OpUpdateOne, fields: map[string]interface{}{"switch": true}}, data.OpEnable},
+		{&testMutation{op: ent.OpUpdateOne, fields: map[string]interface{}{"switch": false}}, data.OpDisable},
+		{&testMutation{op: ent.OpUpdateOne, fields: map[string]interface{}{"switch": true, "update_time": "now"}}, data.OpEnable},
+		{&testMutation{op: ent.OpUpdateOne, fields: map[string]interface{}{"switch": true, "title": "t"}}, data.OpEdit},
+		{&testMutation{op: ent.OpUpdateOne, fields: map[string]interface{}{"switch": true}, cleared: []string{"remark"}}, data.OpEdit},
+		{&testMutation{op: ent.OpUpdateOne, fields: map[string]interface{}{"title": "t"}}, data.OpEdit},
+	} {
+		assert.Equal(t, c.opType, GetOpType(c.m))
+	}
+}
+
+func TestChanges(t *testing.T) {
+	r := NewRecorder(nil, log.DefaultLogger, WithIgnoreFields("update_time"), WithSensitiveFields("User.id_card"))
+	m := &testMutation{
+		op: ent.OpUpdateOne,
+		fields: map[string]interface{}{
+			"title":        "new",
+			"password":     "hash",
+			"phone":        "13800138000",
+			"phone_cipher": "cipher",
+			"secret_key":   "sk",
+			"id_card":      "110101199001011234",
+			"update_time":  "now",
+		},
+		cleared: []string{"remark"},
+	}
+	changes := map[string]FieldChange{}
+	for _, c := range r.changes(m, map[string]interface{}{"title": "old", "remark": "r"}) {
+		changes[c.Field] = c
+	}
+	assert.Len(t, changes, 7)
+	assert.Equal(t, FieldChange{Field: "title", Before: "old", After: "new"}, changes["title"])
+	assert.Equal(t, FieldChange{Field: "remark", Before: "r"}, changes["remark"])
+	assert.Equal(t, MaskedValue, changes["password"].After)
+	assert.Equal(t, MaskedValue, changes["phone_cipher"].After)
+	assert.Equal(t, MaskedValue, changes["secret_key"].After)
+	assert.Equal(t, MaskedValue, changes["id_card"].After)
+	assert.NotEqual(t, "13800138000", changes["phone"].After)
+}
+
+func TestOperatorFromCode(t *testing.T) {
+	ctx := metadata.NewServerContext(context.Background(), metadata.New())
+	ctx = meta.SetValue(meta.SetStaffID(ctx, "s1"), "admin", "a1")
+
+	fn, err := operatorFromCode("operator, _ := meta.GetStaffID(ctx)")
+	assert.NoError(t, err)
+	assert.Equal(t, "s1", fn(ctx))
+	fn, err = operatorFromCode(`operator, _ := meta.GetValue(ctx, "admin")`)
+	assert.NoError(t, err)
+	assert.Equal(t, "a1", fn(ctx))
+
+	for _, code := range []string{
+		"operator := getOperator(ctx)",
+		"id, _ := meta.GetStaffID(ctx)",
+		"operator, _ := meta.GetAuth(ctx)",
+		"operator, _ := meta.GetValue(ctx, key)",
+		"operator, _ := meta.GetStaffID(ctx); operator = operator + \"\"",
+	} {
+		_, err = operatorFromCode(code)
+		assert.Error(t, err, code)
+	}
+}
+
+func TestAsyncWrite(t *testing.T) {
+	var mu sync.Mutex
+	var written []*Entry
+	writer := WriterFunc(func(ctx context.Context, entries []*Entry) error {
+		assert.True(t, IsSkip(ctx))
+		mu.Lock()
+		defer mu.Unlock()
+		written = append(written, entries...)
+		return nil
+	})
+	r := NewRecorder(writer, log.DefaultLogger, WithAsync(8))
+	m := &testMutation{op: ent.OpCreate, fields: map[string]interface{}{"title": "t"}}
+	assert.NoError(t, r.write(context.Background(), m, r.entries(context.Background(), m, []string{"1", "2"}, nil)))
+	r.Close()
+	assert.Len(t, written, 2)
+	assert.Equal(t, "2", written[1].RowID)
+	assert.Equal(t, data.OpAdd, written[0].OpType)
+
+	// 关闭后的记录会被丢弃
+	assert.NoError(t, r.write(context.Background(), m, r.entries(context.Background(), m, []string{"3"}, nil)))
+	assert.Len(t, written, 2)
+}
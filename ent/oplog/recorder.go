@@ -0,0 +1,487 @@
+// Package oplog recorder
+package oplog
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"entgo.io/ent"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/yimoka/go/data"
+	"github.com/yimoka/go/ent/ann"
+	"github.com/yimoka/go/middleware/meta"
+	"github.com/yimoka/go/utils"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MaskedValue 敏感字段在操作记录中的值
+const MaskedValue = "******"
+
+// Option 配置
+type Option func(*options)
+
+type options struct {
+	async        bool
+	bufferSize   int
+	operator     func(ctx context.Context) string
+	tables       map[string]bool
+	schemas      map[string]*tableConfig
+	ignoreFields map[string]bool
+	sensitive    map[string]bool
+	mask         map[string]utils.MaskType
+	err          error
+}
+
+// tableConfig 表的操作记录配置 来自 ann.Table 的 MutationConfig
+type tableConfig struct {
+	logTable string
+	operator func(ctx context.Context) string
+}
+
+// WithAsync 异步写入操作记录 bufferSize 为队列长度 队列满时丢弃并记录日志
+// 默认同步写入, 写入失败时该次变更返回错误, 在事务中时会随事务回滚
+func WithAsync(bufferSize int) Option {
+	return func(o *options) {
+		o.async = true
+		if bufferSize > 0 {
+			o.bufferSize = bufferSize
+		}
+	}
+}
+
+// WithOperator 设置获取操作人的方法 默认优先取员工 ID 其次取用户 ID
+func WithOperator(fn func(ctx context.Context) string) Option {
+	return func(o *options) {
+		o.operator = fn
+	}
+}
+
+// WithTables 只记录指定的表 不设置时记录所有表
+func WithTables(tables ...string) Option {
+	return func(o *options) {
+		for _, t := range tables {
+			o.tables[t] = true
+		}
+	}
+}
+
+// WithSchemas 按 ent 的 Schema 及其 Mixin 的注解配置 表名为 Schema 的类型名 即 Mutation.Type()
+// 只记录 ann.Table 的 MutationConfig.OpLogTable 不为空的表, 记录的 Entry.LogTable 为该值
+// MutationConfig.OperatorCode 不为空时按其获取操作人, 运行时只支持调用 meta 包获取元数据的方法, 如 operator, _ := meta.GetStaffID(ctx)
+// 并按 ann.GetOpLogMaskFields 添加敏感字段与掩码字段
+func WithSchemas(schemas ...ent.Interface) Option {
+	return func(o *options) {
+		for _, s := range schemas {
+			t := reflect.TypeOf(s)
+			if t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			node, err := ann.LoadType(s)
+			if err != nil {
+				o.err = fmt.Errorf("oplog: load schema %s: %w", t.Name(), err)
+				return
+			}
+			conf := ann.GetTableConfig(node).MutationConfig
+			if conf.OpLogTable == "" {
+				o.schemas[t.Name()] = &tableConfig{}
+				continue
+			}
+			tc := &tableConfig{logTable: conf.OpLogTable}
+			if conf.OperatorCode != "" {
+				if tc.operator, err = operatorFromCode(conf.OperatorCode); err != nil {
+					o.err = fmt.Errorf("oplog: operatorCode of %s: %w", t.Name(), err)
+					return
+				}
+			}
+			o.schemas[t.Name()] = tc
+			o.tables[t.Name()] = true
+			sensitive, mask := ann.GetOpLogMaskFields(node)
+			for _, f := range sensitive {
+				o.sensitive[f] = true
+			}
+			for f, maskType := range mask {
+				o.mask[f] = maskType
+			}
+		}
+	}
+}
+
+// WithIgnoreFields 不记录变更的字段 如 update_time
+// 字段名为 Mutation 的字段名即数据库列名, 可以为 column 或 Table.column
+func WithIgnoreFields(fields ...string) Option {
+	return func(o *options) {
+		for _, f := range fields {
+			o.ignoreFields[f] = true
+		}
+	}
+}
+
+// WithSensitiveFields 敏感字段 对应 ann.Field 的 Encrypt、RowIrreversibleEncrypt 及 ent 的 Sensitive
+// 值替换为 MaskedValue, 字段名为数据库列名, 可以为 column 或 Table.column
+// 默认包含 password、secret_key 及以 _cipher、_nonce、_bidx 结尾的字段
+func WithSensitiveFields(fields ...string) Option {
+	return func(o *options) {
+		for _, f := range fields {
+			o.sensitive[f] = true
+		}
+	}
+}
+
+// WithMaskFields 掩码字段 对应 ann.Field 的 MaskEncrypt, 值使用 utils.Mask 处理
+// 字段名为数据库列名, 可以为 column 或 Table.column, 默认包含 phone 与 mail
+func WithMaskFields(fields map[string]utils.MaskType) Option {
+	return func(o *options) {
+		for f, t := range fields {
+			o.mask[f] = t
+		}
+	}
+}
+
+// Recorder 操作记录器
+type Recorder struct {
+	writer Writer
+	log    *log.Helper
+	opts   options
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan *asyncItem
+	wg     sync.WaitGroup
+}
+
+type asyncItem struct {
+	ctx     context.Context
+	entries []*Entry
+}
+
+// NewRecorder 创建操作记录器 通过 Hook 注册到 ent 的 Client 或 Schema
+// 异步写入时需在退出前调用 Close 确保队列中的记录写入完成
+func NewRecorder(writer Writer, logger log.Logger, opts ...Option) *Recorder {
+	o := options{
+		bufferSize:   1024,
+		operator:     defaultOperator,
+		tables:       map[string]bool{},
+		schemas:      map[string]*tableConfig{},
+		ignoreFields: map[string]bool{},
+		sensitive:    map[string]bool{"password": true, "secret_key": true},
+		mask:         map[string]utils.MaskType{"phone": utils.MaskTypePhone, "mail": utils.MaskTypeEmail},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	r := &Recorder{
+		writer: writer,
+		log:    log.NewHelper(log.With(logger, "layer", "oplog")),
+		opts:   o,
+	}
+	if o.err != nil {
+		r.log.Fatal(o.err)
+	}
+	if o.async {
+		r.queue = make(chan *asyncItem, o.bufferSize)
+		r.wg.Add(1)
+		go r.run()
+	}
+	return r
+}
+
+// Close 停止接收新的记录并等待异步队列写入完成
+func (r *Recorder) Close() {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return
+	}
+	r.closed = true
+	if r.queue != nil {
+		close(r.queue)
+	}
+	r.mu.Unlock()
+	r.wg.Wait()
+}
+
+// Hook 记录操作的 ent Hook
+// 被其他 Hook 转换了操作类型的变更(如软删除) 由转换后重新执行的变更记录
+func (r *Recorder) Hook() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if IsSkip(ctx) || !r.isRecordTable(m.Type()) {
+				return next.Mutate(ctx, m)
+			}
+			op := m.Op()
+			var ids []string
+			var before map[string]interface{}
+			if !op.Is(ent.OpCreate) {
+				var err error
+				if ids, err = mutationIDs(ctx, m); err != nil {
+					return nil, err
+				}
+				if op.Is(ent.OpUpdateOne) {
+					before = oldFields(ctx, m)
+				}
+			}
+			v, err := next.Mutate(ctx, m)
+			if err != nil || m.Op() != op {
+				return v, err
+			}
+			if op.Is(ent.OpCreate) {
+				if id, ok := mutationID(m); ok {
+					ids = []string{id}
+				}
+			}
+			if len(ids) == 0 {
+				return v, nil
+			}
+			if err := r.write(ctx, m, r.entries(ctx, m, ids, before)); err != nil {
+				return nil, err
+			}
+			return v, nil
+		})
+	}
+}
+
+// entries 生成每一行的操作记录
+func (r *Recorder) entries(ctx context.Context, m ent.Mutation, ids []string, before map[string]interface{}) []*Entry {
+	changes := r.changes(m, before)
+	opType := GetOpType(m)
+	operator, logTable := r.opts.operator, ""
+	if tc, ok := r.opts.schemas[m.Type()]; ok {
+		logTable = tc.logTable
+		if tc.operator != nil {
+			operator = tc.operator
+		}
+	}
+	operatorID := operator(ctx)
+	clientIP := meta.GetClientIP(ctx)
+	traceID := ""
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		traceID = sc.TraceID().String()
+	}
+	now := time.Now()
+	entries := make([]*Entry, 0, len(ids))
+	for _, id := range ids {
+		entries = append(entries, &Entry{
+			Table:    m.Type(),
+			LogTable: logTable,
+			RowID:    id,
+			OpType:   opType,
+			Operator: operatorID,
+			ClientIP: clientIP,
+			TraceID:  traceID,
+			Changes:  changes,
+			Time:     now,
+		})
+	}
+	return entries
+}
+
+// changes 字段的变更 并对敏感字段脱敏 字段名为生成的 Mutation 的字段名 即数据库列名
+func (r *Recorder) changes(m ent.Mutation, before map[string]interface{}) []FieldChange {
+	table := m.Type()
+	changes := make([]FieldChange, 0, len(m.Fields())+len(m.ClearedFields()))
+	add := func(name string, after interface{}) {
+		if r.opts.ignoreFields[name] || r.opts.ignoreFields[table+"."+name] {
+			return
+		}
+		changes = append(changes, FieldChange{
+			Field:  name,
+			Before: r.maskValue(table, name, before[name]),
+			After:  r.maskValue(table, name, after),
+		})
+	}
+	for _, name := range m.Fields() {
+		after, _ := m.Field(name)
+		add(name, after)
+	}
+	for _, name := range m.ClearedFields() {
+		add(name, nil)
+	}
+	return changes
+}
+
+// maskValue 敏感字段脱敏 name 为数据库列名, 如 phone_cipher
+func (r *Recorder) maskValue(table, name string, v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	if r.opts.sensitive[name] || r.opts.sensitive[table+"."+name] ||
+		strings.HasSuffix(name, "_cipher") || strings.HasSuffix(name, "_nonce") || strings.HasSuffix(name, "_bidx") {
+		return MaskedValue
+	}
+	maskType, ok := r.opts.mask[table+"."+name]
+	if !ok {
+		maskType, ok = r.opts.mask[name]
+	}
+	if !ok {
+		return v
+	}
+	if s, isStr := v.(string); isStr {
+		return utils.Mask(s, maskType)
+	}
+	return MaskedValue
+}
+
+// isRecordTable 未设置 WithTables 与 WithSchemas 时记录所有表
+func (r *Recorder) isRecordTable(table string) bool {
+	if len(r.opts.tables) == 0 && len(r.opts.schemas) == 0 {
+		return true
+	}
+	return r.opts.tables[table]
+}
+
+// write 同步写入时使用原 ctx 并附带 Mutation 的 Client, 异步写入时使用脱离请求的 ctx
+func (r *Recorder) write(ctx context.Context, m ent.Mutation, entries []*Entry) error {
+	if !r.opts.async {
+		ctx = Skip(ctx)
+		if client, ok := mutationClient(m); ok {
+			ctx = context.WithValue(ctx, clientKey{}, client)
+		}
+		return r.writer.Write(ctx, entries)
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		r.log.WithContext(ctx).Warnf("oplog: recorder closed, drop %d entries of %s", len(entries), m.Type())
+		return nil
+	}
+	select {
+	case r.queue <- &asyncItem{ctx: Skip(meta.GetNewCtx(ctx)), entries: entries}:
+	default:
+		r.log.WithContext(ctx).Warnf("oplog: queue is full, drop %d entries of %s", len(entries), m.Type())
+	}
+	return nil
+}
+
+func (r *Recorder) run() {
+	defer r.wg.Done()
+	for item := range r.queue {
+		if err := r.writer.Write(item.ctx, item.entries); err != nil {
+			r.log.WithContext(item.ctx).Errorf("oplog: write entries error: %v", err)
+		}
+	}
+}
+
+// maintainedFields 由 mixin 在更新时自动维护的字段 不作为业务字段区分操作类型
+var maintainedFields = map[string]bool{
+	"update_time":      true,
+	"updater":          true,
+	"updater_by_staff": true,
+	"version":          true,
+}
+
+// GetOpType 获取变更的操作类型
+// 更新时根据软删除字段 del 的值区分软删除、恢复
+// 开关字段 switch 为唯一修改的业务字段时区分启用、停用, 同时修改其他字段时为编辑
+func GetOpType(m ent.Mutation) data.OpType {
+	switch {
+	case m.Op().Is(ent.OpCreate):
+		return data.OpAdd
+	case m.Op().Is(ent.OpDelete | ent.OpDeleteOne):
+		return data.OpDel
+	case m.Op().Is(ent.OpUpdate | ent.OpUpdateOne):
+		if v, ok := m.Field("del"); ok {
+			if del, _ := v.(bool); del {
+				return data.OpSoftDel
+			}
+			return data.OpRecover
+		}
+		if v, ok := m.Field("switch"); ok && onlySwitch(m) {
+			if on, _ := v.(bool); on {
+				return data.OpEnable
+			}
+			return data.OpDisable
+		}
+		return data.OpEdit
+	}
+	return data.OpUnknown
+}
+
+// onlySwitch 修改的业务字段是否只有 switch
+func onlySwitch(m ent.Mutation) bool {
+	if len(m.ClearedFields()) > 0 {
+		return false
+	}
+	for _, name := range m.Fields() {
+		if name != "switch" && !maintainedFields[name] {
+			return false
+		}
+	}
+	return true
+}
+
+type skipKey struct{}
+
+// Skip 返回不记录操作的 ctx
+func Skip(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipKey{}, true)
+}
+
+// IsSkip 判断 ctx 是否不记录操作
+func IsSkip(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipKey{}).(bool)
+	return skip
+}
+
+func defaultOperator(ctx context.Context) string {
+	if staffID, _ := meta.GetStaffID(ctx); staffID != "" {
+		return staffID
+	}
+	userID, _ := meta.GetUserID(ctx)
+	return userID
+}
+
+// 生成的 Mutation 的 ID、IDs、Client 方法返回具体类型 所以只能通过反射获取
+
+// mutationIDs 获取变更影响的行 ID 需在变更执行前调用
+func mutationIDs(ctx context.Context, m ent.Mutation) ([]string, error) {
+	method := reflect.ValueOf(m).MethodByName("IDs")
+	if !method.IsValid() || method.Type().NumIn() != 1 || method.Type().NumOut() != 2 {
+		return nil, fmt.Errorf("oplog: mutation %T has no IDs method", m)
+	}
+	out := method.Call([]reflect.Value{reflect.ValueOf(ctx)})
+	if err, _ := out[1].Interface().(error); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, out[0].Len())
+	for i := 0; i < out[0].Len(); i++ {
+		ids = append(ids, fmt.Sprint(out[0].Index(i).Interface()))
+	}
+	return ids, nil
+}
+
+// mutationID 获取创建后的行 ID
+func mutationID(m ent.Mutation) (string, bool) {
+	method := reflect.ValueOf(m).MethodByName("ID")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 2 {
+		return "", false
+	}
+	out := method.Call(nil)
+	if !out[1].Bool() {
+		return "", false
+	}
+	return fmt.Sprint(out[0].Interface()), true
+}
+
+// mutationClient 获取 Mutation 所属的 Client
+func mutationClient(m ent.Mutation) (interface{}, bool) {
+	method := reflect.ValueOf(m).MethodByName("Client")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil, false
+	}
+	return method.Call(nil)[0].Interface(), true
+}
+
+// oldFields 获取单行更新前的值
+func oldFields(ctx context.Context, m ent.Mutation) map[string]interface{} {
+	before := map[string]interface{}{}
+	for _, name := range append(m.Fields(), m.ClearedFields()...) {
+		if v, err := m.OldField(ctx, name); err == nil {
+			before[name] = v
+		}
+	}
+	return before
+}
@@ -0,0 +1,114 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/yimoka/go/internal/entfixture/ent/app"
+)
+
+// App is the model entity for the App schema.
+type App struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// 密钥ID
+	SecretID string `json:"secretID,omitempty"`
+	// 密钥Key
+	SecretKey    string `json:"secretKey,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*App) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case app.FieldID:
+			values[i] = new(sql.NullInt64)
+		case app.FieldSecretID, app.FieldSecretKey:
+			values[i] = new(sql.NullString)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the App fields.
+func (a *App) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case app.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			a.ID = int(value.Int64)
+		case app.FieldSecretID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field secretID", values[i])
+			} else if value.Valid {
+				a.SecretID = value.String
+			}
+		case app.FieldSecretKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field secretKey", values[i])
+			} else if value.Valid {
+				a.SecretKey = value.String
+			}
+		default:
+			a.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the App.
+// This includes values selected through modifiers, order, etc.
+func (a *App) Value(name string) (ent.Value, error) {
+	return a.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this App.
+// Note that you need to call App.Unwrap() before calling this method if this App
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (a *App) Update() *AppUpdateOne {
+	return NewAppClient(a.config).UpdateOne(a)
+}
+
+// Unwrap unwraps the App entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (a *App) Unwrap() *App {
+	_tx, ok := a.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: App is not a transactional entity")
+	}
+	a.config.driver = _tx.drv
+	return a
+}
+
+// String implements the fmt.Stringer.
+func (a *App) String() string {
+	var builder strings.Builder
+	builder.WriteString("App(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", a.ID))
+	builder.WriteString("secretID=")
+	builder.WriteString(a.SecretID)
+	builder.WriteString(", ")
+	builder.WriteString("secretKey=")
+	builder.WriteString(a.SecretKey)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Apps is a parsable slice of App.
+type Apps []*App
@@ -0,0 +1,64 @@
+// Code generated by ent, DO NOT EDIT.
+
+package app
+
+import (
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the app type in the database.
+	Label = "app"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldSecretID holds the string denoting the secretid field in the database.
+	FieldSecretID = "secret_id"
+	// FieldSecretKey holds the string denoting the secretkey field in the database.
+	FieldSecretKey = "secret_key"
+	// Table holds the table name of the app in the database.
+	Table = "apps"
+)
+
+// Columns holds all SQL columns for app fields.
+var Columns = []string{
+	FieldID,
+	FieldSecretID,
+	FieldSecretKey,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// SecretIDValidator is a validator for the "secretID" field. It is called by the builders before save.
+	SecretIDValidator func(string) error
+	// DefaultSecretKey holds the default value on creation for the "secretKey" field.
+	DefaultSecretKey string
+	// SecretKeyValidator is a validator for the "secretKey" field. It is called by the builders before save.
+	SecretKeyValidator func(string) error
+)
+
+// OrderOption defines the ordering options for the App queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// BySecretID orders the results by the secretID field.
+func BySecretID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSecretID, opts...).ToFunc()
+}
+
+// BySecretKey orders the results by the secretKey field.
+func BySecretKey(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSecretKey, opts...).ToFunc()
+}
@@ -0,0 +1,218 @@
+// Code generated by ent, DO NOT EDIT.
+
+package app
+
+import (
+	"entgo.io/ent/dialect/sql"
+	"github.com/yimoka/go/internal/entfixture/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.App {
+	return predicate.App(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.App {
+	return predicate.App(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.App {
+	return predicate.App(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.App {
+	return predicate.App(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.App {
+	return predicate.App(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.App {
+	return predicate.App(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.App {
+	return predicate.App(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.App {
+	return predicate.App(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.App {
+	return predicate.App(sql.FieldLTE(FieldID, id))
+}
+
+// SecretID applies equality check predicate on the "secretID" field. It's identical to SecretIDEQ.
+func SecretID(v string) predicate.App {
+	return predicate.App(sql.FieldEQ(FieldSecretID, v))
+}
+
+// SecretKey applies equality check predicate on the "secretKey" field. It's identical to SecretKeyEQ.
+func SecretKey(v string) predicate.App {
+	return predicate.App(sql.FieldEQ(FieldSecretKey, v))
+}
+
+// SecretIDEQ applies the EQ predicate on the "secretID" field.
+func SecretIDEQ(v string) predicate.App {
+	return predicate.App(sql.FieldEQ(FieldSecretID, v))
+}
+
+// SecretIDNEQ applies the NEQ predicate on the "secretID" field.
+func SecretIDNEQ(v string) predicate.App {
+	return predicate.App(sql.FieldNEQ(FieldSecretID, v))
+}
+
+// SecretIDIn applies the In predicate on the "secretID" field.
+func SecretIDIn(vs ...string) predicate.App {
+	return predicate.App(sql.FieldIn(FieldSecretID, vs...))
+}
+
+// SecretIDNotIn applies the NotIn predicate on the "secretID" field.
+func SecretIDNotIn(vs ...string) predicate.App {
+	return predicate.App(sql.FieldNotIn(FieldSecretID, vs...))
+}
+
+// SecretIDGT applies the GT predicate on the "secretID" field.
+func SecretIDGT(v string) predicate.App {
+	return predicate.App(sql.FieldGT(FieldSecretID, v))
+}
+
+// SecretIDGTE applies the GTE predicate on the "secretID" field.
+func SecretIDGTE(v string) predicate.App {
+	return predicate.App(sql.FieldGTE(FieldSecretID, v))
+}
+
+// SecretIDLT applies the LT predicate on the "secretID" field.
+func SecretIDLT(v string) predicate.App {
+	return predicate.App(sql.FieldLT(FieldSecretID, v))
+}
+
+// SecretIDLTE applies the LTE predicate on the "secretID" field.
+func SecretIDLTE(v string) predicate.App {
+	return predicate.App(sql.FieldLTE(FieldSecretID, v))
+}
+
+// SecretIDContains applies the Contains predicate on the "secretID" field.
+func SecretIDContains(v string) predicate.App {
+	return predicate.App(sql.FieldContains(FieldSecretID, v))
+}
+
+// SecretIDHasPrefix applies the HasPrefix predicate on the "secretID" field.
+func SecretIDHasPrefix(v string) predicate.App {
+	return predicate.App(sql.FieldHasPrefix(FieldSecretID, v))
+}
+
+// SecretIDHasSuffix applies the HasSuffix predicate on the "secretID" field.
+func SecretIDHasSuffix(v string) predicate.App {
+	return predicate.App(sql.FieldHasSuffix(FieldSecretID, v))
+}
+
+// SecretIDEqualFold applies the EqualFold predicate on the "secretID" field.
+func SecretIDEqualFold(v string) predicate.App {
+	return predicate.App(sql.FieldEqualFold(FieldSecretID, v))
+}
+
+// SecretIDContainsFold applies the ContainsFold predicate on the "secretID" field.
+func SecretIDContainsFold(v string) predicate.App {
+	return predicate.App(sql.FieldContainsFold(FieldSecretID, v))
+}
+
+// SecretKeyEQ applies the EQ predicate on the "secretKey" field.
+func SecretKeyEQ(v string) predicate.App {
+	return predicate.App(sql.FieldEQ(FieldSecretKey, v))
+}
+
+// SecretKeyNEQ applies the NEQ predicate on the "secretKey" field.
+func SecretKeyNEQ(v string) predicate.App {
+	return predicate.App(sql.FieldNEQ(FieldSecretKey, v))
+}
+
+// SecretKeyIn applies the In predicate on the "secretKey" field.
+func SecretKeyIn(vs ...string) predicate.App {
+	return predicate.App(sql.FieldIn(FieldSecretKey, vs...))
+}
+
+// SecretKeyNotIn applies the NotIn predicate on the "secretKey" field.
+func SecretKeyNotIn(vs ...string) predicate.App {
+	return predicate.App(sql.FieldNotIn(FieldSecretKey, vs...))
+}
+
+// SecretKeyGT applies the GT predicate on the "secretKey" field.
+func SecretKeyGT(v string) predicate.App {
+	return predicate.App(sql.FieldGT(FieldSecretKey, v))
+}
+
+// SecretKeyGTE applies the GTE predicate on the "secretKey" field.
+func SecretKeyGTE(v string) predicate.App {
+	return predicate.App(sql.FieldGTE(FieldSecretKey, v))
+}
+
+// SecretKeyLT applies the LT predicate on the "secretKey" field.
+func SecretKeyLT(v string) predicate.App {
+	return predicate.App(sql.FieldLT(FieldSecretKey, v))
+}
+
+// SecretKeyLTE applies the LTE predicate on the "secretKey" field.
+func SecretKeyLTE(v string) predicate.App {
+	return predicate.App(sql.FieldLTE(FieldSecretKey, v))
+}
+
+// SecretKeyContains applies the Contains predicate on the "secretKey" field.
+func SecretKeyContains(v string) predicate.App {
+	return predicate.App(sql.FieldContains(FieldSecretKey, v))
+}
+
+// SecretKeyHasPrefix applies the HasPrefix predicate on the "secretKey" field.
+func SecretKeyHasPrefix(v string) predicate.App {
+	return predicate.App(sql.FieldHasPrefix(FieldSecretKey, v))
+}
+
+// SecretKeyHasSuffix applies the HasSuffix predicate on the "secretKey" field.
+func SecretKeyHasSuffix(v string) predicate.App {
+	return predicate.App(sql.FieldHasSuffix(FieldSecretKey, v))
+}
+
+// SecretKeyIsNil applies the IsNil predicate on the "secretKey" field.
+func SecretKeyIsNil() predicate.App {
+	return predicate.App(sql.FieldIsNull(FieldSecretKey))
+}
+
+// SecretKeyNotNil applies the NotNil predicate on the "secretKey" field.
+func SecretKeyNotNil() predicate.App {
+	return predicate.App(sql.FieldNotNull(FieldSecretKey))
+}
+
+// SecretKeyEqualFold applies the EqualFold predicate on the "secretKey" field.
+func SecretKeyEqualFold(v string) predicate.App {
+	return predicate.App(sql.FieldEqualFold(FieldSecretKey, v))
+}
+
+// SecretKeyContainsFold applies the ContainsFold predicate on the "secretKey" field.
+func SecretKeyContainsFold(v string) predicate.App {
+	return predicate.App(sql.FieldContainsFold(FieldSecretKey, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.App) predicate.App {
+	return predicate.App(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.App) predicate.App {
+	return predicate.App(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.App) predicate.App {
+	return predicate.App(sql.NotPredicates(p))
+}
@@ -0,0 +1,221 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/yimoka/go/internal/entfixture/ent/app"
+)
+
+// AppCreate is the builder for creating a App entity.
+type AppCreate struct {
+	config
+	mutation *AppMutation
+	hooks    []Hook
+}
+
+// SetSecretID sets the "secretID" field.
+func (ac *AppCreate) SetSecretID(s string) *AppCreate {
+	ac.mutation.SetSecretID(s)
+	return ac
+}
+
+// SetSecretKey sets the "secretKey" field.
+func (ac *AppCreate) SetSecretKey(s string) *AppCreate {
+	ac.mutation.SetSecretKey(s)
+	return ac
+}
+
+// SetNillableSecretKey sets the "secretKey" field if the given value is not nil.
+func (ac *AppCreate) SetNillableSecretKey(s *string) *AppCreate {
+	if s != nil {
+		ac.SetSecretKey(*s)
+	}
+	return ac
+}
+
+// Mutation returns the AppMutation object of the builder.
+func (ac *AppCreate) Mutation() *AppMutation {
+	return ac.mutation
+}
+
+// Save creates the App in the database.
+func (ac *AppCreate) Save(ctx context.Context) (*App, error) {
+	ac.defaults()
+	return withHooks(ctx, ac.sqlSave, ac.mutation, ac.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (ac *AppCreate) SaveX(ctx context.Context) *App {
+	v, err := ac.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ac *AppCreate) Exec(ctx context.Context) error {
+	_, err := ac.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ac *AppCreate) ExecX(ctx context.Context) {
+	if err := ac.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ac *AppCreate) defaults() {
+	if _, ok := ac.mutation.SecretKey(); !ok {
+		v := app.DefaultSecretKey
+		ac.mutation.SetSecretKey(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ac *AppCreate) check() error {
+	if _, ok := ac.mutation.SecretID(); !ok {
+		return &ValidationError{Name: "secretID", err: errors.New(`ent: missing required field "App.secretID"`)}
+	}
+	if v, ok := ac.mutation.SecretID(); ok {
+		if err := app.SecretIDValidator(v); err != nil {
+			return &ValidationError{Name: "secretID", err: fmt.Errorf(`ent: validator failed for field "App.secretID": %w`, err)}
+		}
+	}
+	if v, ok := ac.mutation.SecretKey(); ok {
+		if err := app.SecretKeyValidator(v); err != nil {
+			return &ValidationError{Name: "secretKey", err: fmt.Errorf(`ent: validator failed for field "App.secretKey": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ac *AppCreate) sqlSave(ctx context.Context) (*App, error) {
+	if err := ac.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := ac.createSpec()
+	if err := sqlgraph.CreateNode(ctx, ac.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	ac.mutation.id = &_node.ID
+	ac.mutation.done = true
+	return _node, nil
+}
+
+func (ac *AppCreate) createSpec() (*App, *sqlgraph.CreateSpec) {
+	var (
+		_node = &App{config: ac.config}
+		_spec = sqlgraph.NewCreateSpec(app.Table, sqlgraph.NewFieldSpec(app.FieldID, field.TypeInt))
+	)
+	if value, ok := ac.mutation.SecretID(); ok {
+		_spec.SetField(app.FieldSecretID, field.TypeString, value)
+		_node.SecretID = value
+	}
+	if value, ok := ac.mutation.SecretKey(); ok {
+		_spec.SetField(app.FieldSecretKey, field.TypeString, value)
+		_node.SecretKey = value
+	}
+	return _node, _spec
+}
+
+// AppCreateBulk is the builder for creating many App entities in bulk.
+type AppCreateBulk struct {
+	config
+	err      error
+	builders []*AppCreate
+}
+
+// Save creates the App entities in the database.
+func (acb *AppCreateBulk) Save(ctx context.Context) ([]*App, error) {
+	if acb.err != nil {
+		return nil, acb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(acb.builders))
+	nodes := make([]*App, len(acb.builders))
+	mutators := make([]Mutator, len(acb.builders))
+	for i := range acb.builders {
+		func(i int, root context.Context) {
+			builder := acb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*AppMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, acb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, acb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, acb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (acb *AppCreateBulk) SaveX(ctx context.Context) []*App {
+	v, err := acb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (acb *AppCreateBulk) Exec(ctx context.Context) error {
+	_, err := acb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (acb *AppCreateBulk) ExecX(ctx context.Context) {
+	if err := acb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,297 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/yimoka/go/internal/entfixture/ent/app"
+	"github.com/yimoka/go/internal/entfixture/ent/predicate"
+)
+
+// AppUpdate is the builder for updating App entities.
+type AppUpdate struct {
+	config
+	hooks    []Hook
+	mutation *AppMutation
+}
+
+// Where appends a list predicates to the AppUpdate builder.
+func (au *AppUpdate) Where(ps ...predicate.App) *AppUpdate {
+	au.mutation.Where(ps...)
+	return au
+}
+
+// SetSecretID sets the "secretID" field.
+func (au *AppUpdate) SetSecretID(s string) *AppUpdate {
+	au.mutation.SetSecretID(s)
+	return au
+}
+
+// SetNillableSecretID sets the "secretID" field if the given value is not nil.
+func (au *AppUpdate) SetNillableSecretID(s *string) *AppUpdate {
+	if s != nil {
+		au.SetSecretID(*s)
+	}
+	return au
+}
+
+// SetSecretKey sets the "secretKey" field.
+func (au *AppUpdate) SetSecretKey(s string) *AppUpdate {
+	au.mutation.SetSecretKey(s)
+	return au
+}
+
+// SetNillableSecretKey sets the "secretKey" field if the given value is not nil.
+func (au *AppUpdate) SetNillableSecretKey(s *string) *AppUpdate {
+	if s != nil {
+		au.SetSecretKey(*s)
+	}
+	return au
+}
+
+// ClearSecretKey clears the value of the "secretKey" field.
+func (au *AppUpdate) ClearSecretKey() *AppUpdate {
+	au.mutation.ClearSecretKey()
+	return au
+}
+
+// Mutation returns the AppMutation object of the builder.
+func (au *AppUpdate) Mutation() *AppMutation {
+	return au.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (au *AppUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, au.sqlSave, au.mutation, au.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (au *AppUpdate) SaveX(ctx context.Context) int {
+	affected, err := au.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (au *AppUpdate) Exec(ctx context.Context) error {
+	_, err := au.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (au *AppUpdate) ExecX(ctx context.Context) {
+	if err := au.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (au *AppUpdate) check() error {
+	if v, ok := au.mutation.SecretID(); ok {
+		if err := app.SecretIDValidator(v); err != nil {
+			return &ValidationError{Name: "secretID", err: fmt.Errorf(`ent: validator failed for field "App.secretID": %w`, err)}
+		}
+	}
+	if v, ok := au.mutation.SecretKey(); ok {
+		if err := app.SecretKeyValidator(v); err != nil {
+			return &ValidationError{Name: "secretKey", err: fmt.Errorf(`ent: validator failed for field "App.secretKey": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (au *AppUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := au.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(app.Table, app.Columns, sqlgraph.NewFieldSpec(app.FieldID, field.TypeInt))
+	if ps := au.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := au.mutation.SecretID(); ok {
+		_spec.SetField(app.FieldSecretID, field.TypeString, value)
+	}
+	if value, ok := au.mutation.SecretKey(); ok {
+		_spec.SetField(app.FieldSecretKey, field.TypeString, value)
+	}
+	if au.mutation.SecretKeyCleared() {
+		_spec.ClearField(app.FieldSecretKey, field.TypeString)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, au.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{app.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	au.mutation.done = true
+	return n, nil
+}
+
+// AppUpdateOne is the builder for updating a single App entity.
+type AppUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *AppMutation
+}
+
+// SetSecretID sets the "secretID" field.
+func (auo *AppUpdateOne) SetSecretID(s string) *AppUpdateOne {
+	auo.mutation.SetSecretID(s)
+	return auo
+}
+
+// SetNillableSecretID sets the "secretID" field if the given value is not nil.
+func (auo *AppUpdateOne) SetNillableSecretID(s *string) *AppUpdateOne {
+	if s != nil {
+		auo.SetSecretID(*s)
+	}
+	return auo
+}
+
+// SetSecretKey sets the "secretKey" field.
+func (auo *AppUpdateOne) SetSecretKey(s string) *AppUpdateOne {
+	auo.mutation.SetSecretKey(s)
+	return auo
+}
+
+// SetNillableSecretKey sets the "secretKey" field if the given value is not nil.
+func (auo *AppUpdateOne) SetNillableSecretKey(s *string) *AppUpdateOne {
+	if s != nil {
+		auo.SetSecretKey(*s)
+	}
+	return auo
+}
+
+// ClearSecretKey clears the value of the "secretKey" field.
+func (auo *AppUpdateOne) ClearSecretKey() *AppUpdateOne {
+	auo.mutation.ClearSecretKey()
+	return auo
+}
+
+// Mutation returns the AppMutation object of the builder.
+func (auo *AppUpdateOne) Mutation() *AppMutation {
+	return auo.mutation
+}
+
+// Where appends a list predicates to the AppUpdate builder.
+func (auo *AppUpdateOne) Where(ps ...predicate.App) *AppUpdateOne {
+	auo.mutation.Where(ps...)
+	return auo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (auo *AppUpdateOne) Select(field string, fields ...string) *AppUpdateOne {
+	auo.fields = append([]string{field}, fields...)
+	return auo
+}
+
+// Save executes the query and returns the updated App entity.
+func (auo *AppUpdateOne) Save(ctx context.Context) (*App, error) {
+	return withHooks(ctx, auo.sqlSave, auo.mutation, auo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (auo *AppUpdateOne) SaveX(ctx context.Context) *App {
+	node, err := auo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (auo *AppUpdateOne) Exec(ctx context.Context) error {
+	_, err := auo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (auo *AppUpdateOne) ExecX(ctx context.Context) {
+	if err := auo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (auo *AppUpdateOne) check() error {
+	if v, ok := auo.mutation.SecretID(); ok {
+		if err := app.SecretIDValidator(v); err != nil {
+			return &ValidationError{Name: "secretID", err: fmt.Errorf(`ent: validator failed for field "App.secretID": %w`, err)}
+		}
+	}
+	if v, ok := auo.mutation.SecretKey(); ok {
+		if err := app.SecretKeyValidator(v); err != nil {
+			return &ValidationError{Name: "secretKey", err: fmt.Errorf(`ent: validator failed for field "App.secretKey": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (auo *AppUpdateOne) sqlSave(ctx context.Context) (_node *App, err error) {
+	if err := auo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(app.Table, app.Columns, sqlgraph.NewFieldSpec(app.FieldID, field.TypeInt))
+	id, ok := auo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "App.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := auo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, app.FieldID)
+		for _, f := range fields {
+			if !app.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != app.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := auo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := auo.mutation.SecretID(); ok {
+		_spec.SetField(app.FieldSecretID, field.TypeString, value)
+	}
+	if value, ok := auo.mutation.SecretKey(); ok {
+		_spec.SetField(app.FieldSecretKey, field.TypeString, value)
+	}
+	if auo.mutation.SecretKeyCleared() {
+		_spec.ClearField(app.FieldSecretKey, field.TypeString)
+	}
+	_node = &App{config: auo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, auo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{app.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	auo.mutation.done = true
+	return _node, nil
+}
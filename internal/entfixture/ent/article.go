@@ -0,0 +1,125 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/yimoka/go/internal/entfixture/ent/article"
+)
+
+// Article is the model entity for the Article schema.
+type Article struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// 租户 ID
+	TenantID string `json:"tenantID,omitempty"`
+	// appid
+	Appid string `json:"appid,omitempty"`
+	// Title holds the value of the "title" field.
+	Title        string `json:"title,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Article) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case article.FieldID:
+			values[i] = new(sql.NullInt64)
+		case article.FieldTenantID, article.FieldAppid, article.FieldTitle:
+			values[i] = new(sql.NullString)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Article fields.
+func (a *Article) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case article.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			a.ID = int(value.Int64)
+		case article.FieldTenantID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field tenantID", values[i])
+			} else if value.Valid {
+				a.TenantID = value.String
+			}
+		case article.FieldAppid:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field appid", values[i])
+			} else if value.Valid {
+				a.Appid = value.String
+			}
+		case article.FieldTitle:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field title", values[i])
+			} else if value.Valid {
+				a.Title = value.String
+			}
+		default:
+			a.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Article.
+// This includes values selected through modifiers, order, etc.
+func (a *Article) Value(name string) (ent.Value, error) {
+	return a.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this Article.
+// Note that you need to call Article.Unwrap() before calling this method if this Article
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (a *Article) Update() *ArticleUpdateOne {
+	return NewArticleClient(a.config).UpdateOne(a)
+}
+
+// Unwrap unwraps the Article entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (a *Article) Unwrap() *Article {
+	_tx, ok := a.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Article is not a transactional entity")
+	}
+	a.config.driver = _tx.drv
+	return a
+}
+
+// String implements the fmt.Stringer.
+func (a *Article) String() string {
+	var builder strings.Builder
+	builder.WriteString("Article(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", a.ID))
+	builder.WriteString("tenantID=")
+	builder.WriteString(a.TenantID)
+	builder.WriteString(", ")
+	builder.WriteString("appid=")
+	builder.WriteString(a.Appid)
+	builder.WriteString(", ")
+	builder.WriteString("title=")
+	builder.WriteString(a.Title)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Articles is a parsable slice of Article.
+type Articles []*Article
@@ -0,0 +1,78 @@
+// Code generated by ent, DO NOT EDIT.
+
+package article
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the article type in the database.
+	Label = "article"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldTenantID holds the string denoting the tenantid field in the database.
+	FieldTenantID = "tenant_id"
+	// FieldAppid holds the string denoting the appid field in the database.
+	FieldAppid = "appid"
+	// FieldTitle holds the string denoting the title field in the database.
+	FieldTitle = "title"
+	// Table holds the table name of the article in the database.
+	Table = "articles"
+)
+
+// Columns holds all SQL columns for article fields.
+var Columns = []string{
+	FieldID,
+	FieldTenantID,
+	FieldAppid,
+	FieldTitle,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// Note that the variables below are initialized by the runtime
+// package on the initialization of the application. Therefore,
+// it should be imported in the main as follows:
+//
+//	import _ "github.com/yimoka/go/internal/entfixture/ent/runtime"
+var (
+	Hooks        [2]ent.Hook
+	Interceptors [2]ent.Interceptor
+	// TenantIDValidator is a validator for the "tenantID" field. It is called by the builders before save.
+	TenantIDValidator func(string) error
+	// AppidValidator is a validator for the "appid" field. It is called by the builders before save.
+	AppidValidator func(string) error
+)
+
+// OrderOption defines the ordering options for the Article queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByTenantID orders the results by the tenantID field.
+func ByTenantID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTenantID, opts...).ToFunc()
+}
+
+// ByAppid orders the results by the appid field.
+func ByAppid(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAppid, opts...).ToFunc()
+}
+
+// ByTitle orders the results by the title field.
+func ByTitle(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTitle, opts...).ToFunc()
+}
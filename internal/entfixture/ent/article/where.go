@@ -0,0 +1,288 @@
+// Code generated by ent, DO NOT EDIT.
+
+package article
+
+import (
+	"entgo.io/ent/dialect/sql"
+	"github.com/yimoka/go/internal/entfixture/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.Article {
+	return predicate.Article(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.Article {
+	return predicate.Article(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.Article {
+	return predicate.Article(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.Article {
+	return predicate.Article(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.Article {
+	return predicate.Article(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.Article {
+	return predicate.Article(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.Article {
+	return predicate.Article(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.Article {
+	return predicate.Article(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.Article {
+	return predicate.Article(sql.FieldLTE(FieldID, id))
+}
+
+// TenantID applies equality check predicate on the "tenantID" field. It's identical to TenantIDEQ.
+func TenantID(v string) predicate.Article {
+	return predicate.Article(sql.FieldEQ(FieldTenantID, v))
+}
+
+// Appid applies equality check predicate on the "appid" field. It's identical to AppidEQ.
+func Appid(v string) predicate.Article {
+	return predicate.Article(sql.FieldEQ(FieldAppid, v))
+}
+
+// Title applies equality check predicate on the "title" field. It's identical to TitleEQ.
+func Title(v string) predicate.Article {
+	return predicate.Article(sql.FieldEQ(FieldTitle, v))
+}
+
+// TenantIDEQ applies the EQ predicate on the "tenantID" field.
+func TenantIDEQ(v string) predicate.Article {
+	return predicate.Article(sql.FieldEQ(FieldTenantID, v))
+}
+
+// TenantIDNEQ applies the NEQ predicate on the "tenantID" field.
+func TenantIDNEQ(v string) predicate.Article {
+	return predicate.Article(sql.FieldNEQ(FieldTenantID, v))
+}
+
+// TenantIDIn applies the In predicate on the "tenantID" field.
+func TenantIDIn(vs ...string) predicate.Article {
+	return predicate.Article(sql.FieldIn(FieldTenantID, vs...))
+}
+
+// TenantIDNotIn applies the NotIn predicate on the "tenantID" field.
+func TenantIDNotIn(vs ...string) predicate.Article {
+	return predicate.Article(sql.FieldNotIn(FieldTenantID, vs...))
+}
+
+// TenantIDGT applies the GT predicate on the "tenantID" field.
+func TenantIDGT(v string) predicate.Article {
+	return predicate.Article(sql.FieldGT(FieldTenantID, v))
+}
+
+// TenantIDGTE applies the GTE predicate on the "tenantID" field.
+func TenantIDGTE(v string) predicate.Article {
+	return predicate.Article(sql.FieldGTE(FieldTenantID, v))
+}
+
+// TenantIDLT applies the LT predicate on the "tenantID" field.
+func TenantIDLT(v string) predicate.Article {
+	return predicate.Article(sql.FieldLT(FieldTenantID, v))
+}
+
+// TenantIDLTE applies the LTE predicate on the "tenantID" field.
+func TenantIDLTE(v string) predicate.Article {
+	return predicate.Article(sql.FieldLTE(FieldTenantID, v))
+}
+
+// TenantIDContains applies the Contains predicate on the "tenantID" field.
+func TenantIDContains(v string) predicate.Article {
+	return predicate.Article(sql.FieldContains(FieldTenantID, v))
+}
+
+// TenantIDHasPrefix applies the HasPrefix predicate on the "tenantID" field.
+func TenantIDHasPrefix(v string) predicate.Article {
+	return predicate.Article(sql.FieldHasPrefix(FieldTenantID, v))
+}
+
+// TenantIDHasSuffix applies the HasSuffix predicate on the "tenantID" field.
+func TenantIDHasSuffix(v string) predicate.Article {
+	return predicate.Article(sql.FieldHasSuffix(FieldTenantID, v))
+}
+
+// TenantIDEqualFold applies the EqualFold predicate on the "tenantID" field.
+func TenantIDEqualFold(v string) predicate.Article {
+	return predicate.Article(sql.FieldEqualFold(FieldTenantID, v))
+}
+
+// TenantIDContainsFold applies the ContainsFold predicate on the "tenantID" field.
+func TenantIDContainsFold(v string) predicate.Article {
+	return predicate.Article(sql.FieldContainsFold(FieldTenantID, v))
+}
+
+// AppidEQ applies the EQ predicate on the "appid" field.
+func AppidEQ(v string) predicate.Article {
+	return predicate.Article(sql.FieldEQ(FieldAppid, v))
+}
+
+// AppidNEQ applies the NEQ predicate on the "appid" field.
+func AppidNEQ(v string) predicate.Article {
+	return predicate.Article(sql.FieldNEQ(FieldAppid, v))
+}
+
+// AppidIn applies the In predicate on the "appid" field.
+func AppidIn(vs ...string) predicate.Article {
+	return predicate.Article(sql.FieldIn(FieldAppid, vs...))
+}
+
+// AppidNotIn applies the NotIn predicate on the "appid" field.
+func AppidNotIn(vs ...string) predicate.Article {
+	return predicate.Article(sql.FieldNotIn(FieldAppid, vs...))
+}
+
+// AppidGT applies the GT predicate on the "appid" field.
+func AppidGT(v string) predicate.Article {
+	return predicate.Article(sql.FieldGT(FieldAppid, v))
+}
+
+// AppidGTE applies the GTE predicate on the "appid" field.
+func AppidGTE(v string) predicate.Article {
+	return predicate.Article(sql.FieldGTE(FieldAppid, v))
+}
+
+// AppidLT applies the LT predicate on the "appid" field.
+func AppidLT(v string) predicate.Article {
+	return predicate.Article(sql.FieldLT(FieldAppid, v))
+}
+
+// AppidLTE applies the LTE predicate on the "appid" field.
+func AppidLTE(v string) predicate.Article {
+	return predicate.Article(sql.FieldLTE(FieldAppid, v))
+}
+
+// AppidContains applies the Contains predicate on the "appid" field.
+func AppidContains(v string) predicate.Article {
+	return predicate.Article(sql.FieldContains(FieldAppid, v))
+}
+
+// AppidHasPrefix applies the HasPrefix predicate on the "appid" field.
+func AppidHasPrefix(v string) predicate.Article {
+	return predicate.Article(sql.FieldHasPrefix(FieldAppid, v))
+}
+
+// AppidHasSuffix applies the HasSuffix predicate on the "appid" field.
+func AppidHasSuffix(v string) predicate.Article {
+	return predicate.Article(sql.FieldHasSuffix(FieldAppid, v))
+}
+
+// AppidEqualFold applies the EqualFold predicate on the "appid" field.
+func AppidEqualFold(v string) predicate.Article {
+	return predicate.Article(sql.FieldEqualFold(FieldAppid, v))
+}
+
+// AppidContainsFold applies the ContainsFold predicate on the "appid" field.
+func AppidContainsFold(v string) predicate.Article {
+	return predicate.Article(sql.FieldContainsFold(FieldAppid, v))
+}
+
+// TitleEQ applies the EQ predicate on the "title" field.
+func TitleEQ(v string) predicate.Article {
+	return predicate.Article(sql.FieldEQ(FieldTitle, v))
+}
+
+// TitleNEQ applies the NEQ predicate on the "title" field.
+func TitleNEQ(v string) predicate.Article {
+	return predicate.Article(sql.FieldNEQ(FieldTitle, v))
+}
+
+// TitleIn applies the In predicate on the "title" field.
+func TitleIn(vs ...string) predicate.Article {
+	return predicate.Article(sql.FieldIn(FieldTitle, vs...))
+}
+
+// TitleNotIn applies the NotIn predicate on the "title" field.
+func TitleNotIn(vs ...string) predicate.Article {
+	return predicate.Article(sql.FieldNotIn(FieldTitle, vs...))
+}
+
+// TitleGT applies the GT predicate on the "title" field.
+func TitleGT(v string) predicate.Article {
+	return predicate.Article(sql.FieldGT(FieldTitle, v))
+}
+
+// TitleGTE applies the GTE predicate on the "title" field.
+func TitleGTE(v string) predicate.Article {
+	return predicate.Article(sql.FieldGTE(FieldTitle, v))
+}
+
+// TitleLT applies the LT predicate on the "title" field.
+func TitleLT(v string) predicate.Article {
+	return predicate.Article(sql.FieldLT(FieldTitle, v))
+}
+
+// TitleLTE applies the LTE predicate on the "title" field.
+func TitleLTE(v string) predicate.Article {
+	return predicate.Article(sql.FieldLTE(FieldTitle, v))
+}
+
+// TitleContains applies the Contains predicate on the "title" field.
+func TitleContains(v string) predicate.Article {
+	return predicate.Article(sql.FieldContains(FieldTitle, v))
+}
+
+// TitleHasPrefix applies the HasPrefix predicate on the "title" field.
+func TitleHasPrefix(v string) predicate.Article {
+	return predicate.Article(sql.FieldHasPrefix(FieldTitle, v))
+}
+
+// TitleHasSuffix applies the HasSuffix predicate on the "title" field.
+func TitleHasSuffix(v string) predicate.Article {
+	return predicate.Article(sql.FieldHasSuffix(FieldTitle, v))
+}
+
+// TitleIsNil applies the IsNil predicate on the "title" field.
+func TitleIsNil() predicate.Article {
+	return predicate.Article(sql.FieldIsNull(FieldTitle))
+}
+
+// TitleNotNil applies the NotNil predicate on the "title" field.
+func TitleNotNil() predicate.Article {
+	return predicate.Article(sql.FieldNotNull(FieldTitle))
+}
+
+// TitleEqualFold applies the EqualFold predicate on the "title" field.
+func TitleEqualFold(v string) predicate.Article {
+	return predicate.Article(sql.FieldEqualFold(FieldTitle, v))
+}
+
+// TitleContainsFold applies the ContainsFold predicate on the "title" field.
+func TitleContainsFold(v string) predicate.Article {
+	return predicate.Article(sql.FieldContainsFold(FieldTitle, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Article) predicate.Article {
+	return predicate.Article(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Article) predicate.Article {
+	return predicate.Article(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Article) predicate.Article {
+	return predicate.Article(sql.NotPredicates(p))
+}
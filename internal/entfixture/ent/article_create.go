@@ -0,0 +1,224 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/yimoka/go/internal/entfixture/ent/article"
+)
+
+// ArticleCreate is the builder for creating a Article entity.
+type ArticleCreate struct {
+	config
+	mutation *ArticleMutation
+	hooks    []Hook
+}
+
+// SetTenantID sets the "tenantID" field.
+func (ac *ArticleCreate) SetTenantID(s string) *ArticleCreate {
+	ac.mutation.SetTenantID(s)
+	return ac
+}
+
+// SetAppid sets the "appid" field.
+func (ac *ArticleCreate) SetAppid(s string) *ArticleCreate {
+	ac.mutation.SetAppid(s)
+	return ac
+}
+
+// SetTitle sets the "title" field.
+func (ac *ArticleCreate) SetTitle(s string) *ArticleCreate {
+	ac.mutation.SetTitle(s)
+	return ac
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (ac *ArticleCreate) SetNillableTitle(s *string) *ArticleCreate {
+	if s != nil {
+		ac.SetTitle(*s)
+	}
+	return ac
+}
+
+// Mutation returns the ArticleMutation object of the builder.
+func (ac *ArticleCreate) Mutation() *ArticleMutation {
+	return ac.mutation
+}
+
+// Save creates the Article in the database.
+func (ac *ArticleCreate) Save(ctx context.Context) (*Article, error) {
+	return withHooks(ctx, ac.sqlSave, ac.mutation, ac.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (ac *ArticleCreate) SaveX(ctx context.Context) *Article {
+	v, err := ac.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ac *ArticleCreate) Exec(ctx context.Context) error {
+	_, err := ac.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ac *ArticleCreate) ExecX(ctx context.Context) {
+	if err := ac.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ac *ArticleCreate) check() error {
+	if _, ok := ac.mutation.TenantID(); !ok {
+		return &ValidationError{Name: "tenantID", err: errors.New(`ent: missing required field "Article.tenantID"`)}
+	}
+	if v, ok := ac.mutation.TenantID(); ok {
+		if err := article.TenantIDValidator(v); err != nil {
+			return &ValidationError{Name: "tenantID", err: fmt.Errorf(`ent: validator failed for field "Article.tenantID": %w`, err)}
+		}
+	}
+	if _, ok := ac.mutation.Appid(); !ok {
+		return &ValidationError{Name: "appid", err: errors.New(`ent: missing required field "Article.appid"`)}
+	}
+	if v, ok := ac.mutation.Appid(); ok {
+		if err := article.AppidValidator(v); err != nil {
+			return &ValidationError{Name: "appid", err: fmt.Errorf(`ent: validator failed for field "Article.appid": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ac *ArticleCreate) sqlSave(ctx context.Context) (*Article, error) {
+	if err := ac.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := ac.createSpec()
+	if err := sqlgraph.CreateNode(ctx, ac.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	ac.mutation.id = &_node.ID
+	ac.mutation.done = true
+	return _node, nil
+}
+
+func (ac *ArticleCreate) createSpec() (*Article, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Article{config: ac.config}
+		_spec = sqlgraph.NewCreateSpec(article.Table, sqlgraph.NewFieldSpec(article.FieldID, field.TypeInt))
+	)
+	if value, ok := ac.mutation.TenantID(); ok {
+		_spec.SetField(article.FieldTenantID, field.TypeString, value)
+		_node.TenantID = value
+	}
+	if value, ok := ac.mutation.Appid(); ok {
+		_spec.SetField(article.FieldAppid, field.TypeString, value)
+		_node.Appid = value
+	}
+	if value, ok := ac.mutation.Title(); ok {
+		_spec.SetField(article.FieldTitle, field.TypeString, value)
+		_node.Title = value
+	}
+	return _node, _spec
+}
+
+// ArticleCreateBulk is the builder for creating many Article entities in bulk.
+type ArticleCreateBulk struct {
+	config
+	err      error
+	builders []*ArticleCreate
+}
+
+// Save creates the Article entities in the database.
+func (acb *ArticleCreateBulk) Save(ctx context.Context) ([]*Article, error) {
+	if acb.err != nil {
+		return nil, acb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(acb.builders))
+	nodes := make([]*Article, len(acb.builders))
+	mutators := make([]Mutator, len(acb.builders))
+	for i := range acb.builders {
+		func(i int, root context.Context) {
+			builder := acb.builders[i]
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ArticleMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, acb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, acb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, acb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (acb *ArticleCreateBulk) SaveX(ctx context.Context) []*Article {
+	v, err := acb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (acb *ArticleCreateBulk) Exec(ctx context.Context) error {
+	_, err := acb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (acb *ArticleCreateBulk) ExecX(ctx context.Context) {
+	if err := acb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
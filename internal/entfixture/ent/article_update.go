@@ -0,0 +1,227 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/yimoka/go/internal/entfixture/ent/article"
+	"github.com/yimoka/go/internal/entfixture/ent/predicate"
+)
+
+// ArticleUpdate is the builder for updating Article entities.
+type ArticleUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ArticleMutation
+}
+
+// Where appends a list predicates to the ArticleUpdate builder.
+func (au *ArticleUpdate) Where(ps ...predicate.Article) *ArticleUpdate {
+	au.mutation.Where(ps...)
+	return au
+}
+
+// SetTitle sets the "title" field.
+func (au *ArticleUpdate) SetTitle(s string) *ArticleUpdate {
+	au.mutation.SetTitle(s)
+	return au
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (au *ArticleUpdate) SetNillableTitle(s *string) *ArticleUpdate {
+	if s != nil {
+		au.SetTitle(*s)
+	}
+	return au
+}
+
+// ClearTitle clears the value of the "title" field.
+func (au *ArticleUpdate) ClearTitle() *ArticleUpdate {
+	au.mutation.ClearTitle()
+	return au
+}
+
+// Mutation returns the ArticleMutation object of the builder.
+func (au *ArticleUpdate) Mutation() *ArticleMutation {
+	return au.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (au *ArticleUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, au.sqlSave, au.mutation, au.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (au *ArticleUpdate) SaveX(ctx context.Context) int {
+	affected, err := au.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (au *ArticleUpdate) Exec(ctx context.Context) error {
+	_, err := au.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (au *ArticleUpdate) ExecX(ctx context.Context) {
+	if err := au.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (au *ArticleUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(article.Table, article.Columns, sqlgraph.NewFieldSpec(article.FieldID, field.TypeInt))
+	if ps := au.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := au.mutation.Title(); ok {
+		_spec.SetField(article.FieldTitle, field.TypeString, value)
+	}
+	if au.mutation.TitleCleared() {
+		_spec.ClearField(article.FieldTitle, field.TypeString)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, au.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{article.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	au.mutation.done = true
+	return n, nil
+}
+
+// ArticleUpdateOne is the builder for updating a single Article entity.
+type ArticleUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ArticleMutation
+}
+
+// SetTitle sets the "title" field.
+func (auo *ArticleUpdateOne) SetTitle(s string) *ArticleUpdateOne {
+	auo.mutation.SetTitle(s)
+	return auo
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (auo *ArticleUpdateOne) SetNillableTitle(s *string) *ArticleUpdateOne {
+	if s != nil {
+		auo.SetTitle(*s)
+	}
+	return auo
+}
+
+// ClearTitle clears the value of the "title" field.
+func (auo *ArticleUpdateOne) ClearTitle() *ArticleUpdateOne {
+	auo.mutation.ClearTitle()
+	return auo
+}
+
+// Mutation returns the ArticleMutation object of the builder.
+func (auo *ArticleUpdateOne) Mutation() *ArticleMutation {
+	return auo.mutation
+}
+
+// Where appends a list predicates to the ArticleUpdate builder.
+func (auo *ArticleUpdateOne) Where(ps ...predicate.Article) *ArticleUpdateOne {
+	auo.mutation.Where(ps...)
+	return auo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (auo *ArticleUpdateOne) Select(field string, fields ...string) *ArticleUpdateOne {
+	auo.fields = append([]string{field}, fields...)
+	return auo
+}
+
+// Save executes the query and returns the updated Article entity.
+func (auo *ArticleUpdateOne) Save(ctx context.Context) (*Article, error) {
+	return withHooks(ctx, auo.sqlSave, auo.mutation, auo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (auo *ArticleUpdateOne) SaveX(ctx context.Context) *Article {
+	node, err := auo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (auo *ArticleUpdateOne) Exec(ctx context.Context) error {
+	_, err := auo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (auo *ArticleUpdateOne) ExecX(ctx context.Context) {
+	if err := auo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (auo *ArticleUpdateOne) sqlSave(ctx context.Context) (_node *Article, err error) {
+	_spec := sqlgraph.NewUpdateSpec(article.Table, article.Columns, sqlgraph.NewFieldSpec(article.FieldID, field.TypeInt))
+	id, ok := auo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Article.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := auo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, article.FieldID)
+		for _, f := range fields {
+			if !article.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != article.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := auo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := auo.mutation.Title(); ok {
+		_spec.SetField(article.FieldTitle, field.TypeString, value)
+	}
+	if auo.mutation.TitleCleared() {
+		_spec.ClearField(article.FieldTitle, field.TypeString)
+	}
+	_node = &Article{config: auo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, auo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{article.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	auo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,147 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/yimoka/go/internal/entfixture/ent/contact"
+)
+
+// Contact is the model entity for the Contact schema.
+type Contact struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// 租户 ID
+	TenantID string `json:"tenantID,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// Phone holds the value of the "phone" field.
+	Phone string `json:"phone,omitempty"`
+	// PhoneCipher holds the value of the "phoneCipher" field.
+	PhoneCipher string `json:"phoneCipher,omitempty"`
+	// PhoneBidx holds the value of the "phoneBidx" field.
+	PhoneBidx    string `json:"phoneBidx,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Contact) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case contact.FieldID:
+			values[i] = new(sql.NullInt64)
+		case contact.FieldTenantID, contact.FieldName, contact.FieldPhone, contact.FieldPhoneCipher, contact.FieldPhoneBidx:
+			values[i] = new(sql.NullString)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Contact fields.
+func (c *Contact) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case contact.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			c.ID = int(value.Int64)
+		case contact.FieldTenantID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field tenantID", values[i])
+			} else if value.Valid {
+				c.TenantID = value.String
+			}
+		case contact.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				c.Name = value.String
+			}
+		case contact.FieldPhone:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field phone", values[i])
+			} else if value.Valid {
+				c.Phone = value.String
+			}
+		case contact.FieldPhoneCipher:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field phoneCipher", values[i])
+			} else if value.Valid {
+				c.PhoneCipher = value.String
+			}
+		case contact.FieldPhoneBidx:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field phoneBidx", values[i])
+			} else if value.Valid {
+				c.PhoneBidx = value.String
+			}
+		default:
+			c.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Contact.
+// This includes values selected through modifiers, order, etc.
+func (c *Contact) Value(name string) (ent.Value, error) {
+	return c.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this Contact.
+// Note that you need to call Contact.Unwrap() before calling this method if this Contact
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (c *Contact) Update() *ContactUpdateOne {
+	return NewContactClient(c.config).UpdateOne(c)
+}
+
+// Unwrap unwraps the Contact entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (c *Contact) Unwrap() *Contact {
+	_tx, ok := c.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Contact is not a transactional entity")
+	}
+	c.config.driver = _tx.drv
+	return c
+}
+
+// String implements the fmt.Stringer.
+func (c *Contact) String() string {
+	var builder strings.Builder
+	builder.WriteString("Contact(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", c.ID))
+	builder.WriteString("tenantID=")
+	builder.WriteString(c.TenantID)
+	builder.WriteString(", ")
+	builder.WriteString("name=")
+	builder.WriteString(c.Name)
+	builder.WriteString(", ")
+	builder.WriteString("phone=")
+	builder.WriteString(c.Phone)
+	builder.WriteString(", ")
+	builder.WriteString("phoneCipher=")
+	builder.WriteString(c.PhoneCipher)
+	builder.WriteString(", ")
+	builder.WriteString("phoneBidx=")
+	builder.WriteString(c.PhoneBidx)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Contacts is a parsable slice of Contact.
+type Contacts []*Contact
@@ -0,0 +1,84 @@
+// Code generated by ent, DO NOT EDIT.
+
+package contact
+
+import (
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the contact type in the database.
+	Label = "contact"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldTenantID holds the string denoting the tenantid field in the database.
+	FieldTenantID = "tenant_id"
+	// FieldName holds the string denoting the name field in the database.
+	FieldName = "name"
+	// FieldPhone holds the string denoting the phone field in the database.
+	FieldPhone = "phone"
+	// FieldPhoneCipher holds the string denoting the phonecipher field in the database.
+	FieldPhoneCipher = "phone_cipher"
+	// FieldPhoneBidx holds the string denoting the phonebidx field in the database.
+	FieldPhoneBidx = "phone_bidx"
+	// Table holds the table name of the contact in the database.
+	Table = "contacts"
+)
+
+// Columns holds all SQL columns for contact fields.
+var Columns = []string{
+	FieldID,
+	FieldTenantID,
+	FieldName,
+	FieldPhone,
+	FieldPhoneCipher,
+	FieldPhoneBidx,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// TenantIDValidator is a validator for the "tenantID" field. It is called by the builders before save.
+	TenantIDValidator func(string) error
+)
+
+// OrderOption defines the ordering options for the Contact queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByTenantID orders the results by the tenantID field.
+func ByTenantID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTenantID, opts...).ToFunc()
+}
+
+// ByName orders the results by the name field.
+func ByName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldName, opts...).ToFunc()
+}
+
+// ByPhone orders the results by the phone field.
+func ByPhone(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPhone, opts...).ToFunc()
+}
+
+// ByPhoneCipher orders the results by the phoneCipher field.
+func ByPhoneCipher(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPhoneCipher, opts...).ToFunc()
+}
+
+// ByPhoneBidx orders the results by the phoneBidx field.
+func ByPhoneBidx(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPhoneBidx, opts...).ToFunc()
+}
@@ -0,0 +1,458 @@
+// Code generated by ent, DO NOT EDIT.
+
+package contact
+
+import (
+	"entgo.io/ent/dialect/sql"
+	"github.com/yimoka/go/internal/entfixture/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.Contact {
+	return predicate.Contact(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.Contact {
+	return predicate.Contact(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.Contact {
+	return predicate.Contact(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.Contact {
+	return predicate.Contact(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.Contact {
+	return predicate.Contact(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.Contact {
+	return predicate.Contact(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.Contact {
+	return predicate.Contact(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.Contact {
+	return predicate.Contact(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.Contact {
+	return predicate.Contact(sql.FieldLTE(FieldID, id))
+}
+
+// TenantID applies equality check predicate on the "tenantID" field. It's identical to TenantIDEQ.
+func TenantID(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldEQ(FieldTenantID, v))
+}
+
+// Name applies equality check predicate on the "name" field. It's identical to NameEQ.
+func Name(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldEQ(FieldName, v))
+}
+
+// Phone applies equality check predicate on the "phone" field. It's identical to PhoneEQ.
+func Phone(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldEQ(FieldPhone, v))
+}
+
+// PhoneCipher applies equality check predicate on the "phoneCipher" field. It's identical to PhoneCipherEQ.
+func PhoneCipher(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldEQ(FieldPhoneCipher, v))
+}
+
+// PhoneBidx applies equality check predicate on the "phoneBidx" field. It's identical to PhoneBidxEQ.
+func PhoneBidx(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldEQ(FieldPhoneBidx, v))
+}
+
+// TenantIDEQ applies the EQ predicate on the "tenantID" field.
+func TenantIDEQ(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldEQ(FieldTenantID, v))
+}
+
+// TenantIDNEQ applies the NEQ predicate on the "tenantID" field.
+func TenantIDNEQ(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldNEQ(FieldTenantID, v))
+}
+
+// TenantIDIn applies the In predicate on the "tenantID" field.
+func TenantIDIn(vs ...string) predicate.Contact {
+	return predicate.Contact(sql.FieldIn(FieldTenantID, vs...))
+}
+
+// TenantIDNotIn applies the NotIn predicate on the "tenantID" field.
+func TenantIDNotIn(vs ...string) predicate.Contact {
+	return predicate.Contact(sql.FieldNotIn(FieldTenantID, vs...))
+}
+
+// TenantIDGT applies the GT predicate on the "tenantID" field.
+func TenantIDGT(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldGT(FieldTenantID, v))
+}
+
+// TenantIDGTE applies the GTE predicate on the "tenantID" field.
+func TenantIDGTE(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldGTE(FieldTenantID, v))
+}
+
+// TenantIDLT applies the LT predicate on the "tenantID" field.
+func TenantIDLT(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldLT(FieldTenantID, v))
+}
+
+// TenantIDLTE applies the LTE predicate on the "tenantID" field.
+func TenantIDLTE(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldLTE(FieldTenantID, v))
+}
+
+// TenantIDContains applies the Contains predicate on the "tenantID" field.
+func TenantIDContains(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldContains(FieldTenantID, v))
+}
+
+// TenantIDHasPrefix applies the HasPrefix predicate on the "tenantID" field.
+func TenantIDHasPrefix(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldHasPrefix(FieldTenantID, v))
+}
+
+// TenantIDHasSuffix applies the HasSuffix predicate on the "tenantID" field.
+func TenantIDHasSuffix(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldHasSuffix(FieldTenantID, v))
+}
+
+// TenantIDEqualFold applies the EqualFold predicate on the "tenantID" field.
+func TenantIDEqualFold(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldEqualFold(FieldTenantID, v))
+}
+
+// TenantIDContainsFold applies the ContainsFold predicate on the "tenantID" field.
+func TenantIDContainsFold(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldContainsFold(FieldTenantID, v))
+}
+
+// NameEQ applies the EQ predicate on the "name" field.
+func NameEQ(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldEQ(FieldName, v))
+}
+
+// NameNEQ applies the NEQ predicate on the "name" field.
+func NameNEQ(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldNEQ(FieldName, v))
+}
+
+// NameIn applies the In predicate on the "name" field.
+func NameIn(vs ...string) predicate.Contact {
+	return predicate.Contact(sql.FieldIn(FieldName, vs...))
+}
+
+// NameNotIn applies the NotIn predicate on the "name" field.
+func NameNotIn(vs ...string) predicate.Contact {
+	return predicate.Contact(sql.FieldNotIn(FieldName, vs...))
+}
+
+// NameGT applies the GT predicate on the "name" field.
+func NameGT(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldGT(FieldName, v))
+}
+
+// NameGTE applies the GTE predicate on the "name" field.
+func NameGTE(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldGTE(FieldName, v))
+}
+
+// NameLT applies the LT predicate on the "name" field.
+func NameLT(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldLT(FieldName, v))
+}
+
+// NameLTE applies the LTE predicate on the "name" field.
+func NameLTE(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldLTE(FieldName, v))
+}
+
+// NameContains applies the Contains predicate on the "name" field.
+func NameContains(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldContains(FieldName, v))
+}
+
+// NameHasPrefix applies the HasPrefix predicate on the "name" field.
+func NameHasPrefix(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldHasPrefix(FieldName, v))
+}
+
+// NameHasSuffix applies the HasSuffix predicate on the "name" field.
+func NameHasSuffix(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldHasSuffix(FieldName, v))
+}
+
+// NameIsNil applies the IsNil predicate on the "name" field.
+func NameIsNil() predicate.Contact {
+	return predicate.Contact(sql.FieldIsNull(FieldName))
+}
+
+// NameNotNil applies the NotNil predicate on the "name" field.
+func NameNotNil() predicate.Contact {
+	return predicate.Contact(sql.FieldNotNull(FieldName))
+}
+
+// NameEqualFold applies the EqualFold predicate on the "name" field.
+func NameEqualFold(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldEqualFold(FieldName, v))
+}
+
+// NameContainsFold applies the ContainsFold predicate on the "name" field.
+func NameContainsFold(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldContainsFold(FieldName, v))
+}
+
+// PhoneEQ applies the EQ predicate on the "phone" field.
+func PhoneEQ(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldEQ(FieldPhone, v))
+}
+
+// PhoneNEQ applies the NEQ predicate on the "phone" field.
+func PhoneNEQ(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldNEQ(FieldPhone, v))
+}
+
+// PhoneIn applies the In predicate on the "phone" field.
+func PhoneIn(vs ...string) predicate.Contact {
+	return predicate.Contact(sql.FieldIn(FieldPhone, vs...))
+}
+
+// PhoneNotIn applies the NotIn predicate on the "phone" field.
+func PhoneNotIn(vs ...string) predicate.Contact {
+	return predicate.Contact(sql.FieldNotIn(FieldPhone, vs...))
+}
+
+// PhoneGT applies the GT predicate on the "phone" field.
+func PhoneGT(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldGT(FieldPhone, v))
+}
+
+// PhoneGTE applies the GTE predicate on the "phone" field.
+func PhoneGTE(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldGTE(FieldPhone, v))
+}
+
+// PhoneLT applies the LT predicate on the "phone" field.
+func PhoneLT(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldLT(FieldPhone, v))
+}
+
+// PhoneLTE applies the LTE predicate on the "phone" field.
+func PhoneLTE(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldLTE(FieldPhone, v))
+}
+
+// PhoneContains applies the Contains predicate on the "phone" field.
+func PhoneContains(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldContains(FieldPhone, v))
+}
+
+// PhoneHasPrefix applies the HasPrefix predicate on the "phone" field.
+func PhoneHasPrefix(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldHasPrefix(FieldPhone, v))
+}
+
+// PhoneHasSuffix applies the HasSuffix predicate on the "phone" field.
+func PhoneHasSuffix(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldHasSuffix(FieldPhone, v))
+}
+
+// PhoneIsNil applies the IsNil predicate on the "phone" field.
+func PhoneIsNil() predicate.Contact {
+	return predicate.Contact(sql.FieldIsNull(FieldPhone))
+}
+
+// PhoneNotNil applies the NotNil predicate on the "phone" field.
+func PhoneNotNil() predicate.Contact {
+	return predicate.Contact(sql.FieldNotNull(FieldPhone))
+}
+
+// PhoneEqualFold applies the EqualFold predicate on the "phone" field.
+func PhoneEqualFold(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldEqualFold(FieldPhone, v))
+}
+
+// PhoneContainsFold applies the ContainsFold predicate on the "phone" field.
+func PhoneContainsFold(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldContainsFold(FieldPhone, v))
+}
+
+// PhoneCipherEQ applies the EQ predicate on the "phoneCipher" field.
+func PhoneCipherEQ(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldEQ(FieldPhoneCipher, v))
+}
+
+// PhoneCipherNEQ applies the NEQ predicate on the "phoneCipher" field.
+func PhoneCipherNEQ(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldNEQ(FieldPhoneCipher, v))
+}
+
+// PhoneCipherIn applies the In predicate on the "phoneCipher" field.
+func PhoneCipherIn(vs ...string) predicate.Contact {
+	return predicate.Contact(sql.FieldIn(FieldPhoneCipher, vs...))
+}
+
+// PhoneCipherNotIn applies the NotIn predicate on the "phoneCipher" field.
+func PhoneCipherNotIn(vs ...string) predicate.Contact {
+	return predicate.Contact(sql.FieldNotIn(FieldPhoneCipher, vs...))
+}
+
+// PhoneCipherGT applies the GT predicate on the "phoneCipher" field.
+func PhoneCipherGT(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldGT(FieldPhoneCipher, v))
+}
+
+// PhoneCipherGTE applies the GTE predicate on the "phoneCipher" field.
+func PhoneCipherGTE(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldGTE(FieldPhoneCipher, v))
+}
+
+// PhoneCipherLT applies the LT predicate on the "phoneCipher" field.
+func PhoneCipherLT(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldLT(FieldPhoneCipher, v))
+}
+
+// PhoneCipherLTE applies the LTE predicate on the "phoneCipher" field.
+func PhoneCipherLTE(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldLTE(FieldPhoneCipher, v))
+}
+
+// PhoneCipherContains applies the Contains predicate on the "phoneCipher" field.
+func PhoneCipherContains(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldContains(FieldPhoneCipher, v))
+}
+
+// PhoneCipherHasPrefix applies the HasPrefix predicate on the "phoneCipher" field.
+func PhoneCipherHasPrefix(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldHasPrefix(FieldPhoneCipher, v))
+}
+
+// PhoneCipherHasSuffix applies the HasSuffix predicate on the "phoneCipher" field.
+func PhoneCipherHasSuffix(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldHasSuffix(FieldPhoneCipher, v))
+}
+
+// PhoneCipherIsNil applies the IsNil predicate on the "phoneCipher" field.
+func PhoneCipherIsNil() predicate.Contact {
+	return predicate.Contact(sql.FieldIsNull(FieldPhoneCipher))
+}
+
+// PhoneCipherNotNil applies the NotNil predicate on the "phoneCipher" field.
+func PhoneCipherNotNil() predicate.Contact {
+	return predicate.Contact(sql.FieldNotNull(FieldPhoneCipher))
+}
+
+// PhoneCipherEqualFold applies the EqualFold predicate on the "phoneCipher" field.
+func PhoneCipherEqualFold(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldEqualFold(FieldPhoneCipher, v))
+}
+
+// PhoneCipherContainsFold applies the ContainsFold predicate on the "phoneCipher" field.
+func PhoneCipherContainsFold(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldContainsFold(FieldPhoneCipher, v))
+}
+
+// PhoneBidxEQ applies the EQ predicate on the "phoneBidx" field.
+func PhoneBidxEQ(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldEQ(FieldPhoneBidx, v))
+}
+
+// PhoneBidxNEQ applies the NEQ predicate on the "phoneBidx" field.
+func PhoneBidxNEQ(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldNEQ(FieldPhoneBidx, v))
+}
+
+// PhoneBidxIn applies the In predicate on the "phoneBidx" field.
+func PhoneBidxIn(vs ...string) predicate.Contact {
+	return predicate.Contact(sql.FieldIn(FieldPhoneBidx, vs...))
+}
+
+// PhoneBidxNotIn applies the NotIn predicate on the "phoneBidx" field.
+func PhoneBidxNotIn(vs ...string) predicate.Contact {
+	return predicate.Contact(sql.FieldNotIn(FieldPhoneBidx, vs...))
+}
+
+// PhoneBidxGT applies the GT predicate on the "phoneBidx" field.
+func PhoneBidxGT(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldGT(FieldPhoneBidx, v))
+}
+
+// PhoneBidxGTE applies the GTE predicate on the "phoneBidx" field.
+func PhoneBidxGTE(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldGTE(FieldPhoneBidx, v))
+}
+
+// PhoneBidxLT applies the LT predicate on the "phoneBidx" field.
+func PhoneBidxLT(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldLT(FieldPhoneBidx, v))
+}
+
+// PhoneBidxLTE applies the LTE predicate on the "phoneBidx" field.
+func PhoneBidxLTE(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldLTE(FieldPhoneBidx, v))
+}
+
+// PhoneBidxContains applies the Contains predicate on the "phoneBidx" field.
+func PhoneBidxContains(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldContains(FieldPhoneBidx, v))
+}
+
+// PhoneBidxHasPrefix applies the HasPrefix predicate on the "phoneBidx" field.
+func PhoneBidxHasPrefix(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldHasPrefix(FieldPhoneBidx, v))
+}
+
+// PhoneBidxHasSuffix applies the HasSuffix predicate on the "phoneBidx" field.
+func PhoneBidxHasSuffix(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldHasSuffix(FieldPhoneBidx, v))
+}
+
+// PhoneBidxIsNil applies the IsNil predicate on the "phoneBidx" field.
+func PhoneBidxIsNil() predicate.Contact {
+	return predicate.Contact(sql.FieldIsNull(FieldPhoneBidx))
+}
+
+// PhoneBidxNotNil applies the NotNil predicate on the "phoneBidx" field.
+func PhoneBidxNotNil() predicate.Contact {
+	return predicate.Contact(sql.FieldNotNull(FieldPhoneBidx))
+}
+
+// PhoneBidxEqualFold applies the EqualFold predicate on the "phoneBidx" field.
+func PhoneBidxEqualFold(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldEqualFold(FieldPhoneBidx, v))
+}
+
+// PhoneBidxContainsFold applies the ContainsFold predicate on the "phoneBidx" field.
+func PhoneBidxContainsFold(v string) predicate.Contact {
+	return predicate.Contact(sql.FieldContainsFold(FieldPhoneBidx, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Contact) predicate.Contact {
+	return predicate.Contact(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Contact) predicate.Contact {
+	return predicate.Contact(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Contact) predicate.Contact {
+	return predicate.Contact(sql.NotPredicates(p))
+}
@@ -0,0 +1,260 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/yimoka/go/internal/entfixture/ent/contact"
+)
+
+// ContactCreate is the builder for creating a Contact entity.
+type ContactCreate struct {
+	config
+	mutation *ContactMutation
+	hooks    []Hook
+}
+
+// SetTenantID sets the "tenantID" field.
+func (cc *ContactCreate) SetTenantID(s string) *ContactCreate {
+	cc.mutation.SetTenantID(s)
+	return cc
+}
+
+// SetName sets the "name" field.
+func (cc *ContactCreate) SetName(s string) *ContactCreate {
+	cc.mutation.SetName(s)
+	return cc
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (cc *ContactCreate) SetNillableName(s *string) *ContactCreate {
+	if s != nil {
+		cc.SetName(*s)
+	}
+	return cc
+}
+
+// SetPhone sets the "phone" field.
+func (cc *ContactCreate) SetPhone(s string) *ContactCreate {
+	cc.mutation.SetPhone(s)
+	return cc
+}
+
+// SetNillablePhone sets the "phone" field if the given value is not nil.
+func (cc *ContactCreate) SetNillablePhone(s *string) *ContactCreate {
+	if s != nil {
+		cc.SetPhone(*s)
+	}
+	return cc
+}
+
+// SetPhoneCipher sets the "phoneCipher" field.
+func (cc *ContactCreate) SetPhoneCipher(s string) *ContactCreate {
+	cc.mutation.SetPhoneCipher(s)
+	return cc
+}
+
+// SetNillablePhoneCipher sets the "phoneCipher" field if the given value is not nil.
+func (cc *ContactCreate) SetNillablePhoneCipher(s *string) *ContactCreate {
+	if s != nil {
+		cc.SetPhoneCipher(*s)
+	}
+	return cc
+}
+
+// SetPhoneBidx sets the "phoneBidx" field.
+func (cc *ContactCreate) SetPhoneBidx(s string) *ContactCreate {
+	cc.mutation.SetPhoneBidx(s)
+	return cc
+}
+
+// SetNillablePhoneBidx sets the "phoneBidx" field if the given value is not nil.
+func (cc *ContactCreate) SetNillablePhoneBidx(s *string) *ContactCreate {
+	if s != nil {
+		cc.SetPhoneBidx(*s)
+	}
+	return cc
+}
+
+// Mutation returns the ContactMutation object of the builder.
+func (cc *ContactCreate) Mutation() *ContactMutation {
+	return cc.mutation
+}
+
+// Save creates the Contact in the database.
+func (cc *ContactCreate) Save(ctx context.Context) (*Contact, error) {
+	return withHooks(ctx, cc.sqlSave, cc.mutation, cc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (cc *ContactCreate) SaveX(ctx context.Context) *Contact {
+	v, err := cc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (cc *ContactCreate) Exec(ctx context.Context) error {
+	_, err := cc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cc *ContactCreate) ExecX(ctx context.Context) {
+	if err := cc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cc *ContactCreate) check() error {
+	if _, ok := cc.mutation.TenantID(); !ok {
+		return &ValidationError{Name: "tenantID", err: errors.New(`ent: missing required field "Contact.tenantID"`)}
+	}
+	if v, ok := cc.mutation.TenantID(); ok {
+		if err := contact.TenantIDValidator(v); err != nil {
+			return &ValidationError{Name: "tenantID", err: fmt.Errorf(`ent: validator failed for field "Contact.tenantID": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (cc *ContactCreate) sqlSave(ctx context.Context) (*Contact, error) {
+	if err := cc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := cc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, cc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	cc.mutation.id = &_node.ID
+	cc.mutation.done = true
+	return _node, nil
+}
+
+func (cc *ContactCreate) createSpec() (*Contact, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Contact{config: cc.config}
+		_spec = sqlgraph.NewCreateSpec(contact.Table, sqlgraph.NewFieldSpec(contact.FieldID, field.TypeInt))
+	)
+	if value, ok := cc.mutation.TenantID(); ok {
+		_spec.SetField(contact.FieldTenantID, field.TypeString, value)
+		_node.TenantID = value
+	}
+	if value, ok := cc.mutation.Name(); ok {
+		_spec.SetField(contact.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := cc.mutation.Phone(); ok {
+		_spec.SetField(contact.FieldPhone, field.TypeString, value)
+		_node.Phone = value
+	}
+	if value, ok := cc.mutation.PhoneCipher(); ok {
+		_spec.SetField(contact.FieldPhoneCipher, field.TypeString, value)
+		_node.PhoneCipher = value
+	}
+	if value, ok := cc.mutation.PhoneBidx(); ok {
+		_spec.SetField(contact.FieldPhoneBidx, field.TypeString, value)
+		_node.PhoneBidx = value
+	}
+	return _node, _spec
+}
+
+// ContactCreateBulk is the builder for creating many Contact entities in bulk.
+type ContactCreateBulk struct {
+	config
+	err      error
+	builders []*ContactCreate
+}
+
+// Save creates the Contact entities in the database.
+func (ccb *ContactCreateBulk) Save(ctx context.Context) ([]*Contact, error) {
+	if ccb.err != nil {
+		return nil, ccb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(ccb.builders))
+	nodes := make([]*Contact, len(ccb.builders))
+	mutators := make([]Mutator, len(ccb.builders))
+	for i := range ccb.builders {
+		func(i int, root context.Context) {
+			builder := ccb.builders[i]
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ContactMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ccb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ccb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ccb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ccb *ContactCreateBulk) SaveX(ctx context.Context) []*Contact {
+	v, err := ccb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ccb *ContactCreateBulk) Exec(ctx context.Context) error {
+	_, err := ccb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ccb *ContactCreateBulk) ExecX(ctx context.Context) {
+	if err := ccb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,383 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/yimoka/go/internal/entfixture/ent/contact"
+	"github.com/yimoka/go/internal/entfixture/ent/predicate"
+)
+
+// ContactUpdate is the builder for updating Contact entities.
+type ContactUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ContactMutation
+}
+
+// Where appends a list predicates to the ContactUpdate builder.
+func (cu *ContactUpdate) Where(ps ...predicate.Contact) *ContactUpdate {
+	cu.mutation.Where(ps...)
+	return cu
+}
+
+// SetName sets the "name" field.
+func (cu *ContactUpdate) SetName(s string) *ContactUpdate {
+	cu.mutation.SetName(s)
+	return cu
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (cu *ContactUpdate) SetNillableName(s *string) *ContactUpdate {
+	if s != nil {
+		cu.SetName(*s)
+	}
+	return cu
+}
+
+// ClearName clears the value of the "name" field.
+func (cu *ContactUpdate) ClearName() *ContactUpdate {
+	cu.mutation.ClearName()
+	return cu
+}
+
+// SetPhone sets the "phone" field.
+func (cu *ContactUpdate) SetPhone(s string) *ContactUpdate {
+	cu.mutation.SetPhone(s)
+	return cu
+}
+
+// SetNillablePhone sets the "phone" field if the given value is not nil.
+func (cu *ContactUpdate) SetNillablePhone(s *string) *ContactUpdate {
+	if s != nil {
+		cu.SetPhone(*s)
+	}
+	return cu
+}
+
+// ClearPhone clears the value of the "phone" field.
+func (cu *ContactUpdate) ClearPhone() *ContactUpdate {
+	cu.mutation.ClearPhone()
+	return cu
+}
+
+// SetPhoneCipher sets the "phoneCipher" field.
+func (cu *ContactUpdate) SetPhoneCipher(s string) *ContactUpdate {
+	cu.mutation.SetPhoneCipher(s)
+	return cu
+}
+
+// SetNillablePhoneCipher sets the "phoneCipher" field if the given value is not nil.
+func (cu *ContactUpdate) SetNillablePhoneCipher(s *string) *ContactUpdate {
+	if s != nil {
+		cu.SetPhoneCipher(*s)
+	}
+	return cu
+}
+
+// ClearPhoneCipher clears the value of the "phoneCipher" field.
+func (cu *ContactUpdate) ClearPhoneCipher() *ContactUpdate {
+	cu.mutation.ClearPhoneCipher()
+	return cu
+}
+
+// SetPhoneBidx sets the "phoneBidx" field.
+func (cu *ContactUpdate) SetPhoneBidx(s string) *ContactUpdate {
+	cu.mutation.SetPhoneBidx(s)
+	return cu
+}
+
+// SetNillablePhoneBidx sets the "phoneBidx" field if the given value is not nil.
+func (cu *ContactUpdate) SetNillablePhoneBidx(s *string) *ContactUpdate {
+	if s != nil {
+		cu.SetPhoneBidx(*s)
+	}
+	return cu
+}
+
+// ClearPhoneBidx clears the value of the "phoneBidx" field.
+func (cu *ContactUpdate) ClearPhoneBidx() *ContactUpdate {
+	cu.mutation.ClearPhoneBidx()
+	return cu
+}
+
+// Mutation returns the ContactMutation object of the builder.
+func (cu *ContactUpdate) Mutation() *ContactMutation {
+	return cu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (cu *ContactUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, cu.sqlSave, cu.mutation, cu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cu *ContactUpdate) SaveX(ctx context.Context) int {
+	affected, err := cu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (cu *ContactUpdate) Exec(ctx context.Context) error {
+	_, err := cu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cu *ContactUpdate) ExecX(ctx context.Context) {
+	if err := cu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (cu *ContactUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(contact.Table, contact.Columns, sqlgraph.NewFieldSpec(contact.FieldID, field.TypeInt))
+	if ps := cu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cu.mutation.Name(); ok {
+		_spec.SetField(contact.FieldName, field.TypeString, value)
+	}
+	if cu.mutation.NameCleared() {
+		_spec.ClearField(contact.FieldName, field.TypeString)
+	}
+	if value, ok := cu.mutation.Phone(); ok {
+		_spec.SetField(contact.FieldPhone, field.TypeString, value)
+	}
+	if cu.mutation.PhoneCleared() {
+		_spec.ClearField(contact.FieldPhone, field.TypeString)
+	}
+	if value, ok := cu.mutation.PhoneCipher(); ok {
+		_spec.SetField(contact.FieldPhoneCipher, field.TypeString, value)
+	}
+	if cu.mutation.PhoneCipherCleared() {
+		_spec.ClearField(contact.FieldPhoneCipher, field.TypeString)
+	}
+	if value, ok := cu.mutation.PhoneBidx(); ok {
+		_spec.SetField(contact.FieldPhoneBidx, field.TypeString, value)
+	}
+	if cu.mutation.PhoneBidxCleared() {
+		_spec.ClearField(contact.FieldPhoneBidx, field.TypeString)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, cu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{contact.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	cu.mutation.done = true
+	return n, nil
+}
+
+// ContactUpdateOne is the builder for updating a single Contact entity.
+type ContactUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ContactMutation
+}
+
+// SetName sets the "name" field.
+func (cuo *ContactUpdateOne) SetName(s string) *ContactUpdateOne {
+	cuo.mutation.SetName(s)
+	return cuo
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (cuo *ContactUpdateOne) SetNillableName(s *string) *ContactUpdateOne {
+	if s != nil {
+		cuo.SetName(*s)
+	}
+	return cuo
+}
+
+// ClearName clears the value of the "name" field.
+func (cuo *ContactUpdateOne) ClearName() *ContactUpdateOne {
+	cuo.mutation.ClearName()
+	return cuo
+}
+
+// SetPhone sets the "phone" field.
+func (cuo *ContactUpdateOne) SetPhone(s string) *ContactUpdateOne {
+	cuo.mutation.SetPhone(s)
+	return cuo
+}
+
+// SetNillablePhone sets the "phone" field if the given value is not nil.
+func (cuo *ContactUpdateOne) SetNillablePhone(s *string) *ContactUpdateOne {
+	if s != nil {
+		cuo.SetPhone(*s)
+	}
+	return cuo
+}
+
+// ClearPhone clears the value of the "phone" field.
+func (cuo *ContactUpdateOne) ClearPhone() *ContactUpdateOne {
+	cuo.mutation.ClearPhone()
+	return cuo
+}
+
+// SetPhoneCipher sets the "phoneCipher" field.
+func (cuo *ContactUpdateOne) SetPhoneCipher(s string) *ContactUpdateOne {
+	cuo.mutation.SetPhoneCipher(s)
+	return cuo
+}
+
+// SetNillablePhoneCipher sets the "phoneCipher" field if the given value is not nil.
+func (cuo *ContactUpdateOne) SetNillablePhoneCipher(s *string) *ContactUpdateOne {
+	if s != nil {
+		cuo.SetPhoneCipher(*s)
+	}
+	return cuo
+}
+
+// ClearPhoneCipher clears the value of the "phoneCipher" field.
+func (cuo *ContactUpdateOne) ClearPhoneCipher() *ContactUpdateOne {
+	cuo.mutation.ClearPhoneCipher()
+	return cuo
+}
+
+// SetPhoneBidx sets the "phoneBidx" field.
+func (cuo *ContactUpdateOne) SetPhoneBidx(s string) *ContactUpdateOne {
+	cuo.mutation.SetPhoneBidx(s)
+	return cuo
+}
+
+// SetNillablePhoneBidx sets the "phoneBidx" field if the given value is not nil.
+func (cuo *ContactUpdateOne) SetNillablePhoneBidx(s *string) *ContactUpdateOne {
+	if s != nil {
+		cuo.SetPhoneBidx(*s)
+	}
+	return cuo
+}
+
+// ClearPhoneBidx clears the value of the "phoneBidx" field.
+func (cuo *ContactUpdateOne) ClearPhoneBidx() *ContactUpdateOne {
+	cuo.mutation.ClearPhoneBidx()
+	return cuo
+}
+
+// Mutation returns the ContactMutation object of the builder.
+func (cuo *ContactUpdateOne) Mutation() *ContactMutation {
+	return cuo.mutation
+}
+
+// Where appends a list predicates to the ContactUpdate builder.
+func (cuo *ContactUpdateOne) Where(ps ...predicate.Contact) *ContactUpdateOne {
+	cuo.mutation.Where(ps...)
+	return cuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (cuo *ContactUpdateOne) Select(field string, fields ...string) *ContactUpdateOne {
+	cuo.fields = append([]string{field}, fields...)
+	return cuo
+}
+
+// Save executes the query and returns the updated Contact entity.
+func (cuo *ContactUpdateOne) Save(ctx context.Context) (*Contact, error) {
+	return withHooks(ctx, cuo.sqlSave, cuo.mutation, cuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cuo *ContactUpdateOne) SaveX(ctx context.Context) *Contact {
+	node, err := cuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (cuo *ContactUpdateOne) Exec(ctx context.Context) error {
+	_, err := cuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cuo *ContactUpdateOne) ExecX(ctx context.Context) {
+	if err := cuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (cuo *ContactUpdateOne) sqlSave(ctx context.Context) (_node *Contact, err error) {
+	_spec := sqlgraph.NewUpdateSpec(contact.Table, contact.Columns, sqlgraph.NewFieldSpec(contact.FieldID, field.TypeInt))
+	id, ok := cuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Contact.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := cuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, contact.FieldID)
+		for _, f := range fields {
+			if !contact.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != contact.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := cuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cuo.mutation.Name(); ok {
+		_spec.SetField(contact.FieldName, field.TypeString, value)
+	}
+	if cuo.mutation.NameCleared() {
+		_spec.ClearField(contact.FieldName, field.TypeString)
+	}
+	if value, ok := cuo.mutation.Phone(); ok {
+		_spec.SetField(contact.FieldPhone, field.TypeString, value)
+	}
+	if cuo.mutation.PhoneCleared() {
+		_spec.ClearField(contact.FieldPhone, field.TypeString)
+	}
+	if value, ok := cuo.mutation.PhoneCipher(); ok {
+		_spec.SetField(contact.FieldPhoneCipher, field.TypeString, value)
+	}
+	if cuo.mutation.PhoneCipherCleared() {
+		_spec.ClearField(contact.FieldPhoneCipher, field.TypeString)
+	}
+	if value, ok := cuo.mutation.PhoneBidx(); ok {
+		_spec.SetField(contact.FieldPhoneBidx, field.TypeString, value)
+	}
+	if cuo.mutation.PhoneBidxCleared() {
+		_spec.ClearField(contact.FieldPhoneBidx, field.TypeString)
+	}
+	_node = &Contact{config: cuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, cuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{contact.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	cuo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,90 @@
+// Code generated by ent, DO NOT EDIT.
+
+package migrate
+
+import (
+	"entgo.io/ent/dialect/sql/schema"
+	"entgo.io/ent/schema/field"
+)
+
+var (
+	// UsersColumns holds the columns for the "users" table.
+	UsersColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "tenant_id", Type: field.TypeString, Size: 63},
+		{Name: "del", Type: field.TypeBool, Default: false},
+		{Name: "delete_time", Type: field.TypeTime, Nullable: true, SchemaType: map[string]string{"mysql": "datetime"}},
+		{Name: "deleter", Type: field.TypeString, Nullable: true, Size: 15},
+		{Name: "switch", Type: field.TypeBool, Default: false},
+		{Name: "update_time", Type: field.TypeTime, SchemaType: map[string]string{"mysql": "datetime"}},
+		{Name: "phone_prefix", Type: field.TypeString, Nullable: true, Size: 7, Default: ""},
+		{Name: "phone", Type: field.TypeString, Nullable: true, Size: 15},
+		{Name: "phone_cipher", Type: field.TypeString, Nullable: true, Size: 255},
+		{Name: "password", Type: field.TypeString, Nullable: true, Size: 255, Default: ""},
+		{Name: "password_nonce", Type: field.TypeString, Nullable: true, Size: 15, Default: ""},
+		{Name: "name", Type: field.TypeString, Nullable: true},
+	}
+	// UsersTable holds the schema information for the "users" table.
+	UsersTable = &schema.Table{
+		Name:       "users",
+		Columns:    UsersColumns,
+		PrimaryKey: []*schema.Column{UsersColumns[0]},
+	}
+	// ContactsColumns holds the columns for the "contacts" table.
+	ContactsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "tenant_id", Type: field.TypeString, Size: 63},
+		{Name: "name", Type: field.TypeString, Nullable: true},
+		{Name: "phone", Type: field.TypeString, Nullable: true},
+		{Name: "phone_cipher", Type: field.TypeString, Nullable: true},
+		{Name: "phone_bidx", Type: field.TypeString, Nullable: true},
+	}
+	// ContactsTable holds the schema information for the "contacts" table.
+	ContactsTable = &schema.Table{
+		Name:       "contacts",
+		Columns:    ContactsColumns,
+		PrimaryKey: []*schema.Column{ContactsColumns[0]},
+	}
+	// AppsColumns holds the columns for the "apps" table.
+	AppsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "secret_id", Type: field.TypeString, Size: 127},
+		{Name: "secret_key", Type: field.TypeString, Nullable: true, Size: 255, Default: ""},
+	}
+	// AppsTable holds the schema information for the "apps" table.
+	AppsTable = &schema.Table{
+		Name:       "apps",
+		Columns:    AppsColumns,
+		PrimaryKey: []*schema.Column{AppsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "app_secret_id",
+				Unique:  false,
+				Columns: []*schema.Column{AppsColumns[1]},
+			},
+		},
+	}
+	// ArticlesColumns holds the columns for the "articles" table.
+	ArticlesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "tenant_id", Type: field.TypeString, Size: 63},
+		{Name: "appid", Type: field.TypeString, Size: 63},
+		{Name: "title", Type: field.TypeString, Nullable: true},
+	}
+	// ArticlesTable holds the schema information for the "articles" table.
+	ArticlesTable = &schema.Table{
+		Name:       "articles",
+		Columns:    ArticlesColumns,
+		PrimaryKey: []*schema.Column{ArticlesColumns[0]},
+	}
+	// Tables holds all the tables in the schema.
+	Tables = []*schema.Table{
+		UsersTable,
+		ContactsTable,
+		AppsTable,
+		ArticlesTable,
+	}
+)
+
+func init() {
+}
@@ -0,0 +1,19 @@
+// Code generated by ent, DO NOT EDIT.
+
+package predicate
+
+import (
+	"entgo.io/ent/dialect/sql"
+)
+
+// User is the predicate function for user builders.
+type User func(*sql.Selector)
+
+// Contact is the predicate function for contact builders.
+type Contact func(*sql.Selector)
+
+// App is the predicate function for app builders.
+type App func(*sql.Selector)
+
+// Article is the predicate function for article builders.
+type Article func(*sql.Selector)
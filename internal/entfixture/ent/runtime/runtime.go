@@ -0,0 +1,142 @@
+// Code generated by ent, DO NOT EDIT.
+
+package runtime
+
+import (
+	"time"
+
+	"github.com/yimoka/go/internal/entfixture/ent/app"
+	"github.com/yimoka/go/internal/entfixture/ent/article"
+	"github.com/yimoka/go/internal/entfixture/ent/contact"
+	"github.com/yimoka/go/internal/entfixture/ent/user"
+	"github.com/yimoka/go/internal/entfixture/schema"
+)
+
+// The init function reads all schema descriptors with runtime code
+// (default values, validators, hooks and policies) and stitches it
+// to their package variables.
+func init() {
+	userMixin := schema.User{}.Mixin()
+	userMixinHooks0 := userMixin[0].Hooks()
+	userMixinHooks1 := userMixin[1].Hooks()
+	user.Hooks[0] = userMixinHooks0[0]
+	user.Hooks[1] = userMixinHooks1[0]
+	userMixinInters0 := userMixin[0].Interceptors()
+	userMixinInters1 := userMixin[1].Interceptors()
+	user.Interceptors[0] = userMixinInters0[0]
+	user.Interceptors[1] = userMixinInters1[0]
+	userMixinFields0 := userMixin[0].Fields()
+	_ = userMixinFields0
+	userMixinFields1 := userMixin[1].Fields()
+	_ = userMixinFields1
+	userMixinFields2 := userMixin[2].Fields()
+	_ = userMixinFields2
+	userMixinFields3 := userMixin[3].Fields()
+	_ = userMixinFields3
+	userMixinFields4 := userMixin[4].Fields()
+	_ = userMixinFields4
+	userMixinFields5 := userMixin[5].Fields()
+	_ = userMixinFields5
+	userFields := schema.User{}.Fields()
+	_ = userFields
+	// userDescTenantID is the schema descriptor for tenantID field.
+	userDescTenantID := userMixinFields0[0].Descriptor()
+	// user.TenantIDValidator is a validator for the "tenantID" field. It is called by the builders before save.
+	user.TenantIDValidator = userDescTenantID.Validators[0].(func(string) error)
+	// userDescDel is the schema descriptor for del field.
+	userDescDel := userMixinFields1[0].Descriptor()
+	// user.DefaultDel holds the default value on creation for the del field.
+	user.DefaultDel = userDescDel.Default.(bool)
+	// userDescDeleter is the schema descriptor for deleter field.
+	userDescDeleter := userMixinFields1[2].Descriptor()
+	// user.DeleterValidator is a validator for the "deleter" field. It is called by the builders before save.
+	user.DeleterValidator = userDescDeleter.Validators[0].(func(string) error)
+	// userDescSwitch is the schema descriptor for switch field.
+	userDescSwitch := userMixinFields2[0].Descriptor()
+	// user.DefaultSwitch holds the default value on creation for the switch field.
+	user.DefaultSwitch = userDescSwitch.Default.(bool)
+	// userDescUpdateTime is the schema descriptor for updateTime field.
+	userDescUpdateTime := userMixinFields3[0].Descriptor()
+	// user.DefaultUpdateTime holds the default value on creation for the updateTime field.
+	user.DefaultUpdateTime = userDescUpdateTime.Default.(func() time.Time)
+	// user.UpdateDefaultUpdateTime holds the default value on update for the updateTime field.
+	user.UpdateDefaultUpdateTime = userDescUpdateTime.UpdateDefault.(func() time.Time)
+	// userDescPhonePrefix is the schema descriptor for phonePrefix field.
+	userDescPhonePrefix := userMixinFields4[0].Descriptor()
+	// user.DefaultPhonePrefix holds the default value on creation for the phonePrefix field.
+	user.DefaultPhonePrefix = userDescPhonePrefix.Default.(string)
+	// user.PhonePrefixValidator is a validator for the "phonePrefix" field. It is called by the builders before save.
+	user.PhonePrefixValidator = userDescPhonePrefix.Validators[0].(func(string) error)
+	// userDescPhone is the schema descriptor for phone field.
+	userDescPhone := userMixinFields4[1].Descriptor()
+	// user.PhoneValidator is a validator for the "phone" field. It is called by the builders before save.
+	user.PhoneValidator = userDescPhone.Validators[0].(func(string) error)
+	// userDescPhoneCipher is the schema descriptor for phoneCipher field.
+	userDescPhoneCipher := userMixinFields4[2].Descriptor()
+	// user.PhoneCipherValidator is a validator for the "phoneCipher" field. It is called by the builders before save.
+	user.PhoneCipherValidator = userDescPhoneCipher.Validators[0].(func(string) error)
+	// userDescPassword is the schema descriptor for password field.
+	userDescPassword := userMixinFields5[0].Descriptor()
+	// user.DefaultPassword holds the default value on creation for the password field.
+	user.DefaultPassword = userDescPassword.Default.(string)
+	// user.PasswordValidator is a validator for the "password" field. It is called by the builders before save.
+	user.PasswordValidator = userDescPassword.Validators[0].(func(string) error)
+	// userDescPasswordNonce is the schema descriptor for passwordNonce field.
+	userDescPasswordNonce := userMixinFields5[1].Descriptor()
+	// user.DefaultPasswordNonce holds the default value on creation for the passwordNonce field.
+	user.DefaultPasswordNonce = userDescPasswordNonce.Default.(string)
+	// user.PasswordNonceValidator is a validator for the "passwordNonce" field. It is called by the builders before save.
+	user.PasswordNonceValidator = userDescPasswordNonce.Validators[0].(func(string) error)
+	contactMixin := schema.Contact{}.Mixin()
+	contactMixinFields0 := contactMixin[0].Fields()
+	_ = contactMixinFields0
+	contactFields := schema.Contact{}.Fields()
+	_ = contactFields
+	// contactDescTenantID is the schema descriptor for tenantID field.
+	contactDescTenantID := contactMixinFields0[0].Descriptor()
+	// contact.TenantIDValidator is a validator for the "tenantID" field. It is called by the builders before save.
+	contact.TenantIDValidator = contactDescTenantID.Validators[0].(func(string) error)
+	appMixin := schema.App{}.Mixin()
+	appMixinFields0 := appMixin[0].Fields()
+	_ = appMixinFields0
+	appFields := schema.App{}.Fields()
+	_ = appFields
+	// appDescSecretID is the schema descriptor for secretID field.
+	appDescSecretID := appMixinFields0[0].Descriptor()
+	// app.SecretIDValidator is a validator for the "secretID" field. It is called by the builders before save.
+	app.SecretIDValidator = appDescSecretID.Validators[0].(func(string) error)
+	// appDescSecretKey is the schema descriptor for secretKey field.
+	appDescSecretKey := appMixinFields0[1].Descriptor()
+	// app.DefaultSecretKey holds the default value on creation for the secretKey field.
+	app.DefaultSecretKey = appDescSecretKey.Default.(string)
+	// app.SecretKeyValidator is a validator for the "secretKey" field. It is called by the builders before save.
+	app.SecretKeyValidator = appDescSecretKey.Validators[0].(func(string) error)
+	articleMixin := schema.Article{}.Mixin()
+	articleMixinHooks0 := articleMixin[0].Hooks()
+	articleMixinHooks1 := articleMixin[1].Hooks()
+	article.Hooks[0] = articleMixinHooks0[0]
+	article.Hooks[1] = articleMixinHooks1[0]
+	articleMixinInters0 := articleMixin[0].Interceptors()
+	articleMixinInters1 := articleMixin[1].Interceptors()
+	article.Interceptors[0] = articleMixinInters0[0]
+	article.Interceptors[1] = articleMixinInters1[0]
+	articleMixinFields0 := articleMixin[0].Fields()
+	_ = articleMixinFields0
+	articleMixinFields1 := articleMixin[1].Fields()
+	_ = articleMixinFields1
+	articleFields := schema.Article{}.Fields()
+	_ = articleFields
+	// articleDescTenantID is the schema descriptor for tenantID field.
+	articleDescTenantID := articleMixinFields0[0].Descriptor()
+	// article.TenantIDValidator is a validator for the "tenantID" field. It is called by the builders before save.
+	article.TenantIDValidator = articleDescTenantID.Validators[0].(func(string) error)
+	// articleDescAppid is the schema descriptor for appid field.
+	articleDescAppid := articleMixinFields1[0].Descriptor()
+	// article.AppidValidator is a validator for the "appid" field. It is called by the builders before save.
+	article.AppidValidator = articleDescAppid.Validators[0].(func(string) error)
+}
+
+const (
+	Version = "v0.14.3"                                         // Version of ent codegen.
+	Sum     = "h1:wokAV/kIlH9TeklJWGGS7AYJdVckr0DloWjIcO9iIIQ=" // Sum of ent codegen.
+)
@@ -0,0 +1,230 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/yimoka/go/internal/entfixture/ent/user"
+)
+
+// User is the model entity for the User schema.
+type User struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// 租户 ID
+	TenantID string `json:"tenantID,omitempty"`
+	// 软删除
+	Del bool `json:"del,omitempty"`
+	// 删除时间
+	DeleteTime *time.Time `json:"deleteTime,omitempty"`
+	// 删除人
+	Deleter string `json:"deleter,omitempty"`
+	// 开关
+	Switch bool `json:"switch,omitempty"`
+	// 更新时间
+	UpdateTime time.Time `json:"updateTime,omitempty"`
+	// 区号
+	PhonePrefix string `json:"phonePrefix,omitempty"`
+	// 手机号码
+	Phone string `json:"phone,omitempty"`
+	// PhoneCipher holds the value of the "phoneCipher" field.
+	PhoneCipher string `json:"-"`
+	// 密码
+	Password string `json:"password,omitempty"`
+	// PasswordNonce holds the value of the "passwordNonce" field.
+	PasswordNonce string `json:"-"`
+	// Name holds the value of the "name" field.
+	Name         string `json:"name,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*User) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case user.FieldDel, user.FieldSwitch:
+			values[i] = new(sql.NullBool)
+		case user.FieldID:
+			values[i] = new(sql.NullInt64)
+		case user.FieldTenantID, user.FieldDeleter, user.FieldPhonePrefix, user.FieldPhone, user.FieldPhoneCipher, user.FieldPassword, user.FieldPasswordNonce, user.FieldName:
+			values[i] = new(sql.NullString)
+		case user.FieldDeleteTime, user.FieldUpdateTime:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the User fields.
+func (u *User) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case user.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			u.ID = int(value.Int64)
+		case user.FieldTenantID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field tenantID", values[i])
+			} else if value.Valid {
+				u.TenantID = value.String
+			}
+		case user.FieldDel:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field del", values[i])
+			} else if value.Valid {
+				u.Del = value.Bool
+			}
+		case user.FieldDeleteTime:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field deleteTime", values[i])
+			} else if value.Valid {
+				u.DeleteTime = new(time.Time)
+				*u.DeleteTime = value.Time
+			}
+		case user.FieldDeleter:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field deleter", values[i])
+			} else if value.Valid {
+				u.Deleter = value.String
+			}
+		case user.FieldSwitch:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field switch", values[i])
+			} else if value.Valid {
+				u.Switch = value.Bool
+			}
+		case user.FieldUpdateTime:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updateTime", values[i])
+			} else if value.Valid {
+				u.UpdateTime = value.Time
+			}
+		case user.FieldPhonePrefix:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field phonePrefix", values[i])
+			} else if value.Valid {
+				u.PhonePrefix = value.String
+			}
+		case user.FieldPhone:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field phone", values[i])
+			} else if value.Valid {
+				u.Phone = value.String
+			}
+		case user.FieldPhoneCipher:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field phoneCipher", values[i])
+			} else if value.Valid {
+				u.PhoneCipher = value.String
+			}
+		case user.FieldPassword:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field password", values[i])
+			} else if value.Valid {
+				u.Password = value.String
+			}
+		case user.FieldPasswordNonce:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field passwordNonce", values[i])
+			} else if value.Valid {
+				u.PasswordNonce = value.String
+			}
+		case user.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				u.Name = value.String
+			}
+		default:
+			u.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the User.
+// This includes values selected through modifiers, order, etc.
+func (u *User) Value(name string) (ent.Value, error) {
+	return u.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this User.
+// Note that you need to call User.Unwrap() before calling this method if this User
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (u *User) Update() *UserUpdateOne {
+	return NewUserClient(u.config).UpdateOne(u)
+}
+
+// Unwrap unwraps the User entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (u *User) Unwrap() *User {
+	_tx, ok := u.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: User is not a transactional entity")
+	}
+	u.config.driver = _tx.drv
+	return u
+}
+
+// String implements the fmt.Stringer.
+func (u *User) String() string {
+	var builder strings.Builder
+	builder.WriteString("User(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", u.ID))
+	builder.WriteString("tenantID=")
+	builder.WriteString(u.TenantID)
+	builder.WriteString(", ")
+	builder.WriteString("del=")
+	builder.WriteString(fmt.Sprintf("%v", u.Del))
+	builder.WriteString(", ")
+	if v := u.DeleteTime; v != nil {
+		builder.WriteString("deleteTime=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("deleter=")
+	builder.WriteString(u.Deleter)
+	builder.WriteString(", ")
+	builder.WriteString("switch=")
+	builder.WriteString(fmt.Sprintf("%v", u.Switch))
+	builder.WriteString(", ")
+	builder.WriteString("updateTime=")
+	builder.WriteString(u.UpdateTime.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("phonePrefix=")
+	builder.WriteString(u.PhonePrefix)
+	builder.WriteString(", ")
+	builder.WriteString("phone=")
+	builder.WriteString(u.Phone)
+	builder.WriteString(", ")
+	builder.WriteString("phoneCipher=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("password=")
+	builder.WriteString(u.Password)
+	builder.WriteString(", ")
+	builder.WriteString("passwordNonce=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("name=")
+	builder.WriteString(u.Name)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Users is a parsable slice of User.
+type Users []*User
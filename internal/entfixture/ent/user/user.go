@@ -0,0 +1,176 @@
+// Code generated by ent, DO NOT EDIT.
+
+package user
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the user type in the database.
+	Label = "user"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldTenantID holds the string denoting the tenantid field in the database.
+	FieldTenantID = "tenant_id"
+	// FieldDel holds the string denoting the del field in the database.
+	FieldDel = "del"
+	// FieldDeleteTime holds the string denoting the deletetime field in the database.
+	FieldDeleteTime = "delete_time"
+	// FieldDeleter holds the string denoting the deleter field in the database.
+	FieldDeleter = "deleter"
+	// FieldSwitch holds the string denoting the switch field in the database.
+	FieldSwitch = "switch"
+	// FieldUpdateTime holds the string denoting the updatetime field in the database.
+	FieldUpdateTime = "update_time"
+	// FieldPhonePrefix holds the string denoting the phoneprefix field in the database.
+	FieldPhonePrefix = "phone_prefix"
+	// FieldPhone holds the string denoting the phone field in the database.
+	FieldPhone = "phone"
+	// FieldPhoneCipher holds the string denoting the phonecipher field in the database.
+	FieldPhoneCipher = "phone_cipher"
+	// FieldPassword holds the string denoting the password field in the database.
+	FieldPassword = "password"
+	// FieldPasswordNonce holds the string denoting the passwordnonce field in the database.
+	FieldPasswordNonce = "password_nonce"
+	// FieldName holds the string denoting the name field in the database.
+	FieldName = "name"
+	// Table holds the table name of the user in the database.
+	Table = "users"
+)
+
+// Columns holds all SQL columns for user fields.
+var Columns = []string{
+	FieldID,
+	FieldTenantID,
+	FieldDel,
+	FieldDeleteTime,
+	FieldDeleter,
+	FieldSwitch,
+	FieldUpdateTime,
+	FieldPhonePrefix,
+	FieldPhone,
+	FieldPhoneCipher,
+	FieldPassword,
+	FieldPasswordNonce,
+	FieldName,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// Note that the variables below are initialized by the runtime
+// package on the initialization of the application. Therefore,
+// it should be imported in the main as follows:
+//
+//	import _ "github.com/yimoka/go/internal/entfixture/ent/runtime"
+var (
+	Hooks        [2]ent.Hook
+	Interceptors [2]ent.Interceptor
+	// TenantIDValidator is a validator for the "tenantID" field. It is called by the builders before save.
+	TenantIDValidator func(string) error
+	// DefaultDel holds the default value on creation for the "del" field.
+	DefaultDel bool
+	// DeleterValidator is a validator for the "deleter" field. It is called by the builders before save.
+	DeleterValidator func(string) error
+	// DefaultSwitch holds the default value on creation for the "switch" field.
+	DefaultSwitch bool
+	// DefaultUpdateTime holds the default value on creation for the "updateTime" field.
+	DefaultUpdateTime func() time.Time
+	// UpdateDefaultUpdateTime holds the default value on update for the "updateTime" field.
+	UpdateDefaultUpdateTime func() time.Time
+	// DefaultPhonePrefix holds the default value on creation for the "phonePrefix" field.
+	DefaultPhonePrefix string
+	// PhonePrefixValidator is a validator for the "phonePrefix" field. It is called by the builders before save.
+	PhonePrefixValidator func(string) error
+	// PhoneValidator is a validator for the "phone" field. It is called by the builders before save.
+	PhoneValidator func(string) error
+	// PhoneCipherValidator is a validator for the "phoneCipher" field. It is called by the builders before save.
+	PhoneCipherValidator func(string) error
+	// DefaultPassword holds the default value on creation for the "password" field.
+	DefaultPassword string
+	// PasswordValidator is a validator for the "password" field. It is called by the builders before save.
+	PasswordValidator func(string) error
+	// DefaultPasswordNonce holds the default value on creation for the "passwordNonce" field.
+	DefaultPasswordNonce string
+	// PasswordNonceValidator is a validator for the "passwordNonce" field. It is called by the builders before save.
+	PasswordNonceValidator func(string) error
+)
+
+// OrderOption defines the ordering options for the User queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByTenantID orders the results by the tenantID field.
+func ByTenantID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTenantID, opts...).ToFunc()
+}
+
+// ByDel orders the results by the del field.
+func ByDel(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDel, opts...).ToFunc()
+}
+
+// ByDeleteTime orders the results by the deleteTime field.
+func ByDeleteTime(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDeleteTime, opts...).ToFunc()
+}
+
+// ByDeleter orders the results by the deleter field.
+func ByDeleter(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDeleter, opts...).ToFunc()
+}
+
+// BySwitch orders the results by the switch field.
+func BySwitch(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSwitch, opts...).ToFunc()
+}
+
+// ByUpdateTime orders the results by the updateTime field.
+func ByUpdateTime(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdateTime, opts...).ToFunc()
+}
+
+// ByPhonePrefix orders the results by the phonePrefix field.
+func ByPhonePrefix(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPhonePrefix, opts...).ToFunc()
+}
+
+// ByPhone orders the results by the phone field.
+func ByPhone(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPhone, opts...).ToFunc()
+}
+
+// ByPhoneCipher orders the results by the phoneCipher field.
+func ByPhoneCipher(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPhoneCipher, opts...).ToFunc()
+}
+
+// ByPassword orders the results by the password field.
+func ByPassword(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPassword, opts...).ToFunc()
+}
+
+// ByPasswordNonce orders the results by the passwordNonce field.
+func ByPasswordNonce(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPasswordNonce, opts...).ToFunc()
+}
+
+// ByName orders the results by the name field.
+func ByName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldName, opts...).ToFunc()
+}
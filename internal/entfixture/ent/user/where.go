@@ -0,0 +1,830 @@
+// Code generated by ent, DO NOT EDIT.
+
+package user
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/yimoka/go/internal/entfixture/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.User {
+	return predicate.User(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.User {
+	return predicate.User(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.User {
+	return predicate.User(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldID, id))
+}
+
+// TenantID applies equality check predicate on the "tenantID" field. It's identical to TenantIDEQ.
+func TenantID(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldTenantID, v))
+}
+
+// Del applies equality check predicate on the "del" field. It's identical to DelEQ.
+func Del(v bool) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldDel, v))
+}
+
+// DeleteTime applies equality check predicate on the "deleteTime" field. It's identical to DeleteTimeEQ.
+func DeleteTime(v time.Time) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldDeleteTime, v))
+}
+
+// Deleter applies equality check predicate on the "deleter" field. It's identical to DeleterEQ.
+func Deleter(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldDeleter, v))
+}
+
+// Switch applies equality check predicate on the "switch" field. It's identical to SwitchEQ.
+func Switch(v bool) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldSwitch, v))
+}
+
+// UpdateTime applies equality check predicate on the "updateTime" field. It's identical to UpdateTimeEQ.
+func UpdateTime(v time.Time) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldUpdateTime, v))
+}
+
+// PhonePrefix applies equality check predicate on the "phonePrefix" field. It's identical to PhonePrefixEQ.
+func PhonePrefix(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldPhonePrefix, v))
+}
+
+// Phone applies equality check predicate on the "phone" field. It's identical to PhoneEQ.
+func Phone(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldPhone, v))
+}
+
+// PhoneCipher applies equality check predicate on the "phoneCipher" field. It's identical to PhoneCipherEQ.
+func PhoneCipher(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldPhoneCipher, v))
+}
+
+// Password applies equality check predicate on the "password" field. It's identical to PasswordEQ.
+func Password(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldPassword, v))
+}
+
+// PasswordNonce applies equality check predicate on the "passwordNonce" field. It's identical to PasswordNonceEQ.
+func PasswordNonce(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldPasswordNonce, v))
+}
+
+// Name applies equality check predicate on the "name" field. It's identical to NameEQ.
+func Name(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldName, v))
+}
+
+// TenantIDEQ applies the EQ predicate on the "tenantID" field.
+func TenantIDEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldTenantID, v))
+}
+
+// TenantIDNEQ applies the NEQ predicate on the "tenantID" field.
+func TenantIDNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldTenantID, v))
+}
+
+// TenantIDIn applies the In predicate on the "tenantID" field.
+func TenantIDIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldTenantID, vs...))
+}
+
+// TenantIDNotIn applies the NotIn predicate on the "tenantID" field.
+func TenantIDNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldTenantID, vs...))
+}
+
+// TenantIDGT applies the GT predicate on the "tenantID" field.
+func TenantIDGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldTenantID, v))
+}
+
+// TenantIDGTE applies the GTE predicate on the "tenantID" field.
+func TenantIDGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldTenantID, v))
+}
+
+// TenantIDLT applies the LT predicate on the "tenantID" field.
+func TenantIDLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldTenantID, v))
+}
+
+// TenantIDLTE applies the LTE predicate on the "tenantID" field.
+func TenantIDLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldTenantID, v))
+}
+
+// TenantIDContains applies the Contains predicate on the "tenantID" field.
+func TenantIDContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldTenantID, v))
+}
+
+// TenantIDHasPrefix applies the HasPrefix predicate on the "tenantID" field.
+func TenantIDHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldTenantID, v))
+}
+
+// TenantIDHasSuffix applies the HasSuffix predicate on the "tenantID" field.
+func TenantIDHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldTenantID, v))
+}
+
+// TenantIDEqualFold applies the EqualFold predicate on the "tenantID" field.
+func TenantIDEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldTenantID, v))
+}
+
+// TenantIDContainsFold applies the ContainsFold predicate on the "tenantID" field.
+func TenantIDContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldTenantID, v))
+}
+
+// DelEQ applies the EQ predicate on the "del" field.
+func DelEQ(v bool) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldDel, v))
+}
+
+// DelNEQ applies the NEQ predicate on the "del" field.
+func DelNEQ(v bool) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldDel, v))
+}
+
+// DeleteTimeEQ applies the EQ predicate on the "deleteTime" field.
+func DeleteTimeEQ(v time.Time) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldDeleteTime, v))
+}
+
+// DeleteTimeNEQ applies the NEQ predicate on the "deleteTime" field.
+func DeleteTimeNEQ(v time.Time) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldDeleteTime, v))
+}
+
+// DeleteTimeIn applies the In predicate on the "deleteTime" field.
+func DeleteTimeIn(vs ...time.Time) predicate.User {
+	return predicate.User(sql.FieldIn(FieldDeleteTime, vs...))
+}
+
+// DeleteTimeNotIn applies the NotIn predicate on the "deleteTime" field.
+func DeleteTimeNotIn(vs ...time.Time) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldDeleteTime, vs...))
+}
+
+// DeleteTimeGT applies the GT predicate on the "deleteTime" field.
+func DeleteTimeGT(v time.Time) predicate.User {
+	return predicate.User(sql.FieldGT(FieldDeleteTime, v))
+}
+
+// DeleteTimeGTE applies the GTE predicate on the "deleteTime" field.
+func DeleteTimeGTE(v time.Time) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldDeleteTime, v))
+}
+
+// DeleteTimeLT applies the LT predicate on the "deleteTime" field.
+func DeleteTimeLT(v time.Time) predicate.User {
+	return predicate.User(sql.FieldLT(FieldDeleteTime, v))
+}
+
+// DeleteTimeLTE applies the LTE predicate on the "deleteTime" field.
+func DeleteTimeLTE(v time.Time) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldDeleteTime, v))
+}
+
+// DeleteTimeIsNil applies the IsNil predicate on the "deleteTime" field.
+func DeleteTimeIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldDeleteTime))
+}
+
+// DeleteTimeNotNil applies the NotNil predicate on the "deleteTime" field.
+func DeleteTimeNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldDeleteTime))
+}
+
+// DeleterEQ applies the EQ predicate on the "deleter" field.
+func DeleterEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldDeleter, v))
+}
+
+// DeleterNEQ applies the NEQ predicate on the "deleter" field.
+func DeleterNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldDeleter, v))
+}
+
+// DeleterIn applies the In predicate on the "deleter" field.
+func DeleterIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldDeleter, vs...))
+}
+
+// DeleterNotIn applies the NotIn predicate on the "deleter" field.
+func DeleterNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldDeleter, vs...))
+}
+
+// DeleterGT applies the GT predicate on the "deleter" field.
+func DeleterGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldDeleter, v))
+}
+
+// DeleterGTE applies the GTE predicate on the "deleter" field.
+func DeleterGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldDeleter, v))
+}
+
+// DeleterLT applies the LT predicate on the "deleter" field.
+func DeleterLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldDeleter, v))
+}
+
+// DeleterLTE applies the LTE predicate on the "deleter" field.
+func DeleterLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldDeleter, v))
+}
+
+// DeleterContains applies the Contains predicate on the "deleter" field.
+func DeleterContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldDeleter, v))
+}
+
+// DeleterHasPrefix applies the HasPrefix predicate on the "deleter" field.
+func DeleterHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldDeleter, v))
+}
+
+// DeleterHasSuffix applies the HasSuffix predicate on the "deleter" field.
+func DeleterHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldDeleter, v))
+}
+
+// DeleterIsNil applies the IsNil predicate on the "deleter" field.
+func DeleterIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldDeleter))
+}
+
+// DeleterNotNil applies the NotNil predicate on the "deleter" field.
+func DeleterNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldDeleter))
+}
+
+// DeleterEqualFold applies the EqualFold predicate on the "deleter" field.
+func DeleterEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldDeleter, v))
+}
+
+// DeleterContainsFold applies the ContainsFold predicate on the "deleter" field.
+func DeleterContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldDeleter, v))
+}
+
+// SwitchEQ applies the EQ predicate on the "switch" field.
+func SwitchEQ(v bool) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldSwitch, v))
+}
+
+// SwitchNEQ applies the NEQ predicate on the "switch" field.
+func SwitchNEQ(v bool) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldSwitch, v))
+}
+
+// UpdateTimeEQ applies the EQ predicate on the "updateTime" field.
+func UpdateTimeEQ(v time.Time) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldUpdateTime, v))
+}
+
+// UpdateTimeNEQ applies the NEQ predicate on the "updateTime" field.
+func UpdateTimeNEQ(v time.Time) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldUpdateTime, v))
+}
+
+// UpdateTimeIn applies the In predicate on the "updateTime" field.
+func UpdateTimeIn(vs ...time.Time) predicate.User {
+	return predicate.User(sql.FieldIn(FieldUpdateTime, vs...))
+}
+
+// UpdateTimeNotIn applies the NotIn predicate on the "updateTime" field.
+func UpdateTimeNotIn(vs ...time.Time) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldUpdateTime, vs...))
+}
+
+// UpdateTimeGT applies the GT predicate on the "updateTime" field.
+func UpdateTimeGT(v time.Time) predicate.User {
+	return predicate.User(sql.FieldGT(FieldUpdateTime, v))
+}
+
+// UpdateTimeGTE applies the GTE predicate on the "updateTime" field.
+func UpdateTimeGTE(v time.Time) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldUpdateTime, v))
+}
+
+// UpdateTimeLT applies the LT predicate on the "updateTime" field.
+func UpdateTimeLT(v time.Time) predicate.User {
+	return predicate.User(sql.FieldLT(FieldUpdateTime, v))
+}
+
+// UpdateTimeLTE applies the LTE predicate on the "updateTime" field.
+func UpdateTimeLTE(v time.Time) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldUpdateTime, v))
+}
+
+// PhonePrefixEQ applies the EQ predicate on the "phonePrefix" field.
+func PhonePrefixEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldPhonePrefix, v))
+}
+
+// PhonePrefixNEQ applies the NEQ predicate on the "phonePrefix" field.
+func PhonePrefixNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldPhonePrefix, v))
+}
+
+// PhonePrefixIn applies the In predicate on the "phonePrefix" field.
+func PhonePrefixIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldPhonePrefix, vs...))
+}
+
+// PhonePrefixNotIn applies the NotIn predicate on the "phonePrefix" field.
+func PhonePrefixNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldPhonePrefix, vs...))
+}
+
+// PhonePrefixGT applies the GT predicate on the "phonePrefix" field.
+func PhonePrefixGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldPhonePrefix, v))
+}
+
+// PhonePrefixGTE applies the GTE predicate on the "phonePrefix" field.
+func PhonePrefixGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldPhonePrefix, v))
+}
+
+// PhonePrefixLT applies the LT predicate on the "phonePrefix" field.
+func PhonePrefixLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldPhonePrefix, v))
+}
+
+// PhonePrefixLTE applies the LTE predicate on the "phonePrefix" field.
+func PhonePrefixLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldPhonePrefix, v))
+}
+
+// PhonePrefixContains applies the Contains predicate on the "phonePrefix" field.
+func PhonePrefixContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldPhonePrefix, v))
+}
+
+// PhonePrefixHasPrefix applies the HasPrefix predicate on the "phonePrefix" field.
+func PhonePrefixHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldPhonePrefix, v))
+}
+
+// PhonePrefixHasSuffix applies the HasSuffix predicate on the "phonePrefix" field.
+func PhonePrefixHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldPhonePrefix, v))
+}
+
+// PhonePrefixIsNil applies the IsNil predicate on the "phonePrefix" field.
+func PhonePrefixIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldPhonePrefix))
+}
+
+// PhonePrefixNotNil applies the NotNil predicate on the "phonePrefix" field.
+func PhonePrefixNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldPhonePrefix))
+}
+
+// PhonePrefixEqualFold applies the EqualFold predicate on the "phonePrefix" field.
+func PhonePrefixEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldPhonePrefix, v))
+}
+
+// PhonePrefixContainsFold applies the ContainsFold predicate on the "phonePrefix" field.
+func PhonePrefixContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldPhonePrefix, v))
+}
+
+// PhoneEQ applies the EQ predicate on the "phone" field.
+func PhoneEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldPhone, v))
+}
+
+// PhoneNEQ applies the NEQ predicate on the "phone" field.
+func PhoneNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldPhone, v))
+}
+
+// PhoneIn applies the In predicate on the "phone" field.
+func PhoneIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldPhone, vs...))
+}
+
+// PhoneNotIn applies the NotIn predicate on the "phone" field.
+func PhoneNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldPhone, vs...))
+}
+
+// PhoneGT applies the GT predicate on the "phone" field.
+func PhoneGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldPhone, v))
+}
+
+// PhoneGTE applies the GTE predicate on the "phone" field.
+func PhoneGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldPhone, v))
+}
+
+// PhoneLT applies the LT predicate on the "phone" field.
+func PhoneLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldPhone, v))
+}
+
+// PhoneLTE applies the LTE predicate on the "phone" field.
+func PhoneLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldPhone, v))
+}
+
+// PhoneContains applies the Contains predicate on the "phone" field.
+func PhoneContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldPhone, v))
+}
+
+// PhoneHasPrefix applies the HasPrefix predicate on the "phone" field.
+func PhoneHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldPhone, v))
+}
+
+// PhoneHasSuffix applies the HasSuffix predicate on the "phone" field.
+func PhoneHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldPhone, v))
+}
+
+// PhoneIsNil applies the IsNil predicate on the "phone" field.
+func PhoneIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldPhone))
+}
+
+// PhoneNotNil applies the NotNil predicate on the "phone" field.
+func PhoneNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldPhone))
+}
+
+// PhoneEqualFold applies the EqualFold predicate on the "phone" field.
+func PhoneEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldPhone, v))
+}
+
+// PhoneContainsFold applies the ContainsFold predicate on the "phone" field.
+func PhoneContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldPhone, v))
+}
+
+// PhoneCipherEQ applies the EQ predicate on the "phoneCipher" field.
+func PhoneCipherEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldPhoneCipher, v))
+}
+
+// PhoneCipherNEQ applies the NEQ predicate on the "phoneCipher" field.
+func PhoneCipherNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldPhoneCipher, v))
+}
+
+// PhoneCipherIn applies the In predicate on the "phoneCipher" field.
+func PhoneCipherIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldPhoneCipher, vs...))
+}
+
+// PhoneCipherNotIn applies the NotIn predicate on the "phoneCipher" field.
+func PhoneCipherNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldPhoneCipher, vs...))
+}
+
+// PhoneCipherGT applies the GT predicate on the "phoneCipher" field.
+func PhoneCipherGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldPhoneCipher, v))
+}
+
+// PhoneCipherGTE applies the GTE predicate on the "phoneCipher" field.
+func PhoneCipherGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldPhoneCipher, v))
+}
+
+// PhoneCipherLT applies the LT predicate on the "phoneCipher" field.
+func PhoneCipherLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldPhoneCipher, v))
+}
+
+// PhoneCipherLTE applies the LTE predicate on the "phoneCipher" field.
+func PhoneCipherLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldPhoneCipher, v))
+}
+
+// PhoneCipherContains applies the Contains predicate on the "phoneCipher" field.
+func PhoneCipherContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldPhoneCipher, v))
+}
+
+// PhoneCipherHasPrefix applies the HasPrefix predicate on the "phoneCipher" field.
+func PhoneCipherHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldPhoneCipher, v))
+}
+
+// PhoneCipherHasSuffix applies the HasSuffix predicate on the "phoneCipher" field.
+func PhoneCipherHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldPhoneCipher, v))
+}
+
+// PhoneCipherIsNil applies the IsNil predicate on the "phoneCipher" field.
+func PhoneCipherIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldPhoneCipher))
+}
+
+// PhoneCipherNotNil applies the NotNil predicate on the "phoneCipher" field.
+func PhoneCipherNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldPhoneCipher))
+}
+
+// PhoneCipherEqualFold applies the EqualFold predicate on the "phoneCipher" field.
+func PhoneCipherEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldPhoneCipher, v))
+}
+
+// PhoneCipherContainsFold applies the ContainsFold predicate on the "phoneCipher" field.
+func PhoneCipherContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldPhoneCipher, v))
+}
+
+// PasswordEQ applies the EQ predicate on the "password" field.
+func PasswordEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldPassword, v))
+}
+
+// PasswordNEQ applies the NEQ predicate on the "password" field.
+func PasswordNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldPassword, v))
+}
+
+// PasswordIn applies the In predicate on the "password" field.
+func PasswordIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldPassword, vs...))
+}
+
+// PasswordNotIn applies the NotIn predicate on the "password" field.
+func PasswordNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldPassword, vs...))
+}
+
+// PasswordGT applies the GT predicate on the "password" field.
+func PasswordGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldPassword, v))
+}
+
+// PasswordGTE applies the GTE predicate on the "password" field.
+func PasswordGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldPassword, v))
+}
+
+// PasswordLT applies the LT predicate on the "password" field.
+func PasswordLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldPassword, v))
+}
+
+// PasswordLTE applies the LTE predicate on the "password" field.
+func PasswordLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldPassword, v))
+}
+
+// PasswordContains applies the Contains predicate on the "password" field.
+func PasswordContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldPassword, v))
+}
+
+// PasswordHasPrefix applies the HasPrefix predicate on the "password" field.
+func PasswordHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldPassword, v))
+}
+
+// PasswordHasSuffix applies the HasSuffix predicate on the "password" field.
+func PasswordHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldPassword, v))
+}
+
+// PasswordIsNil applies the IsNil predicate on the "password" field.
+func PasswordIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldPassword))
+}
+
+// PasswordNotNil applies the NotNil predicate on the "password" field.
+func PasswordNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldPassword))
+}
+
+// PasswordEqualFold applies the EqualFold predicate on the "password" field.
+func PasswordEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldPassword, v))
+}
+
+// PasswordContainsFold applies the ContainsFold predicate on the "password" field.
+func PasswordContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldPassword, v))
+}
+
+// PasswordNonceEQ applies the EQ predicate on the "passwordNonce" field.
+func PasswordNonceEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldPasswordNonce, v))
+}
+
+// PasswordNonceNEQ applies the NEQ predicate on the "passwordNonce" field.
+func PasswordNonceNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldPasswordNonce, v))
+}
+
+// PasswordNonceIn applies the In predicate on the "passwordNonce" field.
+func PasswordNonceIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldPasswordNonce, vs...))
+}
+
+// PasswordNonceNotIn applies the NotIn predicate on the "passwordNonce" field.
+func PasswordNonceNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldPasswordNonce, vs...))
+}
+
+// PasswordNonceGT applies the GT predicate on the "passwordNonce" field.
+func PasswordNonceGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldPasswordNonce, v))
+}
+
+// PasswordNonceGTE applies the GTE predicate on the "passwordNonce" field.
+func PasswordNonceGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldPasswordNonce, v))
+}
+
+// PasswordNonceLT applies the LT predicate on the "passwordNonce" field.
+func PasswordNonceLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldPasswordNonce, v))
+}
+
+// PasswordNonceLTE applies the LTE predicate on the "passwordNonce" field.
+func PasswordNonceLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldPasswordNonce, v))
+}
+
+// PasswordNonceContains applies the Contains predicate on the "passwordNonce" field.
+func PasswordNonceContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldPasswordNonce, v))
+}
+
+// PasswordNonceHasPrefix applies the HasPrefix predicate on the "passwordNonce" field.
+func PasswordNonceHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldPasswordNonce, v))
+}
+
+// PasswordNonceHasSuffix applies the HasSuffix predicate on the "passwordNonce" field.
+func PasswordNonceHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldPasswordNonce, v))
+}
+
+// PasswordNonceIsNil applies the IsNil predicate on the "passwordNonce" field.
+func PasswordNonceIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldPasswordNonce))
+}
+
+// PasswordNonceNotNil applies the NotNil predicate on the "passwordNonce" field.
+func PasswordNonceNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldPasswordNonce))
+}
+
+// PasswordNonceEqualFold applies the EqualFold predicate on the "passwordNonce" field.
+func PasswordNonceEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldPasswordNonce, v))
+}
+
+// PasswordNonceContainsFold applies the ContainsFold predicate on the "passwordNonce" field.
+func PasswordNonceContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldPasswordNonce, v))
+}
+
+// NameEQ applies the EQ predicate on the "name" field.
+func NameEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldName, v))
+}
+
+// NameNEQ applies the NEQ predicate on the "name" field.
+func NameNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldName, v))
+}
+
+// NameIn applies the In predicate on the "name" field.
+func NameIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldName, vs...))
+}
+
+// NameNotIn applies the NotIn predicate on the "name" field.
+func NameNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldName, vs...))
+}
+
+// NameGT applies the GT predicate on the "name" field.
+func NameGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldName, v))
+}
+
+// NameGTE applies the GTE predicate on the "name" field.
+func NameGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldName, v))
+}
+
+// NameLT applies the LT predicate on the "name" field.
+func NameLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldName, v))
+}
+
+// NameLTE applies the LTE predicate on the "name" field.
+func NameLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldName, v))
+}
+
+// NameContains applies the Contains predicate on the "name" field.
+func NameContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldName, v))
+}
+
+// NameHasPrefix applies the HasPrefix predicate on the "name" field.
+func NameHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldName, v))
+}
+
+// NameHasSuffix applies the HasSuffix predicate on the "name" field.
+func NameHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldName, v))
+}
+
+// NameIsNil applies the IsNil predicate on the "name" field.
+func NameIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldName))
+}
+
+// NameNotNil applies the NotNil predicate on the "name" field.
+func NameNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldName))
+}
+
+// NameEqualFold applies the EqualFold predicate on the "name" field.
+func NameEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldName, v))
+}
+
+// NameContainsFold applies the ContainsFold predicate on the "name" field.
+func NameContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldName, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.User) predicate.User {
+	return predicate.User(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.User) predicate.User {
+	return predicate.User(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.User) predicate.User {
+	return predicate.User(sql.NotPredicates(p))
+}
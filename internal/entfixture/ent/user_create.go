@@ -0,0 +1,462 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/yimoka/go/internal/entfixture/ent/user"
+)
+
+// UserCreate is the builder for creating a User entity.
+type UserCreate struct {
+	config
+	mutation *UserMutation
+	hooks    []Hook
+}
+
+// SetTenantID sets the "tenantID" field.
+func (uc *UserCreate) SetTenantID(s string) *UserCreate {
+	uc.mutation.SetTenantID(s)
+	return uc
+}
+
+// SetDel sets the "del" field.
+func (uc *UserCreate) SetDel(b bool) *UserCreate {
+	uc.mutation.SetDel(b)
+	return uc
+}
+
+// SetNillableDel sets the "del" field if the given value is not nil.
+func (uc *UserCreate) SetNillableDel(b *bool) *UserCreate {
+	if b != nil {
+		uc.SetDel(*b)
+	}
+	return uc
+}
+
+// SetDeleteTime sets the "deleteTime" field.
+func (uc *UserCreate) SetDeleteTime(t time.Time) *UserCreate {
+	uc.mutation.SetDeleteTime(t)
+	return uc
+}
+
+// SetNillableDeleteTime sets the "deleteTime" field if the given value is not nil.
+func (uc *UserCreate) SetNillableDeleteTime(t *time.Time) *UserCreate {
+	if t != nil {
+		uc.SetDeleteTime(*t)
+	}
+	return uc
+}
+
+// SetDeleter sets the "deleter" field.
+func (uc *UserCreate) SetDeleter(s string) *UserCreate {
+	uc.mutation.SetDeleter(s)
+	return uc
+}
+
+// SetNillableDeleter sets the "deleter" field if the given value is not nil.
+func (uc *UserCreate) SetNillableDeleter(s *string) *UserCreate {
+	if s != nil {
+		uc.SetDeleter(*s)
+	}
+	return uc
+}
+
+// SetSwitch sets the "switch" field.
+func (uc *UserCreate) SetSwitch(b bool) *UserCreate {
+	uc.mutation.SetSwitch(b)
+	return uc
+}
+
+// SetNillableSwitch sets the "switch" field if the given value is not nil.
+func (uc *UserCreate) SetNillableSwitch(b *bool) *UserCreate {
+	if b != nil {
+		uc.SetSwitch(*b)
+	}
+	return uc
+}
+
+// SetUpdateTime sets the "updateTime" field.
+func (uc *UserCreate) SetUpdateTime(t time.Time) *UserCreate {
+	uc.mutation.SetUpdateTime(t)
+	return uc
+}
+
+// SetNillableUpdateTime sets the "updateTime" field if the given value is not nil.
+func (uc *UserCreate) SetNillableUpdateTime(t *time.Time) *UserCreate {
+	if t != nil {
+		uc.SetUpdateTime(*t)
+	}
+	return uc
+}
+
+// SetPhonePrefix sets the "phonePrefix" field.
+func (uc *UserCreate) SetPhonePrefix(s string) *UserCreate {
+	uc.mutation.SetPhonePrefix(s)
+	return uc
+}
+
+// SetNillablePhonePrefix sets the "phonePrefix" field if the given value is not nil.
+func (uc *UserCreate) SetNillablePhonePrefix(s *string) *UserCreate {
+	if s != nil {
+		uc.SetPhonePrefix(*s)
+	}
+	return uc
+}
+
+// SetPhone sets the "phone" field.
+func (uc *UserCreate) SetPhone(s string) *UserCreate {
+	uc.mutation.SetPhone(s)
+	return uc
+}
+
+// SetNillablePhone sets the "phone" field if the given value is not nil.
+func (uc *UserCreate) SetNillablePhone(s *string) *UserCreate {
+	if s != nil {
+		uc.SetPhone(*s)
+	}
+	return uc
+}
+
+// SetPhoneCipher sets the "phoneCipher" field.
+func (uc *UserCreate) SetPhoneCipher(s string) *UserCreate {
+	uc.mutation.SetPhoneCipher(s)
+	return uc
+}
+
+// SetNillablePhoneCipher sets the "phoneCipher" field if the given value is not nil.
+func (uc *UserCreate) SetNillablePhoneCipher(s *string) *UserCreate {
+	if s != nil {
+		uc.SetPhoneCipher(*s)
+	}
+	return uc
+}
+
+// SetPassword sets the "password" field.
+func (uc *UserCreate) SetPassword(s string) *UserCreate {
+	uc.mutation.SetPassword(s)
+	return uc
+}
+
+// SetNillablePassword sets the "password" field if the given value is not nil.
+func (uc *UserCreate) SetNillablePassword(s *string) *UserCreate {
+	if s != nil {
+		uc.SetPassword(*s)
+	}
+	return uc
+}
+
+// SetPasswordNonce sets the "passwordNonce" field.
+func (uc *UserCreate) SetPasswordNonce(s string) *UserCreate {
+	uc.mutation.SetPasswordNonce(s)
+	return uc
+}
+
+// SetNillablePasswordNonce sets the "passwordNonce" field if the given value is not nil.
+func (uc *UserCreate) SetNillablePasswordNonce(s *string) *UserCreate {
+	if s != nil {
+		uc.SetPasswordNonce(*s)
+	}
+	return uc
+}
+
+// SetName sets the "name" field.
+func (uc *UserCreate) SetName(s string) *UserCreate {
+	uc.mutation.SetName(s)
+	return uc
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (uc *UserCreate) SetNillableName(s *string) *UserCreate {
+	if s != nil {
+		uc.SetName(*s)
+	}
+	return uc
+}
+
+// Mutation returns the UserMutation object of the builder.
+func (uc *UserCreate) Mutation() *UserMutation {
+	return uc.mutation
+}
+
+// Save creates the User in the database.
+func (uc *UserCreate) Save(ctx context.Context) (*User, error) {
+	if err := uc.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, uc.sqlSave, uc.mutation, uc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (uc *UserCreate) SaveX(ctx context.Context) *User {
+	v, err := uc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (uc *UserCreate) Exec(ctx context.Context) error {
+	_, err := uc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (uc *UserCreate) ExecX(ctx context.Context) {
+	if err := uc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (uc *UserCreate) defaults() error {
+	if _, ok := uc.mutation.Del(); !ok {
+		v := user.DefaultDel
+		uc.mutation.SetDel(v)
+	}
+	if _, ok := uc.mutation.Switch(); !ok {
+		v := user.DefaultSwitch
+		uc.mutation.SetSwitch(v)
+	}
+	if _, ok := uc.mutation.UpdateTime(); !ok {
+		if user.DefaultUpdateTime == nil {
+			return fmt.Errorf("ent: uninitialized user.DefaultUpdateTime (forgotten import ent/runtime?)")
+		}
+		v := user.DefaultUpdateTime()
+		uc.mutation.SetUpdateTime(v)
+	}
+	if _, ok := uc.mutation.PhonePrefix(); !ok {
+		v := user.DefaultPhonePrefix
+		uc.mutation.SetPhonePrefix(v)
+	}
+	if _, ok := uc.mutation.Password(); !ok {
+		v := user.DefaultPassword
+		uc.mutation.SetPassword(v)
+	}
+	if _, ok := uc.mutation.PasswordNonce(); !ok {
+		v := user.DefaultPasswordNonce
+		uc.mutation.SetPasswordNonce(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (uc *UserCreate) check() error {
+	if _, ok := uc.mutation.TenantID(); !ok {
+		return &ValidationError{Name: "tenantID", err: errors.New(`ent: missing required field "User.tenantID"`)}
+	}
+	if v, ok := uc.mutation.TenantID(); ok {
+		if err := user.TenantIDValidator(v); err != nil {
+			return &ValidationError{Name: "tenantID", err: fmt.Errorf(`ent: validator failed for field "User.tenantID": %w`, err)}
+		}
+	}
+	if _, ok := uc.mutation.Del(); !ok {
+		return &ValidationError{Name: "del", err: errors.New(`ent: missing required field "User.del"`)}
+	}
+	if v, ok := uc.mutation.Deleter(); ok {
+		if err := user.DeleterValidator(v); err != nil {
+			return &ValidationError{Name: "deleter", err: fmt.Errorf(`ent: validator failed for field "User.deleter": %w`, err)}
+		}
+	}
+	if _, ok := uc.mutation.Switch(); !ok {
+		return &ValidationError{Name: "switch", err: errors.New(`ent: missing required field "User.switch"`)}
+	}
+	if _, ok := uc.mutation.UpdateTime(); !ok {
+		return &ValidationError{Name: "updateTime", err: errors.New(`ent: missing required field "User.updateTime"`)}
+	}
+	if v, ok := uc.mutation.PhonePrefix(); ok {
+		if err := user.PhonePrefixValidator(v); err != nil {
+			return &ValidationError{Name: "phonePrefix", err: fmt.Errorf(`ent: validator failed for field "User.phonePrefix": %w`, err)}
+		}
+	}
+	if v, ok := uc.mutation.Phone(); ok {
+		if err := user.PhoneValidator(v); err != nil {
+			return &ValidationError{Name: "phone", err: fmt.Errorf(`ent: validator failed for field "User.phone": %w`, err)}
+		}
+	}
+	if v, ok := uc.mutation.PhoneCipher(); ok {
+		if err := user.PhoneCipherValidator(v); err != nil {
+			return &ValidationError{Name: "phoneCipher", err: fmt.Errorf(`ent: validator failed for field "User.phoneCipher": %w`, err)}
+		}
+	}
+	if v, ok := uc.mutation.Password(); ok {
+		if err := user.PasswordValidator(v); err != nil {
+			return &ValidationError{Name: "password", err: fmt.Errorf(`ent: validator failed for field "User.password": %w`, err)}
+		}
+	}
+	if v, ok := uc.mutation.PasswordNonce(); ok {
+		if err := user.PasswordNonceValidator(v); err != nil {
+			return &ValidationError{Name: "passwordNonce", err: fmt.Errorf(`ent: validator failed for field "User.passwordNonce": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (uc *UserCreate) sqlSave(ctx context.Context) (*User, error) {
+	if err := uc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := uc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, uc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	uc.mutation.id = &_node.ID
+	uc.mutation.done = true
+	return _node, nil
+}
+
+func (uc *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec) {
+	var (
+		_node = &User{config: uc.config}
+		_spec = sqlgraph.NewCreateSpec(user.Table, sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt))
+	)
+	if value, ok := uc.mutation.TenantID(); ok {
+		_spec.SetField(user.FieldTenantID, field.TypeString, value)
+		_node.TenantID = value
+	}
+	if value, ok := uc.mutation.Del(); ok {
+		_spec.SetField(user.FieldDel, field.TypeBool, value)
+		_node.Del = value
+	}
+	if value, ok := uc.mutation.DeleteTime(); ok {
+		_spec.SetField(user.FieldDeleteTime, field.TypeTime, value)
+		_node.DeleteTime = &value
+	}
+	if value, ok := uc.mutation.Deleter(); ok {
+		_spec.SetField(user.FieldDeleter, field.TypeString, value)
+		_node.Deleter = value
+	}
+	if value, ok := uc.mutation.Switch(); ok {
+		_spec.SetField(user.FieldSwitch, field.TypeBool, value)
+		_node.Switch = value
+	}
+	if value, ok := uc.mutation.UpdateTime(); ok {
+		_spec.SetField(user.FieldUpdateTime, field.TypeTime, value)
+		_node.UpdateTime = value
+	}
+	if value, ok := uc.mutation.PhonePrefix(); ok {
+		_spec.SetField(user.FieldPhonePrefix, field.TypeString, value)
+		_node.PhonePrefix = value
+	}
+	if value, ok := uc.mutation.Phone(); ok {
+		_spec.SetField(user.FieldPhone, field.TypeString, value)
+		_node.Phone = value
+	}
+	if value, ok := uc.mutation.PhoneCipher(); ok {
+		_spec.SetField(user.FieldPhoneCipher, field.TypeString, value)
+		_node.PhoneCipher = value
+	}
+	if value, ok := uc.mutation.Password(); ok {
+		_spec.SetField(user.FieldPassword, field.TypeString, value)
+		_node.Password = value
+	}
+	if value, ok := uc.mutation.PasswordNonce(); ok {
+		_spec.SetField(user.FieldPasswordNonce, field.TypeString, value)
+		_node.PasswordNonce = value
+	}
+	if value, ok := uc.mutation.Name(); ok {
+		_spec.SetField(user.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	return _node, _spec
+}
+
+// UserCreateBulk is the builder for creating many User entities in bulk.
+type UserCreateBulk struct {
+	config
+	err      error
+	builders []*UserCreate
+}
+
+// Save creates the User entities in the database.
+func (ucb *UserCreateBulk) Save(ctx context.Context) ([]*User, error) {
+	if ucb.err != nil {
+		return nil, ucb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(ucb.builders))
+	nodes := make([]*User, len(ucb.builders))
+	mutators := make([]Mutator, len(ucb.builders))
+	for i := range ucb.builders {
+		func(i int, root context.Context) {
+			builder := ucb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*UserMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ucb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ucb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ucb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ucb *UserCreateBulk) SaveX(ctx context.Context) []*User {
+	v, err := ucb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ucb *UserCreateBulk) Exec(ctx context.Context) error {
+	_, err := ucb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ucb *UserCreateBulk) ExecX(ctx context.Context) {
+	if err := ucb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,784 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/yimoka/go/internal/entfixture/ent/predicate"
+	"github.com/yimoka/go/internal/entfixture/ent/user"
+)
+
+// UserUpdate is the builder for updating User entities.
+type UserUpdate struct {
+	config
+	hooks    []Hook
+	mutation *UserMutation
+}
+
+// Where appends a list predicates to the UserUpdate builder.
+func (uu *UserUpdate) Where(ps ...predicate.User) *UserUpdate {
+	uu.mutation.Where(ps...)
+	return uu
+}
+
+// SetDel sets the "del" field.
+func (uu *UserUpdate) SetDel(b bool) *UserUpdate {
+	uu.mutation.SetDel(b)
+	return uu
+}
+
+// SetNillableDel sets the "del" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableDel(b *bool) *UserUpdate {
+	if b != nil {
+		uu.SetDel(*b)
+	}
+	return uu
+}
+
+// SetDeleteTime sets the "deleteTime" field.
+func (uu *UserUpdate) SetDeleteTime(t time.Time) *UserUpdate {
+	uu.mutation.SetDeleteTime(t)
+	return uu
+}
+
+// SetNillableDeleteTime sets the "deleteTime" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableDeleteTime(t *time.Time) *UserUpdate {
+	if t != nil {
+		uu.SetDeleteTime(*t)
+	}
+	return uu
+}
+
+// ClearDeleteTime clears the value of the "deleteTime" field.
+func (uu *UserUpdate) ClearDeleteTime() *UserUpdate {
+	uu.mutation.ClearDeleteTime()
+	return uu
+}
+
+// SetDeleter sets the "deleter" field.
+func (uu *UserUpdate) SetDeleter(s string) *UserUpdate {
+	uu.mutation.SetDeleter(s)
+	return uu
+}
+
+// SetNillableDeleter sets the "deleter" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableDeleter(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetDeleter(*s)
+	}
+	return uu
+}
+
+// ClearDeleter clears the value of the "deleter" field.
+func (uu *UserUpdate) ClearDeleter() *UserUpdate {
+	uu.mutation.ClearDeleter()
+	return uu
+}
+
+// SetSwitch sets the "switch" field.
+func (uu *UserUpdate) SetSwitch(b bool) *UserUpdate {
+	uu.mutation.SetSwitch(b)
+	return uu
+}
+
+// SetNillableSwitch sets the "switch" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableSwitch(b *bool) *UserUpdate {
+	if b != nil {
+		uu.SetSwitch(*b)
+	}
+	return uu
+}
+
+// SetUpdateTime sets the "updateTime" field.
+func (uu *UserUpdate) SetUpdateTime(t time.Time) *UserUpdate {
+	uu.mutation.SetUpdateTime(t)
+	return uu
+}
+
+// SetPhonePrefix sets the "phonePrefix" field.
+func (uu *UserUpdate) SetPhonePrefix(s string) *UserUpdate {
+	uu.mutation.SetPhonePrefix(s)
+	return uu
+}
+
+// SetNillablePhonePrefix sets the "phonePrefix" field if the given value is not nil.
+func (uu *UserUpdate) SetNillablePhonePrefix(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetPhonePrefix(*s)
+	}
+	return uu
+}
+
+// ClearPhonePrefix clears the value of the "phonePrefix" field.
+func (uu *UserUpdate) ClearPhonePrefix() *UserUpdate {
+	uu.mutation.ClearPhonePrefix()
+	return uu
+}
+
+// SetPhone sets the "phone" field.
+func (uu *UserUpdate) SetPhone(s string) *UserUpdate {
+	uu.mutation.SetPhone(s)
+	return uu
+}
+
+// SetNillablePhone sets the "phone" field if the given value is not nil.
+func (uu *UserUpdate) SetNillablePhone(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetPhone(*s)
+	}
+	return uu
+}
+
+// ClearPhone clears the value of the "phone" field.
+func (uu *UserUpdate) ClearPhone() *UserUpdate {
+	uu.mutation.ClearPhone()
+	return uu
+}
+
+// SetPhoneCipher sets the "phoneCipher" field.
+func (uu *UserUpdate) SetPhoneCipher(s string) *UserUpdate {
+	uu.mutation.SetPhoneCipher(s)
+	return uu
+}
+
+// SetNillablePhoneCipher sets the "phoneCipher" field if the given value is not nil.
+func (uu *UserUpdate) SetNillablePhoneCipher(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetPhoneCipher(*s)
+	}
+	return uu
+}
+
+// ClearPhoneCipher clears the value of the "phoneCipher" field.
+func (uu *UserUpdate) ClearPhoneCipher() *UserUpdate {
+	uu.mutation.ClearPhoneCipher()
+	return uu
+}
+
+// SetPassword sets the "password" field.
+func (uu *UserUpdate) SetPassword(s string) *UserUpdate {
+	uu.mutation.SetPassword(s)
+	return uu
+}
+
+// SetNillablePassword sets the "password" field if the given value is not nil.
+func (uu *UserUpdate) SetNillablePassword(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetPassword(*s)
+	}
+	return uu
+}
+
+// ClearPassword clears the value of the "password" field.
+func (uu *UserUpdate) ClearPassword() *UserUpdate {
+	uu.mutation.ClearPassword()
+	return uu
+}
+
+// SetPasswordNonce sets the "passwordNonce" field.
+func (uu *UserUpdate) SetPasswordNonce(s string) *UserUpdate {
+	uu.mutation.SetPasswordNonce(s)
+	return uu
+}
+
+// SetNillablePasswordNonce sets the "passwordNonce" field if the given value is not nil.
+func (uu *UserUpdate) SetNillablePasswordNonce(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetPasswordNonce(*s)
+	}
+	return uu
+}
+
+// ClearPasswordNonce clears the value of the "passwordNonce" field.
+func (uu *UserUpdate) ClearPasswordNonce() *UserUpdate {
+	uu.mutation.ClearPasswordNonce()
+	return uu
+}
+
+// SetName sets the "name" field.
+func (uu *UserUpdate) SetName(s string) *UserUpdate {
+	uu.mutation.SetName(s)
+	return uu
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableName(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetName(*s)
+	}
+	return uu
+}
+
+// ClearName clears the value of the "name" field.
+func (uu *UserUpdate) ClearName() *UserUpdate {
+	uu.mutation.ClearName()
+	return uu
+}
+
+// Mutation returns the UserMutation object of the builder.
+func (uu *UserUpdate) Mutation() *UserMutation {
+	return uu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (uu *UserUpdate) Save(ctx context.Context) (int, error) {
+	if err := uu.defaults(); err != nil {
+		return 0, err
+	}
+	return withHooks(ctx, uu.sqlSave, uu.mutation, uu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (uu *UserUpdate) SaveX(ctx context.Context) int {
+	affected, err := uu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (uu *UserUpdate) Exec(ctx context.Context) error {
+	_, err := uu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (uu *UserUpdate) ExecX(ctx context.Context) {
+	if err := uu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (uu *UserUpdate) defaults() error {
+	if _, ok := uu.mutation.UpdateTime(); !ok {
+		if user.UpdateDefaultUpdateTime == nil {
+			return fmt.Errorf("ent: uninitialized user.UpdateDefaultUpdateTime (forgotten import ent/runtime?)")
+		}
+		v := user.UpdateDefaultUpdateTime()
+		uu.mutation.SetUpdateTime(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (uu *UserUpdate) check() error {
+	if v, ok := uu.mutation.Deleter(); ok {
+		if err := user.DeleterValidator(v); err != nil {
+			return &ValidationError{Name: "deleter", err: fmt.Errorf(`ent: validator failed for field "User.deleter": %w`, err)}
+		}
+	}
+	if v, ok := uu.mutation.PhonePrefix(); ok {
+		if err := user.PhonePrefixValidator(v); err != nil {
+			return &ValidationError{Name: "phonePrefix", err: fmt.Errorf(`ent: validator failed for field "User.phonePrefix": %w`, err)}
+		}
+	}
+	if v, ok := uu.mutation.Phone(); ok {
+		if err := user.PhoneValidator(v); err != nil {
+			return &ValidationError{Name: "phone", err: fmt.Errorf(`ent: validator failed for field "User.phone": %w`, err)}
+		}
+	}
+	if v, ok := uu.mutation.PhoneCipher(); ok {
+		if err := user.PhoneCipherValidator(v); err != nil {
+			return &ValidationError{Name: "phoneCipher", err: fmt.Errorf(`ent: validator failed for field "User.phoneCipher": %w`, err)}
+		}
+	}
+	if v, ok := uu.mutation.Password(); ok {
+		if err := user.PasswordValidator(v); err != nil {
+			return &ValidationError{Name: "password", err: fmt.Errorf(`ent: validator failed for field "User.password": %w`, err)}
+		}
+	}
+	if v, ok := uu.mutation.PasswordNonce(); ok {
+		if err := user.PasswordNonceValidator(v); err != nil {
+			return &ValidationError{Name: "passwordNonce", err: fmt.Errorf(`ent: validator failed for field "User.passwordNonce": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (uu *UserUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := uu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(user.Table, user.Columns, sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt))
+	if ps := uu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := uu.mutation.Del(); ok {
+		_spec.SetField(user.FieldDel, field.TypeBool, value)
+	}
+	if value, ok := uu.mutation.DeleteTime(); ok {
+		_spec.SetField(user.FieldDeleteTime, field.TypeTime, value)
+	}
+	if uu.mutation.DeleteTimeCleared() {
+		_spec.ClearField(user.FieldDeleteTime, field.TypeTime)
+	}
+	if value, ok := uu.mutation.Deleter(); ok {
+		_spec.SetField(user.FieldDeleter, field.TypeString, value)
+	}
+	if uu.mutation.DeleterCleared() {
+		_spec.ClearField(user.FieldDeleter, field.TypeString)
+	}
+	if value, ok := uu.mutation.Switch(); ok {
+		_spec.SetField(user.FieldSwitch, field.TypeBool, value)
+	}
+	if value, ok := uu.mutation.UpdateTime(); ok {
+		_spec.SetField(user.FieldUpdateTime, field.TypeTime, value)
+	}
+	if value, ok := uu.mutation.PhonePrefix(); ok {
+		_spec.SetField(user.FieldPhonePrefix, field.TypeString, value)
+	}
+	if uu.mutation.PhonePrefixCleared() {
+		_spec.ClearField(user.FieldPhonePrefix, field.TypeString)
+	}
+	if value, ok := uu.mutation.Phone(); ok {
+		_spec.SetField(user.FieldPhone, field.TypeString, value)
+	}
+	if uu.mutation.PhoneCleared() {
+		_spec.ClearField(user.FieldPhone, field.TypeString)
+	}
+	if value, ok := uu.mutation.PhoneCipher(); ok {
+		_spec.SetField(user.FieldPhoneCipher, field.TypeString, value)
+	}
+	if uu.mutation.PhoneCipherCleared() {
+		_spec.ClearField(user.FieldPhoneCipher, field.TypeString)
+	}
+	if value, ok := uu.mutation.Password(); ok {
+		_spec.SetField(user.FieldPassword, field.TypeString, value)
+	}
+	if uu.mutation.PasswordCleared() {
+		_spec.ClearField(user.FieldPassword, field.TypeString)
+	}
+	if value, ok := uu.mutation.PasswordNonce(); ok {
+		_spec.SetField(user.FieldPasswordNonce, field.TypeString, value)
+	}
+	if uu.mutation.PasswordNonceCleared() {
+		_spec.ClearField(user.FieldPasswordNonce, field.TypeString)
+	}
+	if value, ok := uu.mutation.Name(); ok {
+		_spec.SetField(user.FieldName, field.TypeString, value)
+	}
+	if uu.mutation.NameCleared() {
+		_spec.ClearField(user.FieldName, field.TypeString)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, uu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{user.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	uu.mutation.done = true
+	return n, nil
+}
+
+// UserUpdateOne is the builder for updating a single User entity.
+type UserUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *UserMutation
+}
+
+// SetDel sets the "del" field.
+func (uuo *UserUpdateOne) SetDel(b bool) *UserUpdateOne {
+	uuo.mutation.SetDel(b)
+	return uuo
+}
+
+// SetNillableDel sets the "del" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableDel(b *bool) *UserUpdateOne {
+	if b != nil {
+		uuo.SetDel(*b)
+	}
+	return uuo
+}
+
+// SetDeleteTime sets the "deleteTime" field.
+func (uuo *UserUpdateOne) SetDeleteTime(t time.Time) *UserUpdateOne {
+	uuo.mutation.SetDeleteTime(t)
+	return uuo
+}
+
+// SetNillableDeleteTime sets the "deleteTime" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableDeleteTime(t *time.Time) *UserUpdateOne {
+	if t != nil {
+		uuo.SetDeleteTime(*t)
+	}
+	return uuo
+}
+
+// ClearDeleteTime clears the value of the "deleteTime" field.
+func (uuo *UserUpdateOne) ClearDeleteTime() *UserUpdateOne {
+	uuo.mutation.ClearDeleteTime()
+	return uuo
+}
+
+// SetDeleter sets the "deleter" field.
+func (uuo *UserUpdateOne) SetDeleter(s string) *UserUpdateOne {
+	uuo.mutation.SetDeleter(s)
+	return uuo
+}
+
+// SetNillableDeleter sets the "deleter" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableDeleter(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetDeleter(*s)
+	}
+	return uuo
+}
+
+// ClearDeleter clears the value of the "deleter" field.
+func (uuo *UserUpdateOne) ClearDeleter() *UserUpdateOne {
+	uuo.mutation.ClearDeleter()
+	return uuo
+}
+
+// SetSwitch sets the "switch" field.
+func (uuo *UserUpdateOne) SetSwitch(b bool) *UserUpdateOne {
+	uuo.mutation.SetSwitch(b)
+	return uuo
+}
+
+// SetNillableSwitch sets the "switch" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableSwitch(b *bool) *UserUpdateOne {
+	if b != nil {
+		uuo.SetSwitch(*b)
+	}
+	return uuo
+}
+
+// SetUpdateTime sets the "updateTime" field.
+func (uuo *UserUpdateOne) SetUpdateTime(t time.Time) *UserUpdateOne {
+	uuo.mutation.SetUpdateTime(t)
+	return uuo
+}
+
+// SetPhonePrefix sets the "phonePrefix" field.
+func (uuo *UserUpdateOne) SetPhonePrefix(s string) *UserUpdateOne {
+	uuo.mutation.SetPhonePrefix(s)
+	return uuo
+}
+
+// SetNillablePhonePrefix sets the "phonePrefix" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillablePhonePrefix(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetPhonePrefix(*s)
+	}
+	return uuo
+}
+
+// ClearPhonePrefix clears the value of the "phonePrefix" field.
+func (uuo *UserUpdateOne) ClearPhonePrefix() *UserUpdateOne {
+	uuo.mutation.ClearPhonePrefix()
+	return uuo
+}
+
+// SetPhone sets the "phone" field.
+func (uuo *UserUpdateOne) SetPhone(s string) *UserUpdateOne {
+	uuo.mutation.SetPhone(s)
+	return uuo
+}
+
+// SetNillablePhone sets the "phone" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillablePhone(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetPhone(*s)
+	}
+	return uuo
+}
+
+// ClearPhone clears the value of the "phone" field.
+func (uuo *UserUpdateOne) ClearPhone() *UserUpdateOne {
+	uuo.mutation.ClearPhone()
+	return uuo
+}
+
+// SetPhoneCipher sets the "phoneCipher" field.
+func (uuo *UserUpdateOne) SetPhoneCipher(s string) *UserUpdateOne {
+	uuo.mutation.SetPhoneCipher(s)
+	return uuo
+}
+
+// SetNillablePhoneCipher sets the "phoneCipher" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillablePhoneCipher(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetPhoneCipher(*s)
+	}
+	return uuo
+}
+
+// ClearPhoneCipher clears the value of the "phoneCipher" field.
+func (uuo *UserUpdateOne) ClearPhoneCipher() *UserUpdateOne {
+	uuo.mutation.ClearPhoneCipher()
+	return uuo
+}
+
+// SetPassword sets the "password" field.
+func (uuo *UserUpdateOne) SetPassword(s string) *UserUpdateOne {
+	uuo.mutation.SetPassword(s)
+	return uuo
+}
+
+// SetNillablePassword sets the "password" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillablePassword(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetPassword(*s)
+	}
+	return uuo
+}
+
+// ClearPassword clears the value of the "password" field.
+func (uuo *UserUpdateOne) ClearPassword() *UserUpdateOne {
+	uuo.mutation.ClearPassword()
+	return uuo
+}
+
+// SetPasswordNonce sets the "passwordNonce" field.
+func (uuo *UserUpdateOne) SetPasswordNonce(s string) *UserUpdateOne {
+	uuo.mutation.SetPasswordNonce(s)
+	return uuo
+}
+
+// SetNillablePasswordNonce sets the "passwordNonce" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillablePasswordNonce(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetPasswordNonce(*s)
+	}
+	return uuo
+}
+
+// ClearPasswordNonce clears the value of the "passwordNonce" field.
+func (uuo *UserUpdateOne) ClearPasswordNonce() *UserUpdateOne {
+	uuo.mutation.ClearPasswordNonce()
+	return uuo
+}
+
+// SetName sets the "name" field.
+func (uuo *UserUpdateOne) SetName(s string) *UserUpdateOne {
+	uuo.mutation.SetName(s)
+	return uuo
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableName(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetName(*s)
+	}
+	return uuo
+}
+
+// ClearName clears the value of the "name" field.
+func (uuo *UserUpdateOne) ClearName() *UserUpdateOne {
+	uuo.mutation.ClearName()
+	return uuo
+}
+
+// Mutation returns the UserMutation object of the builder.
+func (uuo *UserUpdateOne) Mutation() *UserMutation {
+	return uuo.mutation
+}
+
+// Where appends a list predicates to the UserUpdate builder.
+func (uuo *UserUpdateOne) Where(ps ...predicate.User) *UserUpdateOne {
+	uuo.mutation.Where(ps...)
+	return uuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (uuo *UserUpdateOne) Select(field string, fields ...string) *UserUpdateOne {
+	uuo.fields = append([]string{field}, fields...)
+	return uuo
+}
+
+// Save executes the query and returns the updated User entity.
+func (uuo *UserUpdateOne) Save(ctx context.Context) (*User, error) {
+	if err := uuo.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, uuo.sqlSave, uuo.mutation, uuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (uuo *UserUpdateOne) SaveX(ctx context.Context) *User {
+	node, err := uuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (uuo *UserUpdateOne) Exec(ctx context.Context) error {
+	_, err := uuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (uuo *UserUpdateOne) ExecX(ctx context.Context) {
+	if err := uuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (uuo *UserUpdateOne) defaults() error {
+	if _, ok := uuo.mutation.UpdateTime(); !ok {
+		if user.UpdateDefaultUpdateTime == nil {
+			return fmt.Errorf("ent: uninitialized user.UpdateDefaultUpdateTime (forgotten import ent/runtime?)")
+		}
+		v := user.UpdateDefaultUpdateTime()
+		uuo.mutation.SetUpdateTime(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (uuo *UserUpdateOne) check() error {
+	if v, ok := uuo.mutation.Deleter(); ok {
+		if err := user.DeleterValidator(v); err != nil {
+			return &ValidationError{Name: "deleter", err: fmt.Errorf(`ent: validator failed for field "User.deleter": %w`, err)}
+		}
+	}
+	if v, ok := uuo.mutation.PhonePrefix(); ok {
+		if err := user.PhonePrefixValidator(v); err != nil {
+			return &ValidationError{Name: "phonePrefix", err: fmt.Errorf(`ent: validator failed for field "User.phonePrefix": %w`, err)}
+		}
+	}
+	if v, ok := uuo.mutation.Phone(); ok {
+		if err := user.PhoneValidator(v); err != nil {
+			return &ValidationError{Name: "phone", err: fmt.Errorf(`ent: validator failed for field "User.phone": %w`, err)}
+		}
+	}
+	if v, ok := uuo.mutation.PhoneCipher(); ok {
+		if err := user.PhoneCipherValidator(v); err != nil {
+			return &ValidationError{Name: "phoneCipher", err: fmt.Errorf(`ent: validator failed for field "User.phoneCipher": %w`, err)}
+		}
+	}
+	if v, ok := uuo.mutation.Password(); ok {
+		if err := user.PasswordValidator(v); err != nil {
+			return &ValidationError{Name: "password", err: fmt.Errorf(`ent: validator failed for field "User.password": %w`, err)}
+		}
+	}
+	if v, ok := uuo.mutation.PasswordNonce(); ok {
+		if err := user.PasswordNonceValidator(v); err != nil {
+			return &ValidationError{Name: "passwordNonce", err: fmt.Errorf(`ent: validator failed for field "User.passwordNonce": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (uuo *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error) {
+	if err := uuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(user.Table, user.Columns, sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt))
+	id, ok := uuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "User.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := uuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, user.FieldID)
+		for _, f := range fields {
+			if !user.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != user.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := uuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := uuo.mutation.Del(); ok {
+		_spec.SetField(user.FieldDel, field.TypeBool, value)
+	}
+	if value, ok := uuo.mutation.DeleteTime(); ok {
+		_spec.SetField(user.FieldDeleteTime, field.TypeTime, value)
+	}
+	if uuo.mutation.DeleteTimeCleared() {
+		_spec.ClearField(user.FieldDeleteTime, field.TypeTime)
+	}
+	if value, ok := uuo.mutation.Deleter(); ok {
+		_spec.SetField(user.FieldDeleter, field.TypeString, value)
+	}
+	if uuo.mutation.DeleterCleared() {
+		_spec.ClearField(user.FieldDeleter, field.TypeString)
+	}
+	if value, ok := uuo.mutation.Switch(); ok {
+		_spec.SetField(user.FieldSwitch, field.TypeBool, value)
+	}
+	if value, ok := uuo.mutation.UpdateTime(); ok {
+		_spec.SetField(user.FieldUpdateTime, field.TypeTime, value)
+	}
+	if value, ok := uuo.mutation.PhonePrefix(); ok {
+		_spec.SetField(user.FieldPhonePrefix, field.TypeString, value)
+	}
+	if uuo.mutation.PhonePrefixCleared() {
+		_spec.ClearField(user.FieldPhonePrefix, field.TypeString)
+	}
+	if value, ok := uuo.mutation.Phone(); ok {
+		_spec.SetField(user.FieldPhone, field.TypeString, value)
+	}
+	if uuo.mutation.PhoneCleared() {
+		_spec.ClearField(user.FieldPhone, field.TypeString)
+	}
+	if value, ok := uuo.mutation.PhoneCipher(); ok {
+		_spec.SetField(user.FieldPhoneCipher, field.TypeString, value)
+	}
+	if uuo.mutation.PhoneCipherCleared() {
+		_spec.ClearField(user.FieldPhoneCipher, field.TypeString)
+	}
+	if value, ok := uuo.mutation.Password(); ok {
+		_spec.SetField(user.FieldPassword, field.TypeString, value)
+	}
+	if uuo.mutation.PasswordCleared() {
+		_spec.ClearField(user.FieldPassword, field.TypeString)
+	}
+	if value, ok := uuo.mutation.PasswordNonce(); ok {
+		_spec.SetField(user.FieldPasswordNonce, field.TypeString, value)
+	}
+	if uuo.mutation.PasswordNonceCleared() {
+		_spec.ClearField(user.FieldPasswordNonce, field.TypeString)
+	}
+	if value, ok := uuo.mutation.Name(); ok {
+		_spec.SetField(user.FieldName, field.TypeString, value)
+	}
+	if uuo.mutation.NameCleared() {
+		_spec.ClearField(user.FieldName, field.TypeString)
+	}
+	_node = &User{config: uuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, uuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{user.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	uuo.mutation.done = true
+	return _node, nil
+}
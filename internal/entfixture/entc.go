@@ -0,0 +1,52 @@
+//go:build ignore
+
+// 生成测试使用的 ent 代码 go run entc.go
+// 直接加载 Schema 而不通过 go/packages 解析源码, 新增 Schema 时需加入 schemas
+package main
+
+import (
+	"log"
+
+	"entgo.io/ent"
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/entc/load"
+	"github.com/yimoka/go/internal/entfixture/schema"
+)
+
+var schemas = []ent.Interface{
+	schema.User{},
+	schema.Contact{},
+	schema.App{},
+	schema.Article{},
+}
+
+func main() {
+	specs := make([]*load.Schema, 0, len(schemas))
+	for _, s := range schemas {
+		b, err := load.MarshalSchema(s)
+		if err != nil {
+			log.Fatalf("marshal schema: %v", err)
+		}
+		spec, err := load.UnmarshalSchema(b)
+		if err != nil {
+			log.Fatalf("unmarshal schema: %v", err)
+		}
+		specs = append(specs, spec)
+	}
+	storage, err := gen.NewStorage("sql")
+	if err != nil {
+		log.Fatal(err)
+	}
+	graph, err := gen.NewGraph(&gen.Config{
+		Schema:  "github.com/yimoka/go/internal/entfixture/schema",
+		Target:  "./ent",
+		Package: "github.com/yimoka/go/internal/entfixture/ent",
+		Storage: storage,
+	}, specs...)
+	if err != nil {
+		log.Fatalf("load graph: %v", err)
+	}
+	if err := graph.Gen(); err != nil {
+		log.Fatalf("running ent codegen: %v", err)
+	}
+}
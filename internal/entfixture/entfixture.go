@@ -0,0 +1,192 @@
+// Package entfixture 测试 ent 的 Hook 与 Interceptor 使用的生成代码与驱动
+// schema 中的 Schema 由 mixin 组合, ent 为其生成的代码, 修改 Schema 后执行 go generate 重新生成
+package entfixture
+
+//go:generate go run entc.go
+
+import (
+	"context"
+	stdsql "database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/yimoka/go/internal/entfixture/ent"
+
+	// 注册 Schema 的 Hook 与 Interceptor
+	_ "github.com/yimoka/go/internal/entfixture/ent/runtime"
+)
+
+// Stmt 执行的 SQL 语句
+type Stmt struct {
+	Query string
+	Args  []any
+}
+
+// Values INSERT 与 UPDATE 语句中各列的值 列名 -> 值, 只支持单行的 INSERT
+func (s Stmt) Values() map[string]any {
+	values := map[string]any{}
+	switch {
+	case strings.HasPrefix(s.Query, "INSERT"):
+		start := strings.Index(s.Query, "(")
+		end := strings.Index(s.Query, ")")
+		if start < 0 || end < start {
+			return values
+		}
+		for i, c := range strings.Split(s.Query[start+1:end], ", ") {
+			if i < len(s.Args) {
+				values[strings.Trim(c, "`")] = s.Args[i]
+			}
+		}
+	case strings.HasPrefix(s.Query, "UPDATE"):
+		_, set, _ := strings.Cut(s.Query, " SET ")
+		set, _, _ = strings.Cut(set, " WHERE ")
+		for i, c := range strings.Split(set, ", ") {
+			if col, _, ok := strings.Cut(c, " = ?"); ok && i < len(s.Args) {
+				values[strings.Trim(col, "`")] = s.Args[i]
+			}
+		}
+	}
+	return values
+}
+
+// Driver 记录执行的 SQL 的 MySQL 驱动 不连接数据库
+// 查询按顺序返回 AddRows 添加的结果, 没有时返回空结果; 执行返回自增 ID 1 与影响行数 1
+type Driver struct {
+	mu    sync.Mutex
+	stmts []Stmt
+	rows  []*Rows
+}
+
+// NewClient 创建使用 Driver 的 Client
+func NewClient(opts ...ent.Option) (*ent.Client, *Driver) {
+	drv := &Driver{}
+	return ent.NewClient(append([]ent.Option{ent.Driver(drv)}, opts...)...), drv
+}
+
+// AddRows 添加一个查询结果 columns 为列名, values 为各行的值
+func (d *Driver) AddRows(columns []string, values ...[]any) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.rows = append(d.rows, &Rows{columns: columns, values: values, i: -1})
+}
+
+// Stmts 执行过的语句
+func (d *Driver) Stmts() []Stmt {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]Stmt{}, d.stmts...)
+}
+
+// Last 最后执行的以 prefix 开头的语句 如 INSERT、UPDATE、SELECT
+func (d *Driver) Last(prefix string) (Stmt, bool) {
+	stmts := d.Stmts()
+	for i := len(stmts) - 1; i >= 0; i-- {
+		if strings.HasPrefix(stmts[i].Query, prefix) {
+			return stmts[i], true
+		}
+	}
+	return Stmt{}, false
+}
+
+// Reset 清空记录的语句与未使用的结果
+func (d *Driver) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.stmts, d.rows = nil, nil
+}
+
+func (d *Driver) record(query string, args any) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	a, _ := args.([]any)
+	d.stmts = append(d.stmts, Stmt{Query: query, Args: a})
+}
+
+// Exec _
+func (d *Driver) Exec(_ context.Context, query string, args, v any) error {
+	d.record(query, args)
+	if res, ok := v.(*sql.Result); ok {
+		*res = result{}
+	}
+	return nil
+}
+
+// Query _
+func (d *Driver) Query(_ context.Context, query string, args, v any) error {
+	d.record(query, args)
+	rows, ok := v.(*sql.Rows)
+	if !ok {
+		return fmt.Errorf("entfixture: unexpected query result %T", v)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	r := &Rows{i: -1}
+	if len(d.rows) > 0 {
+		r, d.rows = d.rows[0], d.rows[1:]
+	}
+	*rows = sql.Rows{ColumnScanner: r}
+	return nil
+}
+
+// Tx _
+func (d *Driver) Tx(context.Context) (dialect.Tx, error) { return dialect.NopTx(d), nil }
+
+// Close _
+func (d *Driver) Close() error { return nil }
+
+// Dialect _
+func (d *Driver) Dialect() string { return dialect.MySQL }
+
+type result struct{}
+
+func (result) LastInsertId() (int64, error) { return 1, nil }
+func (result) RowsAffected() (int64, error) { return 1, nil }
+
+// Rows 查询的结果
+type Rows struct {
+	sql.ColumnScanner
+	columns []string
+	values  [][]any
+	i       int
+}
+
+// Columns _
+func (r *Rows) Columns() ([]string, error) { return r.columns, nil }
+
+// Next _
+func (r *Rows) Next() bool { r.i++; return r.i < len(r.values) }
+
+// Close _
+func (r *Rows) Close() error { return nil }
+
+// Err _
+func (r *Rows) Err() error { return nil }
+
+// Scan 赋值 支持 sql.Scanner、*any 及可转换类型的指针 如 int64 -> *int
+func (r *Rows) Scan(dest ...any) error {
+	row := r.values[r.i]
+	if len(dest) != len(row) {
+		return fmt.Errorf("entfixture: expected %d destination arguments in Scan, not %d", len(row), len(dest))
+	}
+	for i, d := range dest {
+		switch d := d.(type) {
+		case stdsql.Scanner:
+			if err := d.Scan(row[i]); err != nil {
+				return err
+			}
+		case *any:
+			*d = row[i]
+		default:
+			dv := reflect.ValueOf(d)
+			if dv.Kind() != reflect.Pointer || row[i] == nil || !reflect.TypeOf(row[i]).ConvertibleTo(dv.Elem().Type()) {
+				return fmt.Errorf("entfixture: unsupported Scan, storing %T into %T", row[i], d)
+			}
+			dv.Elem().Set(reflect.ValueOf(row[i]).Convert(dv.Elem().Type()))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+// Package schema app
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema"
+	"github.com/yimoka/go/ent/ann"
+	"github.com/yimoka/go/ent/mixin"
+)
+
+// App 应用
+type App struct {
+	ent.Schema
+}
+
+// Mixin _
+func (App) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.Secret{},
+	}
+}
+
+// Annotations _
+func (App) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		ann.Table{SecretKey: "app"},
+	}
+}
@@ -0,0 +1,28 @@
+// Package schema article
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"github.com/yimoka/go/ent/mixin"
+)
+
+// Article 文章 同时隔离租户与应用
+type Article struct {
+	ent.Schema
+}
+
+// Fields _
+func (Article) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("title").Optional(),
+	}
+}
+
+// Mixin _
+func (Article) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.TenantID{},
+		mixin.Appid{},
+	}
+}
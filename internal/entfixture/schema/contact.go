@@ -0,0 +1,32 @@
+// Package schema contact
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"github.com/yimoka/go/ent/ann"
+	"github.com/yimoka/go/ent/mixin"
+	"github.com/yimoka/go/utils"
+)
+
+// Contact 联系人
+type Contact struct {
+	ent.Schema
+}
+
+// Fields _
+func (Contact) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").Optional(),
+		field.String("phone").Optional().Annotations(ann.Field{MaskEncrypt: utils.MaskTypePhone, BlindIndex: 16}),
+		field.String("phoneCipher").Optional().Annotations(ann.Field{OnlyData: true}),
+		field.String("phoneBidx").Optional().Annotations(ann.Field{OnlyData: true}),
+	}
+}
+
+// Mixin _
+func (Contact) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.TenantIDOnlyPortal{},
+	}
+}
@@ -0,0 +1,47 @@
+// Package schema 测试使用的 ent Schema 由 mixin 组合而成
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"github.com/yimoka/go/ent/ann"
+	"github.com/yimoka/go/ent/mixin"
+)
+
+// User 用户
+type User struct {
+	ent.Schema
+}
+
+// Fields _
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").Optional(),
+	}
+}
+
+// Mixin _
+func (User) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.TenantID{},
+		mixin.Del{WithTime: true, WithOperator: true},
+		mixin.Switch{},
+		mixin.UpdateTime{},
+		mixin.Phone{},
+		mixin.Password{},
+	}
+}
+
+// Annotations _
+func (User) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		ann.Table{
+			SecretKey: "user",
+			MutationConfig: ann.MutationConfig{
+				OpLogTable:   "user_op_log",
+				OperatorCode: "operator, _ := meta.GetUserID(ctx)",
+			},
+		},
+	}
+}
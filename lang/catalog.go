@@ -0,0 +1,91 @@
+package lang
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/yimoka/api/fault"
+	"golang.org/x/text/language"
+)
+
+// Catalog 业务模块的消息表 K 为模块的消息键类型, 如 type OrderKey lang.MsgKey
+// 消息加载到公共语言包中, 可被消息文件与配置的 messages 覆盖, 语言的解析与公共语言包的消息相同
+type Catalog[K ~string] struct {
+	lang *CommonLang
+	// defaults 默认语言 English 的消息 语言包中找不到时使用
+	defaults map[K]*i18n.Message
+}
+
+// NewCatalog 创建业务模块的消息表 messages 为各语言的内置消息, 消息 ID 与消息键不同或消息无效时返回错误
+// 消息键类型声明为 MsgKey 或 type X lang.MsgKey 时, yimoka-i18n 可提取消息键并检查翻译
+// 消息添加到当前的语言包 须在处理请求前创建
+func NewCatalog[K ~string](l *CommonLang, messages map[language.Tag]map[K]*i18n.Message) (*Catalog[K], error) {
+	msgs := make(map[language.Tag][]*i18n.Message, len(messages))
+	for tag, m := range messages {
+		for key, msg := range m {
+			if msg == nil || msg.ID != string(key) {
+				return nil, fmt.Errorf("lang: %s message %q: id must match the key", tag, key)
+			}
+			msgs[tag] = append(msgs[tag], msg)
+		}
+	}
+	if err := l.addMessages(msgs); err != nil {
+		return nil, err
+	}
+	return &Catalog[K]{lang: l, defaults: messages[language.English]}, nil
+}
+
+// Keys 默认语言的消息键 已排序
+func (c *Catalog[K]) Keys() []K {
+	keys := make([]K, 0, len(c.defaults))
+	for k := range c.defaults {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// Msg 获取本地化的消息 templateData 为模板数据
+func (c *Catalog[K]) Msg(ctx context.Context, key K, templateData interface{}, langs ...string) string {
+	localizer := c.lang.getLocalizer(ctx, langs...)
+	v, err := localizer.Localize(&i18n.LocalizeConfig{
+		MessageID:      string(key),
+		TemplateData:   templateData,
+		DefaultMessage: c.defaults[key],
+	})
+	if err != nil {
+		c.lang.log.Error("CatalogMsg", err)
+		return HandleError(MsgKey(key), c.defaults[key], templateData)
+	}
+	return v
+}
+
+// newError 以本地化的消息创建错误 reason 为空时使用 fault 的原因, data 为模板数据与 metadata
+func (c *Catalog[K]) newError(ctx context.Context, fn func(format string, args ...interface{}) *errors.Error, reason string, key K, data map[string]string) *errors.Error {
+	err := fn("%s", c.Msg(ctx, key, data))
+	if reason != "" {
+		err.Reason = reason
+	}
+	if len(data) > 0 {
+		err = err.WithMetadata(data)
+	}
+	return err
+}
+
+// Error 创建本地化的参数错误(400) reason 为业务的错误原因, 如 ORDER_PAID, data 为模板数据并作为错误的 metadata
+func (c *Catalog[K]) Error(ctx context.Context, reason string, key K, data map[string]string) *errors.Error {
+	return c.newError(ctx, fault.ErrorBadRequest, reason, key, data)
+}
+
+// NotFound 创建本地化的找不到错误(404)
+func (c *Catalog[K]) NotFound(ctx context.Context, reason string, key K, data map[string]string) *errors.Error {
+	return c.newError(ctx, fault.ErrorNotFound, reason, key, data)
+}
+
+// InternalError 创建本地化的服务器内部错误(500)
+func (c *Catalog[K]) InternalError(ctx context.Context, reason string, key K, data map[string]string) *errors.Error {
+	return c.newError(ctx, fault.ErrorInternalServerError, reason, key, data)
+}
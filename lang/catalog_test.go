@@ -0,0 +1,106 @@
+package lang
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/api/fault"
+	"github.com/yimoka/go/config"
+	"golang.org/x/text/language"
+)
+
+type orderKey MsgKey
+
+const (
+	orderPaidKey     orderKey = "order_paid"
+	orderNotFoundKey orderKey = "order_not_found"
+)
+
+var orderMessages = map[language.Tag]map[orderKey]*i18n.Message{
+	language.English: {
+		orderPaidKey:     {ID: string(orderPaidKey), Other: "Order {{.ID}} has been paid"},
+		orderNotFoundKey: {ID: string(orderNotFoundKey), Other: "Order not found"},
+	},
+	language.Chinese: {
+		orderPaidKey:     {ID: string(orderPaidKey), Other: "订单 {{.ID}} 已支付"},
+		orderNotFoundKey: {ID: string(orderNotFoundKey), Other: "订单不存在"},
+	},
+}
+
+func TestCatalog(t *testing.T) {
+	l := NewCommonLang(map[string]*config.Lang{
+		"zh": {Messages: []*config.LangMessage{{Id: string(orderNotFoundKey), Other: "找不到订单"}}},
+	}, log.DefaultLogger)
+	c, loadErr := NewCatalog(l, orderMessages)
+	assert.NoError(t, loadErr)
+	ctx := WithLocale(context.Background(), language.Chinese)
+
+	assert.Equal(t, []orderKey{orderNotFoundKey, orderPaidKey}, c.Keys())
+	assert.Equal(t, "Order 1 has been paid", c.Msg(ctx, orderPaidKey, map[string]string{"ID": "1"}, "en"))
+
+	err := c.Error(ctx, "ORDER_PAID", orderPaidKey, map[string]string{"ID": "1"})
+	assert.Equal(t, int32(400), err.Code)
+	assert.Equal(t, "ORDER_PAID", err.Reason)
+	assert.Equal(t, "订单 1 已支付", err.Message)
+	assert.Equal(t, map[string]string{"ID": "1"}, err.Metadata)
+
+	// 配置的消息优先于消息表
+	err = c.NotFound(ctx, "", orderNotFoundKey, nil)
+	assert.True(t, fault.IsNotFound(err))
+	assert.Equal(t, "找不到订单", err.Message)
+
+	// 不支持的语言使用默认语言的消息
+	err = c.InternalError(WithLocale(context.Background(), language.Japanese), "ORDER", orderNotFoundKey, nil)
+	assert.Equal(t, int32(500), err.Code)
+	assert.Equal(t, "Order not found", err.Message)
+	assert.Equal(t, "订单 2 已支付", c.Msg(ctx, orderPaidKey, map[string]string{"ID": "2"}, "zh-CN"))
+	assert.Empty(t, l.MissingTranslations()["zh"])
+}
+
+func TestCatalogKeepMessages(t *testing.T) {
+	l := NewCommonLang(nil, log.DefaultLogger)
+	ctx := context.Background()
+	assert.NoError(t, l.Bundle.AddMessages(language.Chinese, &i18n.Message{ID: "add", Other: "新增的"}))
+	assert.NoError(t, l.AddMessages(language.Chinese, &i18n.Message{ID: dataNotFoundKey.String(), Other: "添加的 找不到数据"}))
+	// 创建消息表不替换语言包 直接添加到语言包的消息仍然有效
+	c, err := NewCatalog(l, orderMessages)
+	assert.NoError(t, err)
+	assert.Equal(t, "新增的", localize(l.Bundle, "add", "zh"))
+	assert.Equal(t, "添加的 找不到数据", l.GetDataNotFoundMsg(ctx, "zh"))
+	assert.Equal(t, "订单不存在", c.Msg(ctx, orderNotFoundKey, nil, "zh"))
+	// 重新加载后 消息表与 AddMessages 的消息仍然有效
+	assert.NoError(t, l.Reload())
+	assert.Equal(t, "添加的 找不到数据", l.GetDataNotFoundMsg(ctx, "zh"))
+	assert.Equal(t, "订单不存在", c.Msg(ctx, orderNotFoundKey, nil, "zh"))
+
+	// 消息文件优先于消息表
+	dir := t.TempDir()
+	file := filepath.Join(dir, "zh.toml")
+	assert.NoError(t, os.WriteFile(file, []byte(`order_not_found = "文件 订单不存在"`), 0o600))
+	l = NewCommonLang(nil, log.DefaultLogger, WithMessageDir(dir))
+	c, err = NewCatalog(l, orderMessages)
+	assert.NoError(t, err)
+	assert.Equal(t, "文件 订单不存在", c.Msg(ctx, orderNotFoundKey, nil, "zh"))
+
+	// 初始加载失败时 消息添加到当前的语言包
+	assert.NoError(t, os.WriteFile(file, []byte("data_not_found = "), 0o600))
+	l = NewCommonLang(nil, log.DefaultLogger, WithMessageDir(dir))
+	c, err = NewCatalog(l, orderMessages)
+	assert.NoError(t, err)
+	assert.Equal(t, "订单不存在", c.Msg(ctx, orderNotFoundKey, nil, "zh"))
+}
+
+func TestCatalogInvalid(t *testing.T) {
+	l := NewCommonLang(nil, log.DefaultLogger)
+	c, err := NewCatalog(l, map[language.Tag]map[orderKey]*i18n.Message{
+		language.Chinese: {orderPaidKey: {ID: string(orderNotFoundKey), Other: "订单不存在"}},
+	})
+	assert.Error(t, err)
+	assert.Nil(t, c)
+	assert.Empty(t, localize(l.CurrentBundle(), string(orderNotFoundKey), "zh"))
+}
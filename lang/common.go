@@ -3,6 +3,9 @@ package lang
 
 import (
 	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
 
 	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
@@ -14,8 +17,21 @@ import (
 
 // CommonLang 公共语言包
 type CommonLang struct {
+	// Bundle 当前的语言包 重新加载后随之替换
+	// 开启监听或运行中重新加载时须使用 CurrentBundle 读取, 直接添加到 Bundle 的消息在重新加载后不保留
 	Bundle *i18n.Bundle
 	log    *log.Helper
+	// mu 串行加载与替换语言包 避免较早的加载结果覆盖较新的语言包
+	mu sync.Mutex
+	// dicts AddDict 添加的字典 查找时优先于配置的字典
+	dicts     *dictStore
+	dictsOnce sync.Once
+	// configDicts 配置的字典 查找时优先于内置字典, 重新加载时替换
+	configDicts atomic.Pointer[dictStore]
+	// bundle 当前的语言包 重新加载后替换
+	bundle  atomic.Pointer[i18n.Bundle]
+	loader  *Loader
+	watcher *watcher
 }
 
 // GetCommonBundle 获取公共语言包
@@ -26,12 +42,114 @@ func GetCommonBundle(langMapConfig map[string]*config.Lang) *i18n.Bundle {
 	return bundle
 }
 
-// NewCommonLang 创建公共语言包
-func NewCommonLang(langMapConfig map[string]*config.Lang, logger log.Logger) *CommonLang {
-	return &CommonLang{
-		Bundle: GetCommonBundle(langMapConfig),
+// NewCommonLang 创建公共语言包 opts 用于从目录或 fs.FS 加载消息文件
+// 消息文件加载失败时记录错误并仅使用内置消息与配置
+func NewCommonLang(langMapConfig map[string]*config.Lang, logger log.Logger, opts ...BundleOption) *CommonLang {
+	c := &CommonLang{
 		log:    log.NewHelper(log.With(logger, "layer", "commonLang")),
+		dicts:  newDictStore(),
+		loader: NewLoader(langMapConfig, append([]BundleOption{withDefaults(langMap)}, opts...)...),
 	}
+	bundle, err := c.loader.Load()
+	if err != nil {
+		c.log.Errorf("load message files: %v", err)
+		bundle = GetCommonBundle(langMapConfig)
+	}
+	c.Bundle = bundle
+	c.bundle.Store(bundle)
+	c.configDicts.Store(newConfigDicts(c.loader.getConfig()))
+	if c.loader.watch {
+		if c.watcher, err = newWatcher(c.loader.dirs(), c.reload); err != nil {
+			c.log.Errorf("watch message files: %v", err)
+		}
+	}
+	return c
+}
+
+// CurrentBundle 获取当前的语言包
+func (c *CommonLang) CurrentBundle() *i18n.Bundle {
+	return c.bundle.Load()
+}
+
+// LanguageTags 当前语言包支持的语言 第一个为默认语言 English, 可用于 NewNegotiator
+func (c *CommonLang) LanguageTags() []language.Tag {
+	return slices.Clone(c.CurrentBundle().LanguageTags())
+}
+
+// Reload 重新加载消息文件与配置的 messages、dict 失败时保留当前的语言包与字典
+func (c *CommonLang) Reload() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.load()
+}
+
+// SetConfig 替换配置的 messages、dict 并重新加载 传入的 map 被复制, 之后对其的修改不生效
+// 重新加载失败时保留当前的语言包与字典, 新的配置在下次重新加载时生效
+func (c *CommonLang) SetConfig(langMapConfig map[string]*config.Lang) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loader.setConfig(langMapConfig)
+	return c.load()
+}
+
+// load 加载并替换语言包与配置的字典 调用方须持有 mu
+func (c *CommonLang) load() error {
+	bundle, err := c.loader.Load()
+	if err != nil {
+		return err
+	}
+	c.Bundle = bundle
+	c.bundle.Store(bundle)
+	c.configDicts.Store(newConfigDicts(c.loader.getConfig()))
+	return nil
+}
+
+// AddMessages 添加消息 与业务模块的消息表相同, 在内置消息之后、消息文件之前加载, 重新加载后仍然有效
+// 消息添加到当前的语言包 须在处理请求前调用
+func (c *CommonLang) AddMessages(tag language.Tag, msgs ...*i18n.Message) error {
+	return c.addMessages(map[language.Tag][]*i18n.Message{tag: msgs})
+}
+
+// addMessages 校验后记录到加载器并添加到当前的语言包 跳过被消息文件或配置覆盖的消息
+func (c *CommonLang) addMessages(msgs map[language.Tag][]*i18n.Message) error {
+	check := i18n.NewBundle(language.English)
+	for tag, m := range msgs {
+		if err := check.AddMessages(tag, m...); err != nil {
+			return err
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loader.addCatalog(msgs)
+	bundle := c.CurrentBundle()
+	for tag, m := range msgs {
+		m = slices.DeleteFunc(slices.Clone(m), func(msg *i18n.Message) bool {
+			return c.loader.overridden(tag, msg.ID)
+		})
+		if err := bundle.AddMessages(tag, m...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *CommonLang) reload() {
+	if err := c.Reload(); err != nil {
+		c.log.Errorf("reload message files: %v", err)
+	}
+}
+
+// MissingTranslations 各语言相对英语缺少的消息 ID key 为语言
+func (c *CommonLang) MissingTranslations() map[string][]string {
+	return c.loader.Missing()
+}
+
+// Close 停止监听消息文件
+func (c *CommonLang) Close() error {
+	if c.watcher == nil {
+		return nil
+	}
+	return c.watcher.close()
 }
 
 // HandleMetadataError 处理 metadata 错误
@@ -54,9 +172,9 @@ func (c *CommonLang) HandleMetadataError(ctx context.Context, err *errors.Error,
 // GetLocalizer _
 func (c *CommonLang) getLocalizer(ctx context.Context, langs ...string) *i18n.Localizer {
 	if len(langs) == 0 {
-		return i18n.NewLocalizer(c.Bundle, GetAcceptArr(ctx)...)
+		return i18n.NewLocalizer(c.CurrentBundle(), GetAcceptArr(ctx)...)
 	}
-	return i18n.NewLocalizer(c.Bundle, langs...)
+	return i18n.NewLocalizer(c.CurrentBundle(), langs...)
 }
 
 // getMsg _
@@ -74,6 +192,23 @@ func (c *CommonLang) getMsg(ctx context.Context, key MsgKey, templateData interf
 	return v
 }
 
+// getPluralMsg 获取复数形式的消息 模板数据为 Count
+func (c *CommonLang) getPluralMsg(ctx context.Context, key MsgKey, count int, langs ...string) string {
+	localizer := c.getLocalizer(ctx, langs...)
+	templateData := map[string]interface{}{"Count": count}
+	v, err := localizer.Localize(&i18n.LocalizeConfig{
+		MessageID:      key.String(),
+		TemplateData:   templateData,
+		PluralCount:    count,
+		DefaultMessage: dfMsgMap[key],
+	})
+	if err != nil {
+		c.log.Error("GetPluralMsg", err)
+		return HandleError(key, dfMsgMap[key], templateData)
+	}
+	return v
+}
+
 // GetParameterErrorMsg 获取参数错误消息
 func (c *CommonLang) GetParameterErrorMsg(ctx context.Context, langs ...string) string {
 	return c.getMsg(ctx, parameterErrorKey, nil, langs...)
@@ -106,7 +241,7 @@ func (c *CommonLang) GetDecryptFailMsg(ctx context.Context, langs ...string) str
 
 // GetParamCanNotEmptyMsg 获取参数不能为空消息
 func (c *CommonLang) GetParamCanNotEmptyMsg(ctx context.Context, name string, langs ...string) string {
-	return c.getMsg(ctx, paramCanNotEmptyKey, map[string]string{"Name": name}, langs...)
+	return c.getMsg(ctx, paramCanNotEmptyKey, map[string]string{"Name": c.fieldLabel(ctx, name, langs...)}, langs...)
 }
 
 // GetNotEditableMsg 获取数据不可编辑消息
@@ -151,22 +286,22 @@ func (c *CommonLang) GetNoPermissionMsg(ctx context.Context, langs ...string) st
 
 // GetNotConfiguredMsg 获取未配置消息
 func (c *CommonLang) GetNotConfiguredMsg(ctx context.Context, name string, langs ...string) string {
-	return c.getMsg(ctx, notConfiguredKey, map[string]string{"Name": name}, langs...)
+	return c.getMsg(ctx, notConfiguredKey, map[string]string{"Name": c.fieldLabel(ctx, name, langs...)}, langs...)
 }
 
 // GetCanNotEmptyMsg 获取不能为空消息
 func (c *CommonLang) GetCanNotEmptyMsg(ctx context.Context, name string, langs ...string) string {
-	return c.getMsg(ctx, canNotEmptyKey, map[string]string{"Name": name}, langs...)
+	return c.getMsg(ctx, canNotEmptyKey, map[string]string{"Name": c.fieldLabel(ctx, name, langs...)}, langs...)
 }
 
 // GetExpiredMsg 获取已过期消息
 func (c *CommonLang) GetExpiredMsg(ctx context.Context, name string, langs ...string) string {
-	return c.getMsg(ctx, expiredKey, map[string]string{"Name": name}, langs...)
+	return c.getMsg(ctx, expiredKey, map[string]string{"Name": c.fieldLabel(ctx, name, langs...)}, langs...)
 }
 
 // GetDataAbnormalMsg 获取数据异常消息
 func (c *CommonLang) GetDataAbnormalMsg(ctx context.Context, name string, langs ...string) string {
-	return c.getMsg(ctx, dataAbnormalKey, map[string]string{"Name": name}, langs...)
+	return c.getMsg(ctx, dataAbnormalKey, map[string]string{"Name": c.fieldLabel(ctx, name, langs...)}, langs...)
 }
 
 // GetDataFoundMsg 获取数据未找到消息
@@ -204,6 +339,11 @@ func (c *CommonLang) GetDataErrorMsg(ctx context.Context, langs ...string) strin
 	return c.getMsg(ctx, dataErrorKey, nil, langs...)
 }
 
+// GetDataConflictMsg 获取数据冲突消息 用于乐观锁版本不一致等并发修改的场景
+func (c *CommonLang) GetDataConflictMsg(ctx context.Context, langs ...string) string {
+	return c.getMsg(ctx, dataConflictKey, nil, langs...)
+}
+
 // GetCacheNotFoundMsg 获取缓存未找到消息
 func (c *CommonLang) GetCacheNotFoundMsg(ctx context.Context, langs ...string) string {
 	return c.getMsg(ctx, cacheNotFoundKey, nil, langs...)
@@ -248,3 +388,8 @@ func (c *CommonLang) GetCacheMGetFailMsg(ctx context.Context, langs ...string) s
 func (c *CommonLang) GetCacheMDelFailMsg(ctx context.Context, langs ...string) string {
 	return c.getMsg(ctx, cacheMDelFailKey, nil, langs...)
 }
+
+// GetReplyValidationErrorMsg 获取响应数据验证错误消息
+func (c *CommonLang) GetReplyValidationErrorMsg(ctx context.Context, langs ...string) string {
+	return c.getMsg(ctx, replyValidationErrorKey, nil, langs...)
+}
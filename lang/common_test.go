@@ -325,3 +325,9 @@ func TestGetCacheMDelFailMsg(t *testing.T) {
 	actual = l.GetCacheMDelFailMsg(context.Background(), lang)
 	assert.Equal(t, expected, actual)
 }
+
+func TestGetDataConflictMsg(t *testing.T) {
+	l := NewCommonLang(nil, log.DefaultLogger)
+	assert.Equal(t, "The data has been modified by others, please refresh and try again", l.GetDataConflictMsg(context.Background(), "en-US"))
+	assert.Equal(t, "数据已被他人修改，请刷新后重试", l.GetDataConflictMsg(context.Background(), "zh-CN"))
+}
@@ -68,14 +68,16 @@ const (
 	expiredKey                   MsgKey = "expired"                      // 已过期
 
 	// 数据相关错误消息键
-	dataAbnormalKey        MsgKey = "data_abnormal"         // 数据异常
-	dataNotFoundKey        MsgKey = "data_not_found"        // 数据未找到
-	dataDuplicateKey       MsgKey = "data_duplicate"        // 数据重复
-	dataConstraintKey      MsgKey = "data_constraint"       // 数据约束错误
-	dataNotLoadedKey       MsgKey = "data_not_loaded"       // 数据未加载
-	dataNotSingularKey     MsgKey = "data_not_singular"     // 数据非唯一
-	dataValidationErrorKey MsgKey = "data_validation_error" // 数据验证错误
-	dataErrorKey           MsgKey = "data_error"            // 数据错误
+	dataAbnormalKey         MsgKey = "data_abnormal"          // 数据异常
+	dataNotFoundKey         MsgKey = "data_not_found"         // 数据未找到
+	dataDuplicateKey        MsgKey = "data_duplicate"         // 数据重复
+	dataConstraintKey       MsgKey = "data_constraint"        // 数据约束错误
+	dataNotLoadedKey        MsgKey = "data_not_loaded"        // 数据未加载
+	dataNotSingularKey      MsgKey = "data_not_singular"      // 数据非唯一
+	dataValidationErrorKey  MsgKey = "data_validation_error"  // 数据验证错误
+	replyValidationErrorKey MsgKey = "reply_validation_error" // 响应数据验证错误
+	dataErrorKey            MsgKey = "data_error"             // 数据错误
+	dataConflictKey         MsgKey = "data_conflict"          // 数据冲突
 
 	// 缓存相关错误消息键
 	cacheNotFoundKey        MsgKey = "cache_not_found"          // 缓存未找到
@@ -88,6 +90,19 @@ const (
 	cacheMGetFailKey        MsgKey = "cache_mget_fail"          // 缓存批量获取失败
 	cacheMDelFailKey        MsgKey = "cache_mdel_fail"          // 缓存批量删除失败
 
+	// 相对时间消息键 Count 为数量
+	relativeNowKey          MsgKey = "relative_now"           // 刚刚
+	relativeMinutesAgoKey   MsgKey = "relative_minutes_ago"   // 几分钟前
+	relativeMinutesLaterKey MsgKey = "relative_minutes_later" // 几分钟后
+	relativeHoursAgoKey     MsgKey = "relative_hours_ago"     // 几小时前
+	relativeHoursLaterKey   MsgKey = "relative_hours_later"   // 几小时后
+	relativeDaysAgoKey      MsgKey = "relative_days_ago"      // 几天前
+	relativeDaysLaterKey    MsgKey = "relative_days_later"    // 几天后
+	relativeMonthsAgoKey    MsgKey = "relative_months_ago"    // 几个月前
+	relativeMonthsLaterKey  MsgKey = "relative_months_later"  // 几个月后
+	relativeYearsAgoKey     MsgKey = "relative_years_ago"     // 几年前
+	relativeYearsLaterKey   MsgKey = "relative_years_later"   // 几年后
+
 	// 必填
 	validateRequiredKey MsgKey = validatePrefix + "required" // 必填验证
 	// 字符串验证消息键
@@ -124,6 +139,13 @@ const (
 	validateStringStrictKey            MsgKey = validatePrefix + "string.strict"              // 严格模式验证
 	validateStringExampleKey           MsgKey = validatePrefix + "string.example"             // 示例值验证
 
+	// 自定义 CEL 规则 见 validation 包
+	validateStringCNMobileKey    MsgKey = validatePrefix + "string.cn_mobile"    // 中国大陆手机号验证
+	validateStringCNIDCardKey    MsgKey = validatePrefix + "string.cn_id_card"   // 中国居民身份证号验证
+	validateStringCNUSCCKey      MsgKey = validatePrefix + "string.cn_uscc"      // 统一社会信用代码验证
+	validateStringBankCardKey    MsgKey = validatePrefix + "string.bank_card"    // 银行卡号 长度与 Luhn 校验
+	validateStringSonyflakeIDKey MsgKey = validatePrefix + "string.sonyflake_id" // Sonyflake ID 格式验证
+
 	// 布尔值验证消息键
 	validateBoolConstKey MsgKey = validatePrefix + "bool.const" // 布尔常量验证
 
@@ -323,14 +345,16 @@ var dfMsgMap = map[MsgKey]*i18n.Message{
 	canNotEmptyKey:          {ID: canNotEmptyKey.String(), Other: "{{.Name}} can not be empty"},
 	expiredKey:              {ID: expiredKey.String(), Other: "{{.Name}} has expired"},
 
-	dataAbnormalKey:        {ID: dataAbnormalKey.String(), Other: "{{.Name}} data abnormal"},
-	dataNotFoundKey:        {ID: dataNotFoundKey.String(), Other: "Data not found"},
-	dataDuplicateKey:       {ID: dataDuplicateKey.String(), Other: "The data already exists, please do not add it repeatedly"},
-	dataConstraintKey:      {ID: dataConstraintKey.String(), Other: "Data constraint check failed, please check your parameters"},
-	dataNotLoadedKey:       {ID: dataNotLoadedKey.String(), Other: "Database not loaded, please contact the administrator"},
-	dataNotSingularKey:     {ID: dataNotSingularKey.String(), Other: "Data error Not Singular, please contact the administrator"},
-	dataValidationErrorKey: {ID: dataValidationErrorKey.String(), Other: "Data validation failed, please check your parameters"},
-	dataErrorKey:           {ID: dataErrorKey.String(), Other: "Data layer error, please contact the administrator"},
+	dataAbnormalKey:         {ID: dataAbnormalKey.String(), Other: "{{.Name}} data abnormal"},
+	dataNotFoundKey:         {ID: dataNotFoundKey.String(), Other: "Data not found"},
+	dataDuplicateKey:        {ID: dataDuplicateKey.String(), Other: "The data already exists, please do not add it repeatedly"},
+	dataConstraintKey:       {ID: dataConstraintKey.String(), Other: "Data constraint check failed, please check your parameters"},
+	dataNotLoadedKey:        {ID: dataNotLoadedKey.String(), Other: "Database not loaded, please contact the administrator"},
+	dataNotSingularKey:      {ID: dataNotSingularKey.String(), Other: "Data error Not Singular, please contact the administrator"},
+	dataValidationErrorKey:  {ID: dataValidationErrorKey.String(), Other: "Data validation failed, please check your parameters"},
+	replyValidationErrorKey: {ID: replyValidationErrorKey.String(), Other: "Response data is abnormal, please try again later"},
+	dataErrorKey:            {ID: dataErrorKey.String(), Other: "Data layer error, please contact the administrator"},
+	dataConflictKey:         {ID: dataConflictKey.String(), Other: "The data has been modified by others, please refresh and try again"},
 
 	cacheNotFoundKey:        {ID: cacheNotFoundKey.String(), Other: "Cache not found"},
 	cachePreMatchGetFailKey: {ID: cachePreMatchGetFailKey.String(), Other: "Pre-match cache get failed"},
@@ -342,6 +366,18 @@ var dfMsgMap = map[MsgKey]*i18n.Message{
 	cacheMGetFailKey:        {ID: cacheMGetFailKey.String(), Other: "Batch get cache failed"},
 	cacheMDelFailKey:        {ID: cacheMDelFailKey.String(), Other: "Batch delete cache failed"},
 
+	relativeNowKey:          {ID: relativeNowKey.String(), Other: "just now"},
+	relativeMinutesAgoKey:   {ID: relativeMinutesAgoKey.String(), One: "{{.Count}} minute ago", Other: "{{.Count}} minutes ago"},
+	relativeMinutesLaterKey: {ID: relativeMinutesLaterKey.String(), One: "in {{.Count}} minute", Other: "in {{.Count}} minutes"},
+	relativeHoursAgoKey:     {ID: relativeHoursAgoKey.String(), One: "{{.Count}} hour ago", Other: "{{.Count}} hours ago"},
+	relativeHoursLaterKey:   {ID: relativeHoursLaterKey.String(), One: "in {{.Count}} hour", Other: "in {{.Count}} hours"},
+	relativeDaysAgoKey:      {ID: relativeDaysAgoKey.String(), One: "{{.Count}} day ago", Other: "{{.Count}} days ago"},
+	relativeDaysLaterKey:    {ID: relativeDaysLaterKey.String(), One: "in {{.Count}} day", Other: "in {{.Count}} days"},
+	relativeMonthsAgoKey:    {ID: relativeMonthsAgoKey.String(), One: "{{.Count}} month ago", Other: "{{.Count}} months ago"},
+	relativeMonthsLaterKey:  {ID: relativeMonthsLaterKey.String(), One: "in {{.Count}} month", Other: "in {{.Count}} months"},
+	relativeYearsAgoKey:     {ID: relativeYearsAgoKey.String(), One: "{{.Count}} year ago", Other: "{{.Count}} years ago"},
+	relativeYearsLaterKey:   {ID: relativeYearsLaterKey.String(), One: "in {{.Count}} year", Other: "in {{.Count}} years"},
+
 	validateRequiredKey: {ID: validateRequiredKey.String(), Other: "This field is required"},
 	// String validation messages
 	validateStringConstKey:             {ID: validateStringConstKey.String(), Other: "Must be exactly '{{.Rule}}'"},
@@ -376,6 +412,11 @@ var dfMsgMap = map[MsgKey]*i18n.Message{
 	validateStringIPv6WithPrefixlenKey: {ID: validateStringIPv6WithPrefixlenKey.String(), Other: "Must be a valid IPv6 address with prefix length"},
 	validateStringStrictKey:            {ID: validateStringStrictKey.String(), Other: "Must match strict validation rules"},
 	validateStringExampleKey:           {ID: validateStringExampleKey.String(), Other: "Must match the example format: {{.Rule}}"},
+	validateStringCNMobileKey:          {ID: validateStringCNMobileKey.String(), Other: "Must be a valid mobile phone number"},
+	validateStringCNIDCardKey:          {ID: validateStringCNIDCardKey.String(), Other: "Must be a valid ID card number"},
+	validateStringCNUSCCKey:            {ID: validateStringCNUSCCKey.String(), Other: "Must be a valid unified social credit code"},
+	validateStringBankCardKey:          {ID: validateStringBankCardKey.String(), Other: "Must be a valid bank card number"},
+	validateStringSonyflakeIDKey:       {ID: validateStringSonyflakeIDKey.String(), Other: "Must be a valid ID"},
 
 	// Boolean validation messages
 	validateBoolConstKey: {ID: validateBoolConstKey.String(), Other: "Must be {{.Rule}}"},
@@ -572,14 +613,16 @@ var zhMsgMap = map[MsgKey]*i18n.Message{
 	canNotEmptyKey:               {ID: canNotEmptyKey.String(), Other: "{{.Name}} 不能为空"},
 	expiredKey:                   {ID: expiredKey.String(), Other: "{{.Name}} 已过期"},
 
-	dataAbnormalKey:        {ID: dataAbnormalKey.String(), Other: "{{.Name}} 数据异常"},
-	dataNotFoundKey:        {ID: dataNotFoundKey.String(), Other: "找不到数据"},
-	dataDuplicateKey:       {ID: dataDuplicateKey.String(), Other: "该数据已存在,请勿重复添加"},
-	dataConstraintKey:      {ID: dataConstraintKey.String(), Other: "数据约束检查失败，请检查您的参数"},
-	dataNotLoadedKey:       {ID: dataNotLoadedKey.String(), Other: "数据库未加载，请联系管理员"},
-	dataNotSingularKey:     {ID: dataNotSingularKey.String(), Other: "数据出错了 Not Singular,请联系管理员"},
-	dataValidationErrorKey: {ID: dataValidationErrorKey.String(), Other: "数据校验失败，请检查您的参数"},
-	dataErrorKey:           {ID: dataErrorKey.String(), Other: "数据层出错了,请联系管理员"},
+	dataAbnormalKey:         {ID: dataAbnormalKey.String(), Other: "{{.Name}} 数据异常"},
+	dataNotFoundKey:         {ID: dataNotFoundKey.String(), Other: "找不到数据"},
+	dataDuplicateKey:        {ID: dataDuplicateKey.String(), Other: "该数据已存在,请勿重复添加"},
+	dataConstraintKey:       {ID: dataConstraintKey.String(), Other: "数据约束检查失败，请检查您的参数"},
+	dataNotLoadedKey:        {ID: dataNotLoadedKey.String(), Other: "数据库未加载，请联系管理员"},
+	dataNotSingularKey:      {ID: dataNotSingularKey.String(), Other: "数据出错了 Not Singular,请联系管理员"},
+	dataValidationErrorKey:  {ID: dataValidationErrorKey.String(), Other: "数据校验失败，请检查您的参数"},
+	replyValidationErrorKey: {ID: replyValidationErrorKey.String(), Other: "响应数据异常，请稍后重试"},
+	dataErrorKey:            {ID: dataErrorKey.String(), Other: "数据层出错了,请联系管理员"},
+	dataConflictKey:         {ID: dataConflictKey.String(), Other: "数据已被他人修改，请刷新后重试"},
 
 	cacheNotFoundKey:        {ID: cacheNotFoundKey.String(), Other: "缓存不存在"},
 	cachePreMatchGetFailKey: {ID: cachePreMatchGetFailKey.String(), Other: "前置匹配获取缓存失败"},
@@ -591,6 +634,18 @@ var zhMsgMap = map[MsgKey]*i18n.Message{
 	cacheMGetFailKey:        {ID: cacheMGetFailKey.String(), Other: "批量获取缓存失败"},
 	cacheMDelFailKey:        {ID: cacheMDelFailKey.String(), Other: "批量删除缓存失败"},
 
+	relativeNowKey:          {ID: relativeNowKey.String(), Other: "刚刚"},
+	relativeMinutesAgoKey:   {ID: relativeMinutesAgoKey.String(), Other: "{{.Count}} 分钟前"},
+	relativeMinutesLaterKey: {ID: relativeMinutesLaterKey.String(), Other: "{{.Count}} 分钟后"},
+	relativeHoursAgoKey:     {ID: relativeHoursAgoKey.String(), Other: "{{.Count}} 小时前"},
+	relativeHoursLaterKey:   {ID: relativeHoursLaterKey.String(), Other: "{{.Count}} 小时后"},
+	relativeDaysAgoKey:      {ID: relativeDaysAgoKey.String(), Other: "{{.Count}} 天前"},
+	relativeDaysLaterKey:    {ID: relativeDaysLaterKey.String(), Other: "{{.Count}} 天后"},
+	relativeMonthsAgoKey:    {ID: relativeMonthsAgoKey.String(), Other: "{{.Count}} 个月前"},
+	relativeMonthsLaterKey:  {ID: relativeMonthsLaterKey.String(), Other: "{{.Count}} 个月后"},
+	relativeYearsAgoKey:     {ID: relativeYearsAgoKey.String(), Other: "{{.Count}} 年前"},
+	relativeYearsLaterKey:   {ID: relativeYearsLaterKey.String(), Other: "{{.Count}} 年后"},
+
 	validateRequiredKey: {ID: validateRequiredKey.String(), Other: "该字段是必填的"},
 	// String validation messages
 	validateStringConstKey:             {ID: validateStringConstKey.String(), Other: "必须等于 '{{.Rule}}'"},
@@ -625,6 +680,11 @@ var zhMsgMap = map[MsgKey]*i18n.Message{
 	validateStringIPv6WithPrefixlenKey: {ID: validateStringIPv6WithPrefixlenKey.String(), Other: "必须是带有前缀长度的有效IPv6地址"},
 	validateStringStrictKey:            {ID: validateStringStrictKey.String(), Other: "必须符合严格验证规则"},
 	validateStringExampleKey:           {ID: validateStringExampleKey.String(), Other: "必须匹配示例格式: {{.Rule}}"},
+	validateStringCNMobileKey:          {ID: validateStringCNMobileKey.String(), Other: "必须是有效的手机号"},
+	validateStringCNIDCardKey:          {ID: validateStringCNIDCardKey.String(), Other: "必须是有效的身份证号"},
+	validateStringCNUSCCKey:            {ID: validateStringCNUSCCKey.String(), Other: "必须是有效的统一社会信用代码"},
+	validateStringBankCardKey:          {ID: validateStringBankCardKey.String(), Other: "必须是有效的银行卡号"},
+	validateStringSonyflakeIDKey:       {ID: validateStringSonyflakeIDKey.String(), Other: "必须是有效的ID"},
 
 	// Boolean validation messages
 	validateBoolConstKey: {ID: validateBoolConstKey.String(), Other: "必须为 {{.Rule}}"},
@@ -831,7 +891,9 @@ var ruMsgMap = map[MsgKey]*i18n.Message{
 	dataNotLoadedKey:        {ID: dataNotLoadedKey.String(), Other: "База данных не загружена, пожалуйста, свяжитесь с администратором"},
 	dataNotSingularKey:      {ID: dataNotSingularKey.String(), Other: "Ошибка данных Not Singular, пожалуйста, свяжитесь с администратором"},
 	dataValidationErrorKey:  {ID: dataValidationErrorKey.String(), Other: "Ошибка проверки данных, пожалуйста, проверьте ваши параметры"},
+	replyValidationErrorKey: {ID: replyValidationErrorKey.String(), Other: "Данные ответа некорректны, повторите попытку позже"},
 	dataErrorKey:            {ID: dataErrorKey.String(), Other: "Ошибка слоя данных, пожалуйста, свяжитесь с администратором"},
+	dataConflictKey:         {ID: dataConflictKey.String(), Other: "Данные были изменены другим пользователем, пожалуйста, обновите и повторите попытку"},
 	cacheNotFoundKey:        {ID: cacheNotFoundKey.String(), Other: "Кэш не найден"},
 	cachePreMatchGetFailKey: {ID: cachePreMatchGetFailKey.String(), Other: "Предварительное сопоставление получения кэша не удалось"},
 	cacheSetFailKey:         {ID: cacheSetFailKey.String(), Other: "Ошибка установки кэша"},
@@ -842,6 +904,18 @@ var ruMsgMap = map[MsgKey]*i18n.Message{
 	cacheMGetFailKey:        {ID: cacheMGetFailKey.String(), Other: "Ошибка получения кэша"},
 	cacheMDelFailKey:        {ID: cacheMDelFailKey.String(), Other: "Ошибка удаления кэша"},
 
+	relativeNowKey:          {ID: relativeNowKey.String(), Other: "только что"},
+	relativeMinutesAgoKey:   {ID: relativeMinutesAgoKey.String(), One: "{{.Count}} минуту назад", Few: "{{.Count}} минуты назад", Many: "{{.Count}} минут назад", Other: "{{.Count}} минуты назад"},
+	relativeMinutesLaterKey: {ID: relativeMinutesLaterKey.String(), One: "через {{.Count}} минуту", Few: "через {{.Count}} минуты", Many: "через {{.Count}} минут", Other: "через {{.Count}} минуты"},
+	relativeHoursAgoKey:     {ID: relativeHoursAgoKey.String(), One: "{{.Count}} час назад", Few: "{{.Count}} часа назад", Many: "{{.Count}} часов назад", Other: "{{.Count}} часа назад"},
+	relativeHoursLaterKey:   {ID: relativeHoursLaterKey.String(), One: "через {{.Count}} час", Few: "через {{.Count}} часа", Many: "через {{.Count}} часов", Other: "через {{.Count}} часа"},
+	relativeDaysAgoKey:      {ID: relativeDaysAgoKey.String(), One: "{{.Count}} день назад", Few: "{{.Count}} дня назад", Many: "{{.Count}} дней назад", Other: "{{.Count}} дня назад"},
+	relativeDaysLaterKey:    {ID: relativeDaysLaterKey.String(), One: "через {{.Count}} день", Few: "через {{.Count}} дня", Many: "через {{.Count}} дней", Other: "через {{.Count}} дня"},
+	relativeMonthsAgoKey:    {ID: relativeMonthsAgoKey.String(), One: "{{.Count}} месяц назад", Few: "{{.Count}} месяца назад", Many: "{{.Count}} месяцев назад", Other: "{{.Count}} месяца назад"},
+	relativeMonthsLaterKey:  {ID: relativeMonthsLaterKey.String(), One: "через {{.Count}} месяц", Few: "через {{.Count}} месяца", Many: "через {{.Count}} месяцев", Other: "через {{.Count}} месяца"},
+	relativeYearsAgoKey:     {ID: relativeYearsAgoKey.String(), One: "{{.Count}} год назад", Few: "{{.Count}} года назад", Many: "{{.Count}} лет назад", Other: "{{.Count}} года назад"},
+	relativeYearsLaterKey:   {ID: relativeYearsLaterKey.String(), One: "через {{.Count}} год", Few: "через {{.Count}} года", Many: "через {{.Count}} лет", Other: "через {{.Count}} года"},
+
 	validateRequiredKey: {ID: validateRequiredKey.String(), Other: "Это поле обязательное"},
 	// String validation messages
 	validateStringConstKey:             {ID: validateStringConstKey.String(), Other: "Должно быть равно '{{.Rule}}'"},
@@ -876,6 +950,11 @@ var ruMsgMap = map[MsgKey]*i18n.Message{
 	validateStringIPv6WithPrefixlenKey: {ID: validateStringIPv6WithPrefixlenKey.String(), Other: "Должно быть действительным IPv6 адресом с длиной префикса"},
 	validateStringStrictKey:            {ID: validateStringStrictKey.String(), Other: "Должно соответствовать строгим правилам проверки"},
 	validateStringExampleKey:           {ID: validateStringExampleKey.String(), Other: "Должно соответствовать формату примера: {{.Rule}}"},
+	validateStringCNMobileKey:          {ID: validateStringCNMobileKey.String(), Other: "Должно быть действительным номером мобильного телефона"},
+	validateStringCNIDCardKey:          {ID: validateStringCNIDCardKey.String(), Other: "Должно быть действительным номером удостоверения личности"},
+	validateStringCNUSCCKey:            {ID: validateStringCNUSCCKey.String(), Other: "Должно быть действительным единым кодом социального кредита"},
+	validateStringBankCardKey:          {ID: validateStringBankCardKey.String(), Other: "Должно быть действительным номером банковской карты"},
+	validateStringSonyflakeIDKey:       {ID: validateStringSonyflakeIDKey.String(), Other: "Должно быть действительным ID"},
 
 	// Boolean validation messages
 	validateBoolConstKey: {ID: validateBoolConstKey.String(), Other: "Должно быть {{.Rule}}"},
@@ -1082,7 +1161,9 @@ var frMsgMap = map[MsgKey]*i18n.Message{
 	dataNotLoadedKey:        {ID: dataNotLoadedKey.String(), Other: "Base de données non chargée, veuillez contacter l'administrateur"},
 	dataNotSingularKey:      {ID: dataNotSingularKey.String(), Other: "Erreur de données Not Singular, veuillez contacter l'administrateur"},
 	dataValidationErrorKey:  {ID: dataValidationErrorKey.String(), Other: "Échec de la validation des données, veuillez vérifier vos paramètres"},
+	replyValidationErrorKey: {ID: replyValidationErrorKey.String(), Other: "Les données de la réponse sont anormales, veuillez réessayer plus tard"},
 	dataErrorKey:            {ID: dataErrorKey.String(), Other: "Erreur de couche de données, veuillez contacter l'administrateur"},
+	dataConflictKey:         {ID: dataConflictKey.String(), Other: "Les données ont été modifiées par quelqu'un d'autre, veuillez actualiser et réessayer"},
 	cacheNotFoundKey:        {ID: cacheNotFoundKey.String(), Other: "Cache introuvable"},
 	cachePreMatchGetFailKey: {ID: cachePreMatchGetFailKey.String(), Other: "Échec de la récupération de la pré-correspondance du cache"},
 	cacheSetFailKey:         {ID: cacheSetFailKey.String(), Other: "Échec de la définition du cache"},
@@ -1093,6 +1174,19 @@ var frMsgMap = map[MsgKey]*i18n.Message{
 	cacheMGetFailKey:        {ID: cacheMGetFailKey.String(), Other: "Échec de la récupération en masse du cache"},
 	cacheMDelFailKey:        {ID: cacheMDelFailKey.String(), Other: "Échec de la suppression en masse du cache"},
 
+	relativeNowKey:          {ID: relativeNowKey.String(), Other: "à l'instant"},
+	relativeMinutesAgoKey:   {ID: relativeMinutesAgoKey.String(), One: "il y a {{.Count}} minute", Other: "il y a {{.Count}} minutes"},
+	relativeMinutesLaterKey: {ID: relativeMinutesLaterKey.String(), One: "dans {{.Count}} minute", Other: "dans {{.Count}} minutes"},
+	relativeHoursAgoKey:     {ID: relativeHoursAgoKey.String(), One: "il y a {{.Count}} heure", Other: "il y a {{.Count}} heures"},
+	relativeHoursLaterKey:   {ID: relativeHoursLaterKey.String(), One: "dans {{.Count}} heure", Other: "dans {{.Count}} heures"},
+	relativeDaysAgoKey:      {ID: relativeDaysAgoKey.String(), One: "il y a {{.Count}} jour", Other: "il y a {{.Count}} jours"},
+	relativeDaysLaterKey:    {ID: relativeDaysLaterKey.String(), One: "dans {{.Count}} jour", Other: "dans {{.Count}} jours"},
+	relativeMonthsAgoKey:    {ID: relativeMonthsAgoKey.String(), One: "il y a {{.Count}} mois", Other: "il y a {{.Count}} mois"},
+	relativeMonthsLaterKey:  {ID: relativeMonthsLaterKey.String(), One: "dans {{.Count}} mois", Other: "dans {{.Count}} mois"},
+	relativeYearsAgoKey:     {ID: relativeYearsAgoKey.String(), One: "il y a {{.Count}} an", Other: "il y a {{.Count}} ans"},
+	relativeYearsLaterKey:   {ID: relativeYearsLaterKey.String(), One: "dans {{.Count}} an", Other: "dans {{.Count}} ans"},
+
+	validateRequiredKey: {ID: validateRequiredKey.String(), Other: "Ce champ est obligatoire"},
 	// String validation messages
 	validateStringConstKey:             {ID: validateStringConstKey.String(), Other: "Doit être exactement '{{.Rule}}'"},
 	validateStringLenKey:               {ID: validateStringLenKey.String(), Other: "La longueur doit être exactement de {{.Rule}} caractères"},
@@ -1126,6 +1220,11 @@ var frMsgMap = map[MsgKey]*i18n.Message{
 	validateStringIPv6WithPrefixlenKey: {ID: validateStringIPv6WithPrefixlenKey.String(), Other: "Doit être une adresse IPv6 valide avec une longueur de préfixe"},
 	validateStringStrictKey:            {ID: validateStringStrictKey.String(), Other: "Doit respecter les règles de validation strictes"},
 	validateStringExampleKey:           {ID: validateStringExampleKey.String(), Other: "Doit correspondre au format d'exemple: {{.Rule}}"},
+	validateStringCNMobileKey:          {ID: validateStringCNMobileKey.String(), Other: "Doit être un numéro de téléphone mobile valide"},
+	validateStringCNIDCardKey:          {ID: validateStringCNIDCardKey.String(), Other: "Doit être un numéro de carte d'identité valide"},
+	validateStringCNUSCCKey:            {ID: validateStringCNUSCCKey.String(), Other: "Doit être un code de crédit social unifié valide"},
+	validateStringBankCardKey:          {ID: validateStringBankCardKey.String(), Other: "Doit être un numéro de carte bancaire valide"},
+	validateStringSonyflakeIDKey:       {ID: validateStringSonyflakeIDKey.String(), Other: "Doit être un ID valide"},
 
 	// Boolean validation messages
 	validateBoolConstKey: {ID: validateBoolConstKey.String(), Other: "Doit être {{.Rule}}"},
@@ -0,0 +1,244 @@
+package lang
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/yimoka/go/config"
+	"golang.org/x/text/language"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FieldDictNamespace 字段名称字典的命名空间 key 为字段名或字段全名
+const FieldDictNamespace = "field"
+
+// fieldPrefix 消息文件中字段名称的消息前缀 如 field_user.v1.AddRequest.name、field_name
+const fieldPrefix MsgKey = "field_"
+
+// dictStore 字典 语言 -> 命名空间 -> key -> 值
+type dictStore struct {
+	mu   sync.RWMutex
+	data map[language.Tag]map[string]map[string]string
+}
+
+func newDictStore() *dictStore {
+	return &dictStore{data: map[language.Tag]map[string]map[string]string{}}
+}
+
+// add 合并字典 同 key 覆盖
+func (s *dictStore) add(tag language.Tag, namespace string, data map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	namespaces, ok := s.data[tag]
+	if !ok {
+		namespaces = map[string]map[string]string{}
+		s.data[tag] = namespaces
+	}
+	dict, ok := namespaces[namespace]
+	if !ok {
+		dict = make(map[string]string, len(data))
+		namespaces[namespace] = dict
+	}
+	for k, v := range data {
+		dict[k] = v
+	}
+}
+
+func (s *dictStore) get(tag language.Tag, namespace, key string) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.data[tag][namespace][key]
+	return v, ok
+}
+
+// copyTo 将命名空间的字典复制到 dst 已存在的 key 不覆盖 返回是否存在该命名空间
+func (s *dictStore) copyTo(dst map[string]string, tag language.Tag, namespace string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	dict, ok := s.data[tag][namespace]
+	for k, v := range dict {
+		if _, exists := dst[k]; !exists {
+			dst[k] = v
+		}
+	}
+	return ok
+}
+
+// defaultDicts 内置的字典 由各包在 init 中通过 RegisterDict 注册
+var defaultDicts = newDictStore()
+
+// RegisterDict 注册内置字典 可被配置的 dict 覆盖 应在 init 中调用
+func RegisterDict(tag language.Tag, namespace string, data map[string]string) {
+	defaultDicts.add(tag, namespace, data)
+}
+
+// dictTags 按语言的优先级返回查找字典的语言 包括各语言的父级、基础语言, 最后为默认语言 English
+func dictTags(langs []string) []language.Tag {
+	tags := []language.Tag{}
+	seen := map[language.Tag]bool{}
+	push := func(t language.Tag) {
+		if !seen[t] {
+			seen[t] = true
+			tags = append(tags, t)
+		}
+	}
+	for _, l := range langs {
+		desired, _, err := language.ParseAcceptLanguage(l)
+		if err != nil {
+			continue
+		}
+		for _, t := range desired {
+			for p := t; p != language.Und; p = p.Parent() {
+				push(p)
+			}
+			if base, conf := t.Base(); conf != language.No {
+				push(language.Make(base.String()))
+			}
+		}
+	}
+	push(language.English)
+	return tags
+}
+
+// GetDefaultDict 获取内置字典的值 langs 为空时使用默认语言
+func GetDefaultDict(namespace, key string, langs ...string) (string, bool) {
+	for _, tag := range dictTags(langs) {
+		if v, ok := defaultDicts.get(tag, namespace, key); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// GetDefaultDictData 获取内置字典的命名空间 各 key 按语言的优先级合并
+func GetDefaultDictData(namespace string, langs ...string) (map[string]string, bool) {
+	data := map[string]string{}
+	found := false
+	for _, tag := range dictTags(langs) {
+		found = defaultDicts.copyTo(data, tag, namespace) || found
+	}
+	return data, found
+}
+
+// newConfigDicts 从配置加载字典
+func newConfigDicts(langMap map[string]*config.Lang) *dictStore {
+	s := newDictStore()
+	for key, l := range langMap {
+		tag, err := language.Parse(key)
+		if err != nil {
+			continue
+		}
+		for namespace, d := range l.GetDict() {
+			s.add(tag, namespace, d.GetData())
+		}
+	}
+	return s
+}
+
+// getDicts AddDict 添加的字典 未通过 NewCommonLang 创建时初始化为空字典
+func (c *CommonLang) getDicts() *dictStore {
+	c.dictsOnce.Do(func() {
+		if c.dicts == nil {
+			c.dicts = newDictStore()
+		}
+	})
+	return c.dicts
+}
+
+// AddDict 添加字典 同 key 覆盖内置字典与配置
+func (c *CommonLang) AddDict(tag language.Tag, namespace string, data map[string]string) {
+	c.getDicts().add(tag, namespace, data)
+}
+
+// getLangs 未指定语言时使用请求的语言
+func getLangs(ctx context.Context, langs []string) []string {
+	if len(langs) == 0 {
+		return GetAcceptArr(ctx)
+	}
+	return langs
+}
+
+// GetDict 获取字典的值 语言的解析与消息相同, 优先级为 AddDict 添加的字典 > 配置的字典 > 内置字典
+func (c *CommonLang) GetDict(ctx context.Context, namespace, key string, langs ...string) (string, bool) {
+	configDicts := c.configDicts.Load()
+	for _, tag := range dictTags(getLangs(ctx, langs)) {
+		if v, ok := c.getDicts().get(tag, namespace, key); ok {
+			return v, true
+		}
+		if v, ok := configDicts.get(tag, namespace, key); ok {
+			return v, true
+		}
+		if v, ok := defaultDicts.get(tag, namespace, key); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// GetDictData 获取字典的命名空间 各 key 按语言的优先级合并
+func (c *CommonLang) GetDictData(ctx context.Context, namespace string, langs ...string) (map[string]string, bool) {
+	data := map[string]string{}
+	found := false
+	configDicts := c.configDicts.Load()
+	for _, tag := range dictTags(getLangs(ctx, langs)) {
+		found = c.getDicts().copyTo(data, tag, namespace) || found
+		found = configDicts.copyTo(data, tag, namespace) || found
+		found = defaultDicts.copyTo(data, tag, namespace) || found
+	}
+	return data, found
+}
+
+// GetFieldLabel 获取字段的本地化名称 依次查找字典的 field 命名空间与消息文件中以 field_ 前缀声明的消息(如 field_name)
+// 未配置时返回字段名
+func (c *CommonLang) GetFieldLabel(ctx context.Context, name string, langs ...string) (string, bool) {
+	if name == "" {
+		return "", false
+	}
+	if v, ok := c.GetDict(ctx, FieldDictNamespace, name, langs...); ok && v != "" {
+		return v, true
+	}
+	if c.CurrentBundle() == nil {
+		return name, false
+	}
+	v, err := c.getLocalizer(ctx, langs...).Localize(&i18n.LocalizeConfig{MessageID: (fieldPrefix + MsgKey(name)).String()})
+	if err != nil || v == "" {
+		return name, false
+	}
+	return v, true
+}
+
+// AddFieldLabels 添加字段名称字典 key 为字段名或字段全名 同 key 覆盖配置的字段名称
+func (c *CommonLang) AddFieldLabels(tag language.Tag, labels map[string]string) error {
+	if tag == language.Und {
+		return fmt.Errorf("lang: field labels require a language tag")
+	}
+	c.AddDict(tag, FieldDictNamespace, labels)
+	return nil
+}
+
+// fieldLabel 错误消息中的名称 已配置字段名称时使用本地化名称
+func (c *CommonLang) fieldLabel(ctx context.Context, name string, langs ...string) string {
+	label, _ := c.GetFieldLabel(ctx, name, langs...)
+	return label
+}
+
+// GetEnumLabel 获取枚举值的本地化名称 字典的命名空间为枚举全名(如 user.v1.Status), key 为枚举值的名称 未配置时返回枚举值的名称
+func (c *CommonLang) GetEnumLabel(ctx context.Context, enum protoreflect.EnumDescriptor, number protoreflect.EnumNumber, langs ...string) string {
+	value := enum.Values().ByNumber(number)
+	if value == nil {
+		return strconv.Itoa(int(number))
+	}
+	if v, ok := c.GetDict(ctx, string(enum.FullName()), string(value.Name()), langs...); ok && v != "" {
+		return v
+	}
+	return string(value.Name())
+}
@@ -0,0 +1,87 @@
+package lang
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/config"
+	"golang.org/x/text/language"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestDict(t *testing.T) {
+	RegisterDict(language.English, "test.status", map[string]string{"on": "On", "off": "Off"})
+	RegisterDict(language.Chinese, "test.status", map[string]string{"on": "开启", "off": "关闭"})
+
+	l := NewCommonLang(map[string]*config.Lang{
+		"zh": {Dict: map[string]*config.DictData{
+			"test.status":      {Data: map[string]string{"on": "已开启"}},
+			FieldDictNamespace: {Data: map[string]string{"name": "名称"}},
+		}},
+	}, log.DefaultLogger)
+	ctx := context.Background()
+
+	// 配置优先于内置字典
+	v, ok := l.GetDict(ctx, "test.status", "on", "zh-CN")
+	assert.True(t, ok)
+	assert.Equal(t, "已开启", v)
+	v, _ = l.GetDict(ctx, "test.status", "off", "zh-CN,zh;q=0.9")
+	assert.Equal(t, "关闭", v)
+	// 未匹配的语言使用默认语言
+	v, _ = l.GetDict(ctx, "test.status", "on", "fr")
+	assert.Equal(t, "On", v)
+	_, ok = l.GetDict(ctx, "test.status", "unknown", "zh")
+	assert.False(t, ok)
+
+	data, ok := l.GetDictData(ctx, "test.status", "zh")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"on": "已开启", "off": "关闭"}, data)
+	data, _ = GetDefaultDictData("test.status", "zh")
+	assert.Equal(t, map[string]string{"on": "开启", "off": "关闭"}, data)
+
+	l.AddDict(language.Chinese, "test.status", map[string]string{"off": "已关闭"})
+	v, _ = l.GetDict(ctx, "test.status", "off", "zh")
+	assert.Equal(t, "已关闭", v)
+
+	// 错误消息中的名称使用字段名称字典
+	assert.Equal(t, "名称 不能为空", l.GetCanNotEmptyMsg(ctx, "name", "zh"))
+	label, ok := l.GetFieldLabel(ctx, "title", "zh")
+	assert.False(t, ok)
+	assert.Equal(t, "title", label)
+	// 消息文件中 field_ 前缀的消息
+	assert.NoError(t, l.CurrentBundle().AddMessages(language.Chinese, &i18n.Message{ID: "field_title", Other: "标题"}))
+	label, ok = l.GetFieldLabel(ctx, "title", "zh")
+	assert.True(t, ok)
+	assert.Equal(t, "标题", label)
+	assert.NoError(t, l.AddFieldLabels(language.Chinese, map[string]string{"title": "主题"}))
+	label, _ = l.GetFieldLabel(ctx, "title", "zh")
+	assert.Equal(t, "主题", label)
+	assert.Error(t, l.AddFieldLabels(language.Und, map[string]string{"title": "主题"}))
+
+	// 未通过 NewCommonLang 创建时字典为空
+	empty := &CommonLang{}
+	_, ok = empty.GetDict(ctx, "test.status", "unknown", "zh")
+	assert.False(t, ok)
+	v, _ = empty.GetDict(ctx, "test.status", "on", "zh")
+	assert.Equal(t, "开启", v)
+	empty.AddDict(language.Chinese, "test.status", map[string]string{"on": "已开启"})
+	v, _ = empty.GetDict(ctx, "test.status", "on", "zh")
+	assert.Equal(t, "已开启", v)
+	label, ok = empty.GetFieldLabel(ctx, "title", "zh")
+	assert.False(t, ok)
+	assert.Equal(t, "title", label)
+}
+
+func TestGetEnumLabel(t *testing.T) {
+	l := NewCommonLang(nil, log.DefaultLogger)
+	enum := descriptorpb.FieldDescriptorProto_TYPE_STRING.Descriptor()
+	ctx := context.Background()
+	assert.Equal(t, "TYPE_STRING", l.GetEnumLabel(ctx, enum, 9, "zh"))
+	l.AddDict(language.Chinese, string(enum.FullName()), map[string]string{"TYPE_STRING": "字符串"})
+	assert.Equal(t, "字符串", l.GetEnumLabel(ctx, enum, 9, "zh"))
+	assert.Equal(t, "TYPE_STRING", l.GetEnumLabel(ctx, enum, 9, "en"))
+	assert.Equal(t, "100", l.GetEnumLabel(ctx, enum, 100, "zh"))
+}
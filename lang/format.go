@@ -0,0 +1,286 @@
+package lang
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+
+	"github.com/yimoka/go/middleware/meta"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// FormatDictNamespace 格式字典的命名空间 可通过配置的 dict 按语言覆盖
+const FormatDictNamespace = "format"
+
+// 格式字典的 key
+const (
+	// DateFormatKey 日期的 Go 时间格式
+	DateFormatKey = "date"
+	// TimeFormatKey 时间的 Go 时间格式
+	TimeFormatKey = "time"
+	// DateTimeFormatKey 日期时间的 Go 时间格式
+	DateTimeFormatKey = "datetime"
+	// DecimalSeparatorKey 小数点 未配置时使用 CLDR 的符号
+	DecimalSeparatorKey = "decimal_separator"
+	// GroupSeparatorKey 千位分隔符 未配置时使用 CLDR 的符号, 配置为空时不分组
+	GroupSeparatorKey = "group_separator"
+	// PercentFormatKey 百分比的格式 {number} 为数字
+	PercentFormatKey = "percent"
+	// CurrencyFormatKey 金额的格式 {symbol} 为货币符号, {number} 为数字, 负号在最前
+	CurrencyFormatKey = "currency"
+	// CurrencySymbolPrefix 货币符号的 key 前缀 如 symbol.CNY, 未配置时使用 CLDR 的符号
+	CurrencySymbolPrefix = "symbol."
+)
+
+func init() {
+	RegisterDict(language.English, FormatDictNamespace, map[string]string{
+		DateFormatKey:     "Jan 2, 2006",
+		TimeFormatKey:     "3:04 PM",
+		DateTimeFormatKey: "Jan 2, 2006, 3:04 PM",
+		PercentFormatKey:  "{number}%",
+		CurrencyFormatKey: "{symbol}{number}",
+	})
+	RegisterDict(language.Chinese, FormatDictNamespace, map[string]string{
+		DateFormatKey:     "2006年1月2日",
+		TimeFormatKey:     "15:04",
+		DateTimeFormatKey: "2006年1月2日 15:04",
+	})
+	RegisterDict(language.Russian, FormatDictNamespace, map[string]string{
+		DateFormatKey:     "02.01.2006",
+		TimeFormatKey:     "15:04",
+		DateTimeFormatKey: "02.01.2006, 15:04",
+		PercentFormatKey:  "{number} %",
+		CurrencyFormatKey: "{number} {symbol}",
+	})
+	RegisterDict(language.French, FormatDictNamespace, map[string]string{
+		DateFormatKey:     "02/01/2006",
+		TimeFormatKey:     "15:04",
+		DateTimeFormatKey: "02/01/2006 15:04",
+		PercentFormatKey:  "{number} %",
+		CurrencyFormatKey: "{number} {symbol}",
+	})
+}
+
+// separators CLDR 的小数点与千位分隔符
+type separators struct {
+	decimal string
+	group   string
+}
+
+// separatorCache 语言 -> separators
+var separatorCache sync.Map
+
+// cldrSeparators 从 x/text 的格式化结果中获取语言的小数点与千位分隔符
+func cldrSeparators(tag language.Tag) separators {
+	if v, ok := separatorCache.Load(tag); ok {
+		return v.(separators)
+	}
+	s := separators{decimal: "."}
+	symbols := []string{}
+	for _, r := range message.NewPrinter(tag).Sprintf("%.1f", 12345.5) {
+		if !unicode.IsDigit(r) {
+			symbols = append(symbols, string(r))
+		}
+	}
+	switch len(symbols) {
+	case 1:
+		s.decimal = symbols[0]
+	case 2:
+		s.group, s.decimal = symbols[0], symbols[1]
+	}
+	separatorCache.Store(tag, s)
+	return s
+}
+
+// maxInvalidLocations 缓存的无效时区名称的数量上限 时区名称来自客户端, 避免无限增长
+const maxInvalidLocations = 1024
+
+var (
+	// locationCache 时区名称 -> *time.Location 无效的名称缓存为 time.Local
+	locationCache sync.Map
+	// invalidLocations 已缓存的无效时区名称的数量
+	invalidLocations atomic.Int64
+)
+
+// GetLocation 获取请求的时区 来自元数据 timezone, 未设置或无效时返回 time.Local
+func GetLocation(ctx context.Context) *time.Location {
+	name, err := meta.GetTimezone(ctx)
+	if err != nil || name == "" {
+		return time.Local
+	}
+	if v, ok := locationCache.Load(name); ok {
+		return v.(*time.Location)
+	}
+	loc, lErr := time.LoadLocation(name)
+	if lErr != nil {
+		if invalidLocations.Add(1) <= maxInvalidLocations {
+			locationCache.Store(name, time.Local)
+		}
+		return time.Local
+	}
+	locationCache.Store(name, loc)
+	return loc
+}
+
+// formatTag 格式化使用的语言 为请求的首选语言
+func formatTag(ctx context.Context, langs []string) language.Tag {
+	return dictTags(getLangs(ctx, langs))[0]
+}
+
+// formatPattern 获取格式字典的值
+func (c *CommonLang) formatPattern(ctx context.Context, key string, langs []string) string {
+	v, _ := c.GetDict(ctx, FormatDictNamespace, key, langs...)
+	return v
+}
+
+// formatNumber 按语言的分隔符格式化非负数 最多 digits 位小数, trim 为 true 时去掉小数末尾的 0
+func (c *CommonLang) formatNumber(ctx context.Context, v float64, digits int, trim bool, langs []string) string {
+	if digits < 0 {
+		digits = 0
+	}
+	s := strconv.FormatFloat(roundDigits(v, digits), 'f', digits, 64)
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if trim {
+		fracPart = strings.TrimRight(fracPart, "0")
+	}
+
+	sep := cldrSeparators(formatTag(ctx, langs))
+	if v, ok := c.GetDict(ctx, FormatDictNamespace, DecimalSeparatorKey, langs...); ok {
+		sep.decimal = v
+	}
+	if v, ok := c.GetDict(ctx, FormatDictNamespace, GroupSeparatorKey, langs...); ok {
+		sep.group = v
+	}
+
+	b := strings.Builder{}
+	for i, r := range intPart {
+		if i > 0 && sep.group != "" && (len(intPart)-i)%3 == 0 {
+			b.WriteString(sep.group)
+		}
+		b.WriteRune(r)
+	}
+	if fracPart != "" {
+		b.WriteString(sep.decimal)
+		b.WriteString(fracPart)
+	}
+	return b.String()
+}
+
+// roundDigits 四舍五入到 digits 位小数 strconv 为银行家舍入
+func roundDigits(v float64, digits int) float64 {
+	if digits < 0 {
+		digits = 0
+	}
+	scale := math.Pow10(digits)
+	return math.Round(v*scale) / scale
+}
+
+// signed 四舍五入到 digits 位小数后为负数时在格式化的结果前加负号 避免 -0.001 格式化为 -0
+func signed(v float64, digits int, format func(abs float64) string) string {
+	v = roundDigits(v, digits)
+	if v < 0 {
+		return "-" + format(-v)
+	}
+	return format(math.Abs(v))
+}
+
+// FormatDecimal 按请求的语言格式化数字 最多 digits 位小数, 去掉小数末尾的 0
+func (c *CommonLang) FormatDecimal(ctx context.Context, v float64, digits int, langs ...string) string {
+	return signed(v, digits, func(abs float64) string {
+		return c.formatNumber(ctx, abs, digits, true, langs)
+	})
+}
+
+// FormatPercent 按请求的语言格式化百分比 v 为比例, 如 0.25 为 25%, 最多 digits 位小数
+func (c *CommonLang) FormatPercent(ctx context.Context, v float64, digits int, langs ...string) string {
+	return signed(v*100, digits, func(abs float64) string {
+		number := c.formatNumber(ctx, abs, digits, true, langs)
+		return strings.ReplaceAll(c.formatPattern(ctx, PercentFormatKey, langs), "{number}", number)
+	})
+}
+
+// FormatCurrency 按请求的语言格式化金额 code 为 ISO 4217 货币代码, 小数位数为货币的标准位数(如 CNY 2 位, JPY 0 位)
+// 无效的货币代码以代码作为符号, 保留 2 位小数
+func (c *CommonLang) FormatCurrency(ctx context.Context, amount float64, code string, langs ...string) string {
+	code = strings.ToUpper(code)
+	symbol, digits := code, 2
+	if unit, err := currency.ParseISO(code); err == nil {
+		symbol = message.NewPrinter(formatTag(ctx, langs)).Sprint(currency.Symbol(unit))
+		digits, _ = currency.Standard.Rounding(unit)
+	}
+	if v, ok := c.GetDict(ctx, FormatDictNamespace, CurrencySymbolPrefix+code, langs...); ok {
+		symbol = v
+	}
+	return signed(amount, digits, func(abs float64) string {
+		number := c.formatNumber(ctx, abs, digits, false, langs)
+		return strings.NewReplacer("{symbol}", symbol, "{number}", number).Replace(c.formatPattern(ctx, CurrencyFormatKey, langs))
+	})
+}
+
+// FormatDate 按请求的语言与时区格式化日期
+func (c *CommonLang) FormatDate(ctx context.Context, t time.Time, langs ...string) string {
+	return t.In(GetLocation(ctx)).Format(c.formatPattern(ctx, DateFormatKey, langs))
+}
+
+// FormatTime 按请求的语言与时区格式化时间
+func (c *CommonLang) FormatTime(ctx context.Context, t time.Time, langs ...string) string {
+	return t.In(GetLocation(ctx)).Format(c.formatPattern(ctx, TimeFormatKey, langs))
+}
+
+// FormatDateTime 按请求的语言与时区格式化日期时间
+func (c *CommonLang) FormatDateTime(ctx context.Context, t time.Time, langs ...string) string {
+	return t.In(GetLocation(ctx)).Format(c.formatPattern(ctx, DateTimeFormatKey, langs))
+}
+
+// FormatRelative 按请求的语言格式化相对当前的时间 如 3 分钟前、3 天后
+func (c *CommonLang) FormatRelative(ctx context.Context, t time.Time, langs ...string) string {
+	return c.formatRelative(ctx, t, time.Now(), langs)
+}
+
+// relativeUnit 相对时间的单位
+type relativeUnit struct {
+	// max 使用该单位的最大间隔(不含)
+	max   time.Duration
+	size  time.Duration
+	ago   MsgKey
+	later MsgKey
+}
+
+const day = 24 * time.Hour
+
+var relativeUnits = []relativeUnit{
+	{max: time.Hour, size: time.Minute, ago: relativeMinutesAgoKey, later: relativeMinutesLaterKey},
+	{max: day, size: time.Hour, ago: relativeHoursAgoKey, later: relativeHoursLaterKey},
+	{max: 30 * day, size: day, ago: relativeDaysAgoKey, later: relativeDaysLaterKey},
+	{max: 365 * day, size: 30 * day, ago: relativeMonthsAgoKey, later: relativeMonthsLaterKey},
+	{max: math.MaxInt64, size: 365 * day, ago: relativeYearsAgoKey, later: relativeYearsLaterKey},
+}
+
+func (c *CommonLang) formatRelative(ctx context.Context, t, now time.Time, langs []string) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	if d < time.Minute {
+		return c.getMsg(ctx, relativeNowKey, nil, langs...)
+	}
+	for _, u := range relativeUnits {
+		if d >= u.max {
+			continue
+		}
+		count := int(d / u.size)
+		if future {
+			return c.getPluralMsg(ctx, u.later, count, langs...)
+		}
+		return c.getPluralMsg(ctx, u.ago, count, langs...)
+	}
+	return ""
+}
@@ -0,0 +1,81 @@
+package lang
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/config"
+	"github.com/yimoka/go/middleware/meta"
+)
+
+func TestFormatNumber(t *testing.T) {
+	l := NewCommonLang(map[string]*config.Lang{
+		"fr": {Dict: map[string]*config.DictData{
+			FormatDictNamespace: {Data: map[string]string{CurrencySymbolPrefix + "CNY": "¥", GroupSeparatorKey: "."}},
+		}},
+	}, log.DefaultLogger)
+	ctx := context.Background()
+
+	assert.Equal(t, "1,234,567.89", l.FormatDecimal(ctx, 1234567.891, 2, "en"))
+	assert.Equal(t, "-1,234.5", l.FormatDecimal(ctx, -1234.5, 2, "zh"))
+	assert.Equal(t, "1 234,5", l.FormatDecimal(ctx, 1234.5, 2, "ru"))
+	assert.Equal(t, "12", l.FormatDecimal(ctx, 12.004, 2, "en"))
+	// 配置的分隔符优先
+	assert.Equal(t, "1.234,5", l.FormatDecimal(ctx, 1234.5, 2, "fr"))
+
+	assert.Equal(t, "25.5%", l.FormatPercent(ctx, 0.255, 1, "en"))
+	assert.Equal(t, "25 %", l.FormatPercent(ctx, 0.25, 0, "ru"))
+
+	assert.Equal(t, "$1,234.50", l.FormatCurrency(ctx, 1234.5, "usd", "en"))
+	assert.Equal(t, "-US$1,234.50", l.FormatCurrency(ctx, -1234.5, "USD", "zh"))
+	assert.Equal(t, "¥1,235", l.FormatCurrency(ctx, 1234.5, "JPY", "en"))
+	assert.Equal(t, "1.234,50 ¥", l.FormatCurrency(ctx, 1234.5, "CNY", "fr"))
+	assert.Equal(t, "XYZ1.00", l.FormatCurrency(ctx, 1, "XYZ", "en"))
+	// 四舍五入为 0 时不加负号
+	assert.Equal(t, "0", l.FormatDecimal(ctx, -0.001, 2, "en"))
+	assert.Equal(t, "0%", l.FormatPercent(ctx, -0.00001, 1, "en"))
+	assert.Equal(t, "$0.00", l.FormatCurrency(ctx, -0.001, "USD", "en"))
+	assert.Equal(t, "-0.01", l.FormatDecimal(ctx, -0.005, 2, "en"))
+}
+
+func TestFormatTime(t *testing.T) {
+	l := NewCommonLang(map[string]*config.Lang{
+		"zh": {Dict: map[string]*config.DictData{
+			FormatDictNamespace: {Data: map[string]string{DateFormatKey: "2006-01-02"}},
+		}},
+	}, log.DefaultLogger)
+	ctx := metadata.NewServerContext(context.Background(), metadata.New())
+	ctx = meta.SetTimezone(ctx, "Asia/Shanghai")
+	v := time.Date(2024, 3, 5, 16, 4, 0, 0, time.UTC)
+
+	assert.Equal(t, "Mar 6, 2024, 12:04 AM", l.FormatDateTime(ctx, v, "en"))
+	assert.Equal(t, "2024-03-06", l.FormatDate(ctx, v, "zh"))
+	assert.Equal(t, "00:04", l.FormatTime(ctx, v, "ru"))
+	// 无效的时区使用本地时区
+	ctx = meta.SetTimezone(ctx, "Invalid/Zone")
+	assert.Equal(t, v.In(time.Local).Format("02.01.2006"), l.FormatDate(ctx, v, "ru"))
+	// 无效的时区同样缓存
+	loc, ok := locationCache.Load("Invalid/Zone")
+	assert.True(t, ok)
+	assert.Equal(t, time.Local, loc)
+}
+
+func TestFormatRelative(t *testing.T) {
+	l := NewCommonLang(nil, log.DefaultLogger)
+	ctx := context.Background()
+	now := time.Now()
+
+	assert.Equal(t, "just now", l.formatRelative(ctx, now.Add(-30*time.Second), now, []string{"en"}))
+	assert.Equal(t, "1 minute ago", l.formatRelative(ctx, now.Add(-time.Minute), now, []string{"en"}))
+	assert.Equal(t, "in 3 hours", l.formatRelative(ctx, now.Add(3*time.Hour+time.Minute), now, []string{"en"}))
+	assert.Equal(t, "3 天前", l.formatRelative(ctx, now.Add(-3*day), now, []string{"zh"}))
+	assert.Equal(t, "2 个月后", l.formatRelative(ctx, now.Add(61*day), now, []string{"zh"}))
+	assert.Equal(t, "5 лет назад", l.formatRelative(ctx, now.Add(-5*365*day), now, []string{"ru"}))
+	assert.Equal(t, "через 2 дня", l.formatRelative(ctx, now.Add(2*day), now, []string{"ru"}))
+	assert.Equal(t, "il y a 1 an", l.formatRelative(ctx, now.Add(-400*day), now, []string{"fr"}))
+	assert.Equal(t, "3 minutes ago", l.FormatRelative(ctx, now.Add(-3*time.Minute-time.Second)))
+}
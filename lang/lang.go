@@ -4,8 +4,6 @@ package lang
 import (
 	"context"
 	"html/template"
-	"sort"
-	"strconv"
 	"strings"
 
 	"github.com/nicksnyder/go-i18n/v2/i18n"
@@ -13,50 +11,24 @@ import (
 )
 
 // GetAccept 获取语言字符串
+// 已由 locale 中间件协商时返回协商的语言, 否则依次从元数据 language 与请求头 Accept-Language 获取
 func GetAccept(ctx context.Context) string {
-	lang, err := meta.GetValue(ctx, "language")
-	if err == nil && lang != "" {
-		return lang
+	if tag, ok := LocaleFromContext(ctx); ok {
+		return tag.String()
 	}
-	lang = meta.GetAcceptLanguage(ctx)
-	if lang != "" {
-		meta.SetValue(ctx, "language", lang)
+	lang, err := meta.GetLanguage(ctx)
+	if err == nil && lang != "" {
 		return lang
 	}
-	return ""
+	return meta.GetAcceptLanguage(ctx)
 }
 
-// GetAcceptArr 获取语言数组
+// GetAcceptArr 获取语言数组 按权重排序, 忽略格式错误的项
 func GetAcceptArr(ctx context.Context) []string {
-	str := GetAccept(ctx)
-	if str == "" {
-		return []string{}
-	}
-	// 转为数组
-	lang := strings.Split(str, ",")
-	// 根据权重排序
-	sort.Slice(lang, func(i, j int) bool {
-		iArr := strings.Split(lang[i], ";")
-		iQ := 1.0
-		if len(iArr) > 1 {
-			iQArr := strings.Split(iArr[1], "=")
-			if len(iQArr) > 1 {
-				iQ, _ = strconv.ParseFloat(iQArr[1], 64)
-			}
-		}
-		jArr := strings.Split(lang[j], ";")
-		jQ := 1.0
-		if len(jArr) > 1 {
-			jQArr := strings.Split(jArr[1], "=")
-			if len(jQArr) > 1 {
-				jQ, _ = strconv.ParseFloat(jQArr[1], 64)
-			}
-		}
-		return iQ > jQ
-	})
-	// 去掉权重
-	for i, l := range lang {
-		lang[i] = strings.Split(l, ";")[0]
+	tags := ParseAccept(GetAccept(ctx))
+	lang := make([]string, len(tags))
+	for i, tag := range tags {
+		lang[i] = tag.String()
 	}
 	return lang
 }
@@ -0,0 +1,286 @@
+package lang
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/yimoka/go/config"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// BundleOption 语言包的加载选项
+type BundleOption func(*Loader)
+
+// messageSource 消息文件的来源 目录或 fs.FS
+type messageSource struct {
+	fsys fs.FS
+	root string
+	// dir 本地目录 用于监听文件变更
+	dir string
+}
+
+// WithMessageDir 从目录加载 go-i18n 格式的消息文件(TOML/YAML/JSON)
+// 文件名须以语言结尾 如 zh.toml、active.zh-CN.yaml, 子目录中的文件同样加载
+func WithMessageDir(dirs ...string) BundleOption {
+	return func(l *Loader) {
+		for _, dir := range dirs {
+			l.sources = append(l.sources, messageSource{fsys: os.DirFS(dir), root: ".", dir: dir})
+		}
+	}
+}
+
+// WithMessageFS 从 fs.FS(如 embed.FS)加载消息文件 root 为文件所在的目录
+func WithMessageFS(fsys fs.FS, root string) BundleOption {
+	return func(l *Loader) {
+		l.sources = append(l.sources, messageSource{fsys: fsys, root: root})
+	}
+}
+
+// WithWatch 监听 WithMessageDir 的目录 文件变更时重新加载 仅用于 NewCommonLang
+func WithWatch() BundleOption {
+	return func(l *Loader) {
+		l.watch = true
+	}
+}
+
+// withDefaults 加载内置的消息
+func withDefaults(defaults map[language.Tag]map[MsgKey]*i18n.Message) BundleOption {
+	return func(l *Loader) {
+		l.defaults = defaults
+	}
+}
+
+// Loader 语言包加载器
+// 合并顺序: 内置消息 < 业务模块的消息表 < 消息文件(按选项的顺序) < 配置的消息, 后加载的同 ID 消息覆盖先加载的
+// 记录各语言已加载的消息 ID 用于检查缺失的翻译
+type Loader struct {
+	config   map[string]*config.Lang
+	defaults map[language.Tag]map[MsgKey]*i18n.Message
+	sources  []messageSource
+	watch    bool
+	mu       sync.RWMutex
+	// catalogs 业务模块的内置消息 在内置消息之后、消息文件之前加载
+	catalogs []map[language.Tag][]*i18n.Message
+	// ids 最近一次加载的各语言的消息 ID
+	ids map[language.Tag]map[string]bool
+	// fileIDs 最近一次加载的消息文件中各语言的消息 ID
+	fileIDs map[language.Tag]map[string]bool
+}
+
+// NewLoader 创建语言包加载器
+func NewLoader(langMapConfig map[string]*config.Lang, opts ...BundleOption) *Loader {
+	l := &Loader{config: maps.Clone(langMapConfig)}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// NewBundle 从消息文件与配置创建语言包
+func NewBundle(langMapConfig map[string]*config.Lang, opts ...BundleOption) (*i18n.Bundle, error) {
+	return NewLoader(langMapConfig, opts...).Load()
+}
+
+// Load 加载语言包 每次调用创建新的语言包
+func (l *Loader) Load() (*i18n.Bundle, error) {
+	bundle := i18n.NewBundle(language.English)
+	ids := map[language.Tag]map[string]bool{}
+	fileIDs := map[language.Tag]map[string]bool{}
+	add := func(tag language.Tag, msgs ...*i18n.Message) error {
+		if err := bundle.AddMessages(tag, msgs...); err != nil {
+			return err
+		}
+		markIDs(ids, tag, msgs)
+		return nil
+	}
+
+	for tag, msgs := range l.defaults {
+		for _, m := range msgs {
+			if err := add(tag, m); err != nil {
+				return nil, err
+			}
+		}
+	}
+	l.mu.RLock()
+	catalogs, conf := l.catalogs, l.config
+	l.mu.RUnlock()
+	for _, catalog := range catalogs {
+		for tag, msgs := range catalog {
+			if err := add(tag, msgs...); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, src := range l.sources {
+		files, err := ParseMessageFiles(src.fsys, src.root)
+		if err != nil {
+			return nil, err
+		}
+		for _, mf := range files {
+			if err := add(mf.Tag, mf.Messages...); err != nil {
+				return nil, err
+			}
+			markIDs(fileIDs, mf.Tag, mf.Messages)
+		}
+	}
+	for key, lc := range conf {
+		tag, err := language.Parse(key)
+		if err != nil {
+			continue
+		}
+		for _, m := range lc.GetMessages() {
+			if err := add(tag, MessageToI18n(m)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	l.mu.Lock()
+	l.ids, l.fileIDs = ids, fileIDs
+	l.mu.Unlock()
+	return bundle, nil
+}
+
+// markIDs 记录消息 ID
+func markIDs(ids map[language.Tag]map[string]bool, tag language.Tag, msgs []*i18n.Message) {
+	if ids[tag] == nil {
+		ids[tag] = map[string]bool{}
+	}
+	for _, m := range msgs {
+		ids[tag][m.ID] = true
+	}
+}
+
+// getConfig 获取当前的配置
+func (l *Loader) getConfig() map[string]*config.Lang {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.config
+}
+
+// setConfig 替换配置 复制传入的 map, 下次加载时生效
+func (l *Loader) setConfig(langMapConfig map[string]*config.Lang) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.config = maps.Clone(langMapConfig)
+}
+
+// addCatalog 添加业务模块的内置消息 下次加载时生效
+func (l *Loader) addCatalog(msgs map[language.Tag][]*i18n.Message) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.catalogs = append(l.catalogs, msgs)
+	if l.ids == nil {
+		l.ids = map[language.Tag]map[string]bool{}
+	}
+	for tag, m := range msgs {
+		markIDs(l.ids, tag, m)
+	}
+}
+
+// overridden 消息是否被最近一次加载的消息文件或当前配置的 messages 覆盖
+func (l *Loader) overridden(tag language.Tag, id string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.fileIDs[tag][id] {
+		return true
+	}
+	for key, lc := range l.config {
+		if t, err := language.Parse(key); err != nil || t != tag {
+			continue
+		}
+		for _, m := range lc.GetMessages() {
+			if m.GetId() == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParseMessageFiles 解析 root 目录及其子目录中的消息文件 按文件路径的顺序返回
+func ParseMessageFiles(fsys fs.FS, root string) ([]*i18n.MessageFile, error) {
+	files := []*i18n.MessageFile{}
+	err := fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !isMessageFile(p) {
+			return err
+		}
+		buf, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		// 语言与格式由文件名解析
+		mf, err := i18n.ParseMessageFileBytes(buf, path.Base(p), unmarshalFuncs)
+		if err != nil {
+			return fmt.Errorf("lang: %s: %w", p, err)
+		}
+		if mf.Tag == language.Und {
+			return fmt.Errorf("lang: %s: file name must end with a language tag", p)
+		}
+		mf.Path = p
+		files = append(files, mf)
+		return nil
+	})
+	return files, err
+}
+
+// unmarshalFuncs 支持的消息文件格式
+var unmarshalFuncs = map[string]i18n.UnmarshalFunc{
+	"toml": toml.Unmarshal,
+	"yaml": yaml.Unmarshal,
+	"yml":  yaml.Unmarshal,
+	"json": json.Unmarshal,
+}
+
+// isMessageFile 是否为支持的消息文件
+func isMessageFile(name string) bool {
+	switch filepath.Ext(name) {
+	case ".toml", ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// Missing 最近一次加载中各语言相对英语缺少的消息 ID key 为语言, 无缺失的语言不返回
+func (l *Loader) Missing() map[string][]string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	res := map[string][]string{}
+	en := l.ids[language.English]
+	for tag, ids := range l.ids {
+		if tag == language.English {
+			continue
+		}
+		missing := []string{}
+		for id := range en {
+			if !ids[id] {
+				missing = append(missing, id)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			res[tag.String()] = missing
+		}
+	}
+	return res
+}
+
+// dirs 需要监听的本地目录
+func (l *Loader) dirs() []string {
+	dirs := []string{}
+	for _, src := range l.sources {
+		if src.dir != "" {
+			dirs = append(dirs, src.dir)
+		}
+	}
+	return dirs
+}
@@ -0,0 +1,97 @@
+package lang
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/config"
+	"golang.org/x/text/language"
+)
+
+func localize(bundle *i18n.Bundle, id string, langs ...string) string {
+	v, _ := i18n.NewLocalizer(bundle, langs...).Localize(&i18n.LocalizeConfig{MessageID: id})
+	return v
+}
+
+func TestLoader(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "en.toml"), []byte("hello = \"Hello\"\nbye = \"Bye\"\n"), 0o600))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "user"), 0o700))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "user", "active.zh.yaml"), []byte("hello: 你好\n"), 0o600))
+	fsys := fstest.MapFS{
+		"i18n/zh.json":  {Data: []byte(`{"hello": "您好", "bye": "再见"}`)},
+		"i18n/fr.json":  {Data: []byte(`{"hello": "Bonjour"}`)},
+		"i18n/ja.yml":   {Data: []byte("hello: こんにちは\n")},
+		"i18n/skip.txt": {Data: []byte("hello")},
+	}
+
+	l := NewLoader(map[string]*config.Lang{
+		"fr": {Messages: []*config.LangMessage{{Id: "bye", Other: "Au revoir"}}},
+		"zh": {Messages: []*config.LangMessage{{Id: "bye", Other: "回头见"}}},
+	}, WithMessageDir(dir), WithMessageFS(fsys, "i18n"))
+	bundle, err := l.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", localize(bundle, "hello", "en"))
+	// 后加载的文件覆盖先加载的, 配置覆盖文件
+	assert.Equal(t, "您好", localize(bundle, "hello", "zh"))
+	assert.Equal(t, "回头见", localize(bundle, "bye", "zh"))
+	assert.Equal(t, "Au revoir", localize(bundle, "bye", "fr"))
+	assert.Equal(t, "こんにちは", localize(bundle, "hello", "ja"))
+	assert.Equal(t, map[string][]string{"ja": {"bye"}}, l.Missing())
+
+	_, err = NewBundle(nil, WithMessageFS(fstest.MapFS{"x.toml": {Data: []byte("a = 1 = 2")}}, "."))
+	assert.Error(t, err)
+	_, err = NewBundle(nil, WithMessageFS(fstest.MapFS{"toml": {Data: []byte("a = \"b\"")}}, "."))
+	assert.NoError(t, err)
+}
+
+func TestCommonLangMessageFiles(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "zh.toml")
+	assert.NoError(t, os.WriteFile(file, []byte("data_not_found = \"文件 找不到数据\"\n"), 0o600))
+	l := NewCommonLang(nil, log.DefaultLogger, WithMessageDir(dir), WithWatch())
+	defer func() { assert.NoError(t, l.Close()) }()
+	assert.Equal(t, "文件 找不到数据", l.GetDataNotFoundMsg(context.Background(), "zh"))
+	// 内置的英语消息均有中文翻译
+	assert.NotContains(t, l.MissingTranslations(), "zh")
+
+	assert.NoError(t, os.WriteFile(file, []byte("data_not_found = \"新的 找不到数据\"\n"), 0o600))
+	assert.Eventually(t, func() bool {
+		return l.GetDataNotFoundMsg(context.Background(), "zh") == "新的 找不到数据"
+	}, 3*time.Second, 50*time.Millisecond)
+	assert.Equal(t, "新的 找不到数据", localize(l.CurrentBundle(), dataNotFoundKey.String(), "zh"))
+
+	// 文件错误时保留当前的语言包
+	assert.NoError(t, os.WriteFile(file, []byte("data_not_found = "), 0o600))
+	assert.Error(t, l.Reload())
+	assert.Equal(t, "新的 找不到数据", l.GetDataNotFoundMsg(context.Background(), "zh"))
+}
+
+func TestCommonLangReloadConfig(t *testing.T) {
+	conf := map[string]*config.Lang{"zh": {Dict: map[string]*config.DictData{FieldDictNamespace: {Data: map[string]string{"name": "名称"}}}}}
+	l := NewCommonLang(conf, log.DefaultLogger)
+	ctx := context.Background()
+	assert.NoError(t, l.AddFieldLabels(language.Chinese, map[string]string{"title": "标题"}))
+
+	// 修改传入的 map 不影响语言包
+	conf["zh"] = &config.Lang{Dict: map[string]*config.DictData{FieldDictNamespace: {Data: map[string]string{"name": "修改"}}}}
+	assert.NoError(t, l.Reload())
+	assert.Equal(t, "名称", l.fieldLabel(ctx, "name", "zh"))
+
+	assert.NoError(t, l.SetConfig(map[string]*config.Lang{"zh": {
+		Messages: []*config.LangMessage{{Id: dataNotFoundKey.String(), Other: "配置 找不到数据"}},
+		Dict:     map[string]*config.DictData{FieldDictNamespace: {Data: map[string]string{"name": "姓名", "title": "主题"}}},
+	}}))
+	assert.Equal(t, "配置 找不到数据", l.GetDataNotFoundMsg(ctx, "zh"))
+	assert.Equal(t, "配置 找不到数据", localize(l.Bundle, dataNotFoundKey.String(), "zh"))
+	assert.Equal(t, "姓名", l.fieldLabel(ctx, "name", "zh"))
+	// AddDict 添加的字典优先于配置
+	assert.Equal(t, "标题", l.fieldLabel(ctx, "title", "zh"))
+}
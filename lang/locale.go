@@ -0,0 +1,178 @@
+package lang
+
+import (
+	"context"
+	nethttp "net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/yimoka/go/middleware/meta"
+	"golang.org/x/text/language"
+)
+
+const (
+	// LocaleQuery 默认的语言查询参数
+	LocaleQuery = "lang"
+	// LocaleCookie 默认的语言 Cookie
+	LocaleCookie = "lang"
+)
+
+// Resolver 从请求中获取语言 值为单个语言或 Accept-Language 格式的列表, 获取不到时返回 false
+type Resolver func(ctx context.Context) (string, bool)
+
+// FromQuery 从 HTTP 请求的查询参数中获取语言
+func FromQuery(name string) Resolver {
+	return func(ctx context.Context) (string, bool) {
+		req, ok := httpRequest(ctx)
+		if !ok {
+			return "", false
+		}
+		v := req.URL.Query().Get(name)
+		return v, v != ""
+	}
+}
+
+// FromCookie 从 HTTP 请求的 Cookie 中获取语言
+func FromCookie(name string) Resolver {
+	return func(ctx context.Context) (string, bool) {
+		req, ok := httpRequest(ctx)
+		if !ok {
+			return "", false
+		}
+		c, err := req.Cookie(name)
+		if err != nil {
+			return "", false
+		}
+		return c.Value, c.Value != ""
+	}
+}
+
+// httpRequest 获取 HTTP 请求 按 http.Transporter 接口获取以兼容自定义的 transport
+func httpRequest(ctx context.Context) (*nethttp.Request, bool) {
+	tr, ok := transport.FromServerContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	ht, ok := tr.(http.Transporter)
+	if !ok || ht.Request() == nil {
+		return nil, false
+	}
+	return ht.Request(), true
+}
+
+// FromMeta 从元数据 language 中获取语言 上游服务解析的语言通过元数据传递
+func FromMeta() Resolver {
+	return func(ctx context.Context) (string, bool) {
+		v, err := meta.GetLanguage(ctx)
+		return v, err == nil && v != ""
+	}
+}
+
+// FromHeader 从请求头 Accept-Language 中获取语言
+func FromHeader() Resolver {
+	return func(ctx context.Context) (string, bool) {
+		v := meta.GetAcceptLanguage(ctx)
+		return v, v != ""
+	}
+}
+
+// DefaultResolvers 默认的语言解析顺序: 查询参数 lang、Cookie lang、元数据 language、请求头 Accept-Language
+func DefaultResolvers() []Resolver {
+	return []Resolver{FromQuery(LocaleQuery), FromCookie(LocaleCookie), FromMeta(), FromHeader()}
+}
+
+// ParseAccept 解析 Accept-Language 格式的语言列表 按权重排序, 忽略格式错误、权重为 0 的项与通配符
+func ParseAccept(s string) []language.Tag {
+	type weighted struct {
+		tag language.Tag
+		q   float32
+	}
+	items := []weighted{}
+	seen := map[language.Tag]bool{}
+	for _, entry := range strings.Split(s, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		tags, q, err := language.ParseAcceptLanguage(entry)
+		if err != nil || len(tags) != 1 || tags[0] == language.Und || seen[tags[0]] {
+			continue
+		}
+		if base, _ := tags[0].Base(); base.String() == "mul" {
+			continue
+		}
+		seen[tags[0]] = true
+		items = append(items, weighted{tag: tags[0], q: q[0]})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].q > items[j].q
+	})
+	res := make([]language.Tag, len(items))
+	for i, item := range items {
+		res[i] = item.tag
+	}
+	return res
+}
+
+// Negotiator 按支持的语言协商请求的语言 创建后并发安全
+type Negotiator struct {
+	supported []language.Tag
+	matcher   language.Matcher
+}
+
+// NewNegotiator 创建语言协商器 第一个为默认语言, 为空时仅支持 English
+func NewNegotiator(supported ...language.Tag) *Negotiator {
+	if len(supported) == 0 {
+		supported = []language.Tag{language.English}
+	}
+	return &Negotiator{supported: supported, matcher: language.NewMatcher(supported)}
+}
+
+// Supported 支持的语言
+func (n *Negotiator) Supported() []language.Tag {
+	return n.supported
+}
+
+// Match 返回与 desired 最匹配的支持的语言 无匹配时返回默认语言与 false
+func (n *Negotiator) Match(desired ...language.Tag) (language.Tag, bool) {
+	if len(desired) == 0 {
+		return n.supported[0], false
+	}
+	_, index, conf := n.matcher.Match(desired...)
+	if conf == language.No {
+		return n.supported[0], false
+	}
+	return n.supported[index], true
+}
+
+// Resolve 按解析器的顺序协商语言 第一个能匹配到支持的语言的来源生效, 均不匹配时返回默认语言
+// resolvers 为空时使用 DefaultResolvers
+func (n *Negotiator) Resolve(ctx context.Context, resolvers ...Resolver) language.Tag {
+	if len(resolvers) == 0 {
+		resolvers = DefaultResolvers()
+	}
+	for _, resolver := range resolvers {
+		v, ok := resolver(ctx)
+		if !ok {
+			continue
+		}
+		if tag, ok := n.Match(ParseAccept(v)...); ok {
+			return tag
+		}
+	}
+	return n.supported[0]
+}
+
+type localeKey struct{}
+
+// WithLocale 将协商的语言存入 ctx
+func WithLocale(ctx context.Context, tag language.Tag) context.Context {
+	return context.WithValue(ctx, localeKey{}, tag)
+}
+
+// LocaleFromContext 从 ctx 中获取协商的语言
+func LocaleFromContext(ctx context.Context) (language.Tag, bool) {
+	tag, ok := ctx.Value(localeKey{}).(language.Tag)
+	return tag, ok
+}
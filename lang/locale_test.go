@@ -0,0 +1,108 @@
+package lang
+
+import (
+	"context"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/middleware/meta"
+	"golang.org/x/text/language"
+)
+
+type headerCarrier nethttp.Header
+
+func (h headerCarrier) Get(key string) string { return nethttp.Header(h).Get(key) }
+func (h headerCarrier) Set(key, value string) { nethttp.Header(h).Set(key, value) }
+func (h headerCarrier) Add(key, value string) { nethttp.Header(h).Add(key, value) }
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
+}
+func (h headerCarrier) Values(key string) []string { return nethttp.Header(h).Values(key) }
+
+type testTransport struct {
+	req *nethttp.Request
+}
+
+func (t *testTransport) Kind() transport.Kind            { return transport.KindHTTP }
+func (t *testTransport) Endpoint() string                { return "" }
+func (t *testTransport) Operation() string               { return "" }
+func (t *testTransport) RequestHeader() transport.Header { return headerCarrier(t.req.Header) }
+func (t *testTransport) ReplyHeader() transport.Header   { return headerCarrier{} }
+func (t *testTransport) Request() *nethttp.Request       { return t.req }
+func (t *testTransport) PathTemplate() string            { return "" }
+
+var _ http.Transporter = (*testTransport)(nil)
+
+// newHTTPCtx 构造 HTTP 请求的 ctx
+func newHTTPCtx(target, cookie, accept string) context.Context {
+	req := httptest.NewRequest(nethttp.MethodGet, target, nil)
+	if cookie != "" {
+		req.AddCookie(&nethttp.Cookie{Name: LocaleCookie, Value: cookie})
+	}
+	if accept != "" {
+		req.Header.Set("Accept-Language", accept)
+	}
+	ctx := transport.NewServerContext(context.Background(), &testTransport{req: req})
+	return metadata.NewServerContext(ctx, metadata.New())
+}
+
+func TestParseAccept(t *testing.T) {
+	assert.Equal(t, []language.Tag{language.French, language.SimplifiedChinese, language.English},
+		ParseAccept("en;q=0.5, zh-Hans;q=0.8, fr"))
+	// 忽略格式错误、权重为 0、重复的项与通配符
+	assert.Equal(t, []language.Tag{language.German, language.Make("zh-CN")},
+		ParseAccept("xx-!!, fr;q=abc, de, ja;q=0, *, zh_CN;q=0.9, de;q=0.1"))
+	assert.Empty(t, ParseAccept(""))
+	assert.Empty(t, ParseAccept(";;"))
+}
+
+func TestNegotiator(t *testing.T) {
+	n := NewNegotiator(language.English, language.Chinese, language.Russian)
+	tag, ok := n.Match(language.Make("zh-TW"))
+	assert.True(t, ok)
+	assert.Equal(t, language.Chinese, tag)
+	tag, ok = n.Match(language.Japanese)
+	assert.False(t, ok)
+	assert.Equal(t, language.English, tag)
+
+	// 查询参数 > Cookie > 元数据 > 请求头
+	ctx := newHTTPCtx("/?lang=ru", "zh", "zh")
+	assert.Equal(t, language.Russian, n.Resolve(meta.SetLanguage(ctx, "zh")))
+	assert.Equal(t, language.Chinese, n.Resolve(newHTTPCtx("/", "zh", "ru")))
+	assert.Equal(t, language.Russian, n.Resolve(meta.SetLanguage(newHTTPCtx("/", "", "zh"), "ru")))
+	assert.Equal(t, language.Chinese, n.Resolve(newHTTPCtx("/", "", "ja, zh-CN;q=0.8, ru;q=0.5")))
+	// 不支持的来源跳过
+	assert.Equal(t, language.Russian, n.Resolve(newHTTPCtx("/?lang=ja", "bad!!", "ru")))
+	assert.Equal(t, language.English, n.Resolve(newHTTPCtx("/?lang=ja", "", "")))
+	assert.Equal(t, language.English, n.Resolve(context.Background()))
+	assert.Equal(t, language.Chinese, n.Resolve(newHTTPCtx("/?locale=zh", "", ""), FromQuery("locale")))
+
+	assert.Equal(t, []language.Tag{language.English}, NewNegotiator().Supported())
+}
+
+func TestGetAccept(t *testing.T) {
+	ctx := newHTTPCtx("/", "", "fr;q=0.5, zh-CN")
+	assert.Equal(t, []string{"zh-CN", "fr"}, GetAcceptArr(ctx))
+	// 只读取 不修改元数据
+	v, _ := meta.GetLanguage(ctx)
+	assert.Equal(t, "", v)
+
+	ctx = WithLocale(ctx, language.Russian)
+	assert.Equal(t, "ru", GetAccept(ctx))
+	assert.Equal(t, []string{"ru"}, GetAcceptArr(ctx))
+	assert.Empty(t, GetAcceptArr(context.Background()))
+
+	l := NewCommonLang(nil, nil)
+	assert.Equal(t, language.English, l.LanguageTags()[0])
+	assert.Equal(t, "Data not found", l.GetDataNotFoundMsg(WithLocale(context.Background(), language.English)))
+	assert.Equal(t, "找不到数据", l.GetDataNotFoundMsg(WithLocale(context.Background(), language.Chinese)))
+}
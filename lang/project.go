@@ -0,0 +1,258 @@
+package lang
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// I18nFieldSuffix 多语言字段名的后缀 如 titleI18n 为 title 的多语言值, 与 ent/ann.Field 的 I18NFor 对应
+const I18nFieldSuffix = "I18n"
+
+// ProjectOption 多语言字段投影的选项
+type ProjectOption func(*projectOptions)
+
+type projectOptions struct {
+	langs   []string
+	flatten bool
+}
+
+// WithProjectLangs 指定语言 默认使用请求的语言
+func WithProjectLangs(langs ...string) ProjectOption {
+	return func(o *projectOptions) {
+		o.langs = langs
+	}
+}
+
+// WithFlatten 将匹配的翻译写入值字段并清空多语言字段 用于查询结果直接返回本地语言的内容
+func WithFlatten() ProjectOption {
+	return func(o *projectOptions) {
+		o.flatten = true
+	}
+}
+
+// LocalContent 获取请求语言的内容 按 MatchContent 匹配, 匹配不到或内容为空时返回值字段的内容 base
+func LocalContent[T any](ctx context.Context, base T, i18n map[string]T, langs ...string) T {
+	v, ok := MatchContent(i18n, getLangs(ctx, langs))
+	if !ok || reflect.ValueOf(&v).Elem().IsZero() {
+		return base
+	}
+	return v
+}
+
+// ProjectI18n 将多语言字段(xxxI18n, map[语言]值)投影到请求的语言 直接修改传入的值
+// v 为 ent 实体、proto 消息或其切片, 递归处理嵌套的结构、消息与 ent 的 Edges
+// 默认多语言字段仅保留匹配的语言, 匹配不到或内容为空时清空, 客户端使用值字段的默认内容
+// WithFlatten 时匹配的翻译写入值字段, 多语言字段清空
+func ProjectI18n(ctx context.Context, v any, opts ...ProjectOption) {
+	o := &projectOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	p := &projector{projectOptions: o, visited: map[visitKey]bool{}}
+	if len(p.langs) == 0 {
+		p.langs = GetAcceptArr(ctx)
+	}
+	if msg, ok := v.(proto.Message); ok {
+		p.message(msg.ProtoReflect())
+		return
+	}
+	p.value(reflect.ValueOf(v))
+}
+
+// projector 多语言字段的投影
+type projector struct {
+	*projectOptions
+	// visited 已处理的指针 避免循环引用
+	visited map[visitKey]bool
+}
+
+// visitKey 结构的首个字段与结构的地址相同 需同时比较类型
+type visitKey struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+var protoMessageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+
+// value 处理 Go 的值 仅处理导出的字段
+func (p *projector) value(rv reflect.Value) {
+	switch rv.Kind() {
+	case reflect.Interface:
+		if !rv.IsNil() {
+			p.value(rv.Elem())
+		}
+	case reflect.Pointer:
+		key := visitKey{typ: rv.Type(), ptr: rv.Pointer()}
+		if rv.IsNil() || p.visited[key] {
+			return
+		}
+		p.visited[key] = true
+		if rv.Type().Implements(protoMessageType) {
+			p.message(rv.Interface().(proto.Message).ProtoReflect())
+			return
+		}
+		p.value(rv.Elem())
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			p.value(rv.Index(i))
+		}
+	case reflect.Struct:
+		if rv.CanAddr() && reflect.PointerTo(rv.Type()).Implements(protoMessageType) {
+			p.value(rv.Addr())
+			return
+		}
+		p.structFields(rv)
+	}
+}
+
+func (p *projector) structFields(rv reflect.Value) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+		if base, ok := i18nBaseField(rv, sf); ok && fv.CanSet() {
+			p.structField(fv, base)
+			continue
+		}
+		p.value(fv)
+	}
+}
+
+// i18nBaseField 多语言字段对应的值字段 多语言字段为 map[string]T, 值字段类型为 T
+// ent 生成的字段名为 TitleI18n, protoc-gen-go 生成的为 TitleI18N
+func i18nBaseField(rv reflect.Value, sf reflect.StructField) (reflect.Value, bool) {
+	if sf.Type.Kind() != reflect.Map || sf.Type.Key().Kind() != reflect.String {
+		return reflect.Value{}, false
+	}
+	name, ok := strings.CutSuffix(sf.Name, I18nFieldSuffix)
+	if !ok {
+		name, ok = strings.CutSuffix(sf.Name, strings.ToUpper(I18nFieldSuffix))
+	}
+	if !ok || name == "" {
+		return reflect.Value{}, false
+	}
+	base := rv.FieldByName(name)
+	if !base.IsValid() || base.Type() != sf.Type.Elem() {
+		return reflect.Value{}, false
+	}
+	return base, true
+}
+
+func (p *projector) structField(fv, base reflect.Value) {
+	contents := make(map[string]reflect.Value, fv.Len())
+	iter := fv.MapRange()
+	for iter.Next() {
+		contents[iter.Key().String()] = iter.Value()
+	}
+	key, ok := matchKey(contents, p.langs)
+	matched := ok && !contents[key].IsZero()
+	switch {
+	case !matched:
+		fv.Set(reflect.Zero(fv.Type()))
+	case p.flatten:
+		base.Set(contents[key])
+		fv.Set(reflect.Zero(fv.Type()))
+	default:
+		m := reflect.MakeMapWithSize(fv.Type(), 1)
+		m.SetMapIndex(reflect.ValueOf(key).Convert(fv.Type().Key()), contents[key])
+		fv.Set(m)
+	}
+}
+
+// matchKey 按 MatchContent 匹配内容的语言
+func matchKey[T any](contents map[string]T, langs []string) (string, bool) {
+	keys := make(map[string]string, len(contents))
+	for k := range contents {
+		keys[k] = k
+	}
+	return MatchContent(keys, langs)
+}
+
+// message 处理 proto 消息
+func (p *projector) message(m protoreflect.Message) {
+	if !m.IsValid() {
+		return
+	}
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if base := i18nBaseDescriptor(fd); base != nil {
+			p.messageField(m, fd, base)
+		}
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.IsMap() || (fd.Kind() != protoreflect.MessageKind && fd.Kind() != protoreflect.GroupKind) {
+			return true
+		}
+		if fd.IsList() {
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				p.message(list.Get(i).Message())
+			}
+			return true
+		}
+		p.message(v.Message())
+		return true
+	})
+}
+
+// i18nBaseDescriptor 多语言字段对应的值字段 多语言字段为 map<string, T>, JSON 名为 值字段的 JSON 名 + I18n
+func i18nBaseDescriptor(fd protoreflect.FieldDescriptor) protoreflect.FieldDescriptor {
+	if !fd.IsMap() || fd.MapKey().Kind() != protoreflect.StringKind {
+		return nil
+	}
+	name, ok := strings.CutSuffix(fd.JSONName(), I18nFieldSuffix)
+	if !ok || name == "" {
+		return nil
+	}
+	base := fd.ContainingMessage().Fields().ByJSONName(name)
+	if base == nil || base.Cardinality() == protoreflect.Repeated || base.Kind() != fd.MapValue().Kind() {
+		return nil
+	}
+	if base.Message() != nil && base.Message().FullName() != fd.MapValue().Message().FullName() {
+		return nil
+	}
+	return base
+}
+
+func (p *projector) messageField(m protoreflect.Message, fd, base protoreflect.FieldDescriptor) {
+	if !m.Has(fd) {
+		return
+	}
+	contents := map[string]protoreflect.Value{}
+	m.Get(fd).Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+		contents[k.String()] = v
+		return true
+	})
+	key, ok := matchKey(contents, p.langs)
+	v := contents[key]
+	matched := ok && !isEmptyValue(base, v)
+	m.Clear(fd)
+	switch {
+	case !matched:
+	case p.flatten:
+		m.Set(base, v)
+	default:
+		m.Mutable(fd).Map().Set(protoreflect.ValueOfString(key).MapKey(), v)
+	}
+}
+
+// isEmptyValue 字段的值是否为空
+func isEmptyValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return v.String() == ""
+	case protoreflect.BytesKind:
+		return len(v.Bytes()) == 0
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return !v.Message().IsValid()
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package lang
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// article ent 生成的实体
+type article struct {
+	Title      string
+	TitleI18n  map[string]string
+	Cover      string
+	CoverI18n  map[string]string
+	Extra      *structpb.Struct
+	ExtraI18n  map[string]*structpb.Struct
+	Edges      articleEdges
+	selectI18n map[string]string
+}
+
+type articleEdges struct {
+	Children []*article
+}
+
+func TestLocalContent(t *testing.T) {
+	ctx := WithLocale(context.Background(), language.MustParse("zh-CN"))
+	i18n := map[string]string{"en": "Hello", "zh": "你好", "ja": ""}
+	assert.Equal(t, "你好", LocalContent(ctx, "默认", i18n))
+	assert.Equal(t, "Hello", LocalContent(ctx, "默认", i18n, "en-US"))
+	assert.Equal(t, "默认", LocalContent(ctx, "默认", i18n, "ja"))
+	assert.Equal(t, "默认", LocalContent(ctx, "默认", i18n, "fr"))
+	assert.Equal(t, "默认", LocalContent(ctx, "默认", nil))
+}
+
+func TestProjectI18n(t *testing.T) {
+	ctx := WithLocale(context.Background(), language.MustParse("zh-CN"))
+	newArticle := func() *article {
+		return &article{
+			Title:     "Title",
+			TitleI18n: map[string]string{"en": "Title", "zh": "标题"},
+			Cover:     "cover.png",
+			CoverI18n: map[string]string{"zh": ""},
+			Extra:     &structpb.Struct{},
+			ExtraI18n: map[string]*structpb.Struct{"zh-CN": {Fields: map[string]*structpb.Value{"k": structpb.NewStringValue("v")}}},
+			Edges: articleEdges{Children: []*article{
+				{Title: "Child", TitleI18n: map[string]string{"zh": "子标题"}},
+			}},
+			selectI18n: map[string]string{"zh": "不处理"},
+		}
+	}
+
+	a := newArticle()
+	ProjectI18n(ctx, a)
+	assert.Equal(t, "Title", a.Title)
+	assert.Equal(t, map[string]string{"zh": "标题"}, a.TitleI18n)
+	// 内容为空时使用值字段
+	assert.Nil(t, a.CoverI18n)
+	assert.Len(t, a.ExtraI18n, 1)
+	assert.Equal(t, map[string]string{"zh": "子标题"}, a.Edges.Children[0].TitleI18n)
+	assert.Len(t, a.selectI18n, 1)
+
+	list := []*article{newArticle(), newArticle()}
+	ProjectI18n(ctx, list, WithFlatten())
+	for _, a := range list {
+		assert.Equal(t, "标题", a.Title)
+		assert.Nil(t, a.TitleI18n)
+		assert.Equal(t, "cover.png", a.Cover)
+		assert.Equal(t, "v", a.Extra.Fields["k"].GetStringValue())
+		assert.Equal(t, "子标题", a.Edges.Children[0].Title)
+	}
+
+	a = newArticle()
+	ProjectI18n(ctx, a, WithFlatten(), WithProjectLangs("fr"))
+	assert.Equal(t, "Title", a.Title)
+	assert.Nil(t, a.TitleI18n)
+}
+
+// articleDescriptor article.v1.Article { string title; map<string, string> title_i18n; repeated Article children }
+func articleDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("article.proto"),
+		Package: proto.String("article.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Article"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("title"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+				{Name: proto.String("title_i18n"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(), TypeName: proto.String(".article.v1.Article.TitleI18nEntry")},
+				{Name: proto.String("children"), Number: proto.Int32(3), Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(), TypeName: proto.String(".article.v1.Article")},
+			},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("TitleI18nEntry"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("key"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+					{Name: proto.String("value"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+				},
+				Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+			}},
+		}},
+	}
+	fd, err := protodesc.NewFile(fdp, nil)
+	assert.NoError(t, err)
+	return fd.Messages().Get(0)
+}
+
+func TestProjectI18nProto(t *testing.T) {
+	md := articleDescriptor(t)
+	fields := md.Fields()
+	newArticle := func(title string, i18n map[string]string) *dynamicpb.Message {
+		m := dynamicpb.NewMessage(md)
+		m.Set(fields.ByName("title"), protoreflect.ValueOfString(title))
+		mv := m.Mutable(fields.ByName("title_i18n")).Map()
+		for k, v := range i18n {
+			mv.Set(protoreflect.ValueOfString(k).MapKey(), protoreflect.ValueOfString(v))
+		}
+		return m
+	}
+	i18n := func(m protoreflect.Message) map[string]string {
+		res := map[string]string{}
+		m.Get(fields.ByName("title_i18n")).Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			res[k.String()] = v.String()
+			return true
+		})
+		return res
+	}
+
+	ctx := WithLocale(context.Background(), language.MustParse("en-GB"))
+	m := newArticle("标题", map[string]string{"en": "Title", "zh": "标题"})
+	child := newArticle("子标题", map[string]string{"en-GB": "Child", "en": "Child (US)"})
+	m.Mutable(fields.ByName("children")).List().Append(protoreflect.ValueOfMessage(child))
+
+	ProjectI18n(ctx, m)
+	assert.Equal(t, map[string]string{"en": "Title"}, i18n(m))
+	assert.Equal(t, map[string]string{"en-GB": "Child"}, i18n(child))
+
+	m = newArticle("标题", map[string]string{"en": "Title"})
+	ProjectI18n(ctx, m, WithFlatten())
+	assert.Equal(t, "Title", m.Get(fields.ByName("title")).String())
+	assert.False(t, m.Has(fields.ByName("title_i18n")))
+
+	m = newArticle("标题", map[string]string{"en": "Title"})
+	ProjectI18n(ctx, m, WithFlatten(), WithProjectLangs("zh"))
+	assert.Equal(t, "标题", m.Get(fields.ByName("title")).String())
+	assert.False(t, m.Has(fields.ByName("title_i18n")))
+}
@@ -17,11 +17,41 @@ func (c *CommonLang) GetValidateErrorMsg(ctx context.Context, violation *protova
 		return "", false
 	}
 	templateData := map[string]interface{}{
-		"Field":     violation.FieldValue.String(),
-		"FieldDesc": violation.FieldDescriptor.Name(),
-		"Rule":      violation.RuleValue.String(),
-		"RuleDesc":  violation.RuleDescriptor.Name(),
-		"Message":   violation.Proto.GetMessage(),
+		"Field":      violation.FieldValue.String(),
+		"FieldLabel": c.GetValidateFieldLabel(ctx, violation, langs...),
+		"FieldPath":  protovalidate.FieldPathString(violation.Proto.GetField()),
+		"Rule":       violation.RuleValue.String(),
+		"Message":    violation.Proto.GetMessage(),
+	}
+	// 自定义规则(如 validation 包的预定义规则)没有规则描述
+	if violation.FieldDescriptor != nil {
+		templateData["FieldDesc"] = violation.FieldDescriptor.Name()
+	}
+	if violation.RuleDescriptor != nil {
+		templateData["RuleDesc"] = violation.RuleDescriptor.Name()
+	}
+	// 枚举值的本地化名称
+	if fd := violation.FieldDescriptor; fd != nil && fd.Enum() != nil && !fd.IsList() && !fd.IsMap() && violation.FieldValue.IsValid() {
+		templateData["ValueLabel"] = c.GetEnumLabel(ctx, fd.Enum(), violation.FieldValue.Enum(), langs...)
 	}
 	return c.getMsg(ctx, msgKey, templateData, langs...), true
 }
+
+// GetValidateFieldLabel 获取验证失败字段的本地化名称 依次查找字段全名、字段名的字典 未配置时返回字段名
+func (c *CommonLang) GetValidateFieldLabel(ctx context.Context, violation *protovalidate.Violation, langs ...string) string {
+	names := []string{}
+	if violation.FieldDescriptor != nil {
+		names = append(names, string(violation.FieldDescriptor.FullName()), string(violation.FieldDescriptor.Name()))
+	} else if elements := violation.Proto.GetField().GetElements(); len(elements) > 0 {
+		names = append(names, elements[len(elements)-1].GetFieldName())
+	}
+	for _, name := range names {
+		if label, ok := c.GetFieldLabel(ctx, name, langs...); ok {
+			return label
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return names[len(names)-1]
+}
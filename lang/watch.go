@@ -0,0 +1,88 @@
+package lang
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDelay 文件变更后重新加载的延迟 合并短时间内的多次变更
+const reloadDelay = 200 * time.Millisecond
+
+// watcher 监听消息文件的目录
+type watcher struct {
+	w    *fsnotify.Watcher
+	done chan struct{}
+	once sync.Once
+}
+
+// newWatcher 监听目录及其子目录 消息文件变更时调用 onChange
+func newWatcher(dirs []string, onChange func()) (*watcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range dirs {
+		err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || !d.IsDir() {
+				return err
+			}
+			return w.Add(p)
+		})
+		if err != nil {
+			_ = w.Close()
+			return nil, err
+		}
+	}
+	fw := &watcher{w: w, done: make(chan struct{})}
+	go fw.run(onChange)
+	return fw, nil
+}
+
+func (fw *watcher) run(onChange func()) {
+	var timer *time.Timer
+	for {
+		select {
+		case <-fw.done:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		case e, ok := <-fw.w.Events:
+			if !ok {
+				return
+			}
+			// 新建的子目录加入监听
+			if e.Has(fsnotify.Create) {
+				if fi, err := os.Stat(e.Name); err == nil && fi.IsDir() {
+					_ = fw.w.Add(e.Name)
+				}
+			}
+			if !isMessageFile(e.Name) || e.Op == fsnotify.Chmod {
+				continue
+			}
+			if timer == nil {
+				timer = time.AfterFunc(reloadDelay, onChange)
+			} else {
+				timer.Reset(reloadDelay)
+			}
+		case _, ok := <-fw.w.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+func (fw *watcher) close() error {
+	var err error
+	fw.once.Do(func() {
+		close(fw.done)
+		err = fw.w.Close()
+	})
+	return err
+}
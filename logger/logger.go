@@ -22,28 +22,28 @@ func getCtx() log.Valuer {
 }
 
 // GetLogger _
-func GetLogger(conf *config.Config) log.Logger {
+func GetLogger(conf *config.Config, opts ...Option) log.Logger {
 	logger := conf.Logger
 	if logger == nil {
-		return GetStdLogger(conf)
+		return GetStdLogger(conf, opts...)
 	}
 	if logger.Provider == "tencent" {
-		return GetTencentLogger(conf)
+		return GetTencentLogger(conf, opts...)
 	}
 	if logger.Provider == "otel" {
-		return GetOtelLogger(conf)
+		return GetOtelLogger(conf, opts...)
 	}
-	return GetStdLogger(conf)
+	return GetStdLogger(conf, opts...)
 }
 
 // GetStdLogger _
-func GetStdLogger(conf *config.Config) log.Logger {
+func GetStdLogger(conf *config.Config, opts ...Option) log.Logger {
 	logger := log.NewStdLogger(os.Stdout)
-	return getLogger(conf.Server, logger, conf.Logger, false)
+	return getLogger(conf.Server, logger, conf.Logger, false, opts...)
 }
 
 // GetLogger _
-func getLogger(service *config.Server, logger log.Logger, loggerConf *config.Logger, isWithCtx bool) log.Logger {
+func getLogger(service *config.Server, logger log.Logger, loggerConf *config.Logger, isWithCtx bool, opts ...Option) log.Logger {
 	// 应用日志过滤
 	if loggerConf != nil {
 		var filterOptions []log.FilterOption
@@ -97,6 +97,15 @@ func getLogger(service *config.Server, logger log.Logger, loggerConf *config.Log
 		}
 	}
 
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	// 脱敏策略
+	if o.mask != nil {
+		logger = log.NewFilter(logger, FilterMask(o.mask))
+	}
+
 	kvs := []any{
 		"ts", log.DefaultTimestamp,
 		"caller", log.Caller(8),
@@ -0,0 +1,46 @@
+// Package logger mask.go
+package logger
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/yimoka/go/mask"
+	"google.golang.org/protobuf/proto"
+)
+
+// Option 日志配置
+type Option func(*options)
+
+type options struct {
+	mask *mask.Policy
+}
+
+// WithMask 使用脱敏策略处理日志 与接口响应使用同一策略
+func WithMask(policy *mask.Policy) Option {
+	return func(o *options) {
+		o.mask = policy
+	}
+}
+
+// FilterMask 使用脱敏策略的日志过滤
+// key 匹配字段规则时按类型脱敏, proto 消息脱敏其副本, 其他值按值的正则脱敏; 日志没有用户信息, 不会按权限显示原值
+func FilterMask(policy *mask.Policy) log.FilterOption {
+	return log.FilterFunc(func(_ log.Level, keyvals ...any) bool {
+		for i := 1; i < len(keyvals); i += 2 {
+			switch v := keyvals[i].(type) {
+			case nil:
+			case proto.Message:
+				msg := proto.Clone(v)
+				policy.Mask(context.Background(), msg)
+				keyvals[i] = msg
+			default:
+				str := toString(v)
+				if masked := policy.MaskField(context.Background(), toString(keyvals[i-1]), str); masked != str {
+					keyvals[i] = masked
+				}
+			}
+		}
+		return false
+	})
+}
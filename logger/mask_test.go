@@ -0,0 +1,38 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/config"
+	"github.com/yimoka/go/mask"
+	"github.com/yimoka/go/utils"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestFilterMask(t *testing.T) {
+	policy, err := mask.NewPolicy([]mask.Rule{
+		{Fields: []string{"phone", "value"}, Type: utils.MaskTypePhone},
+		{ValuePattern: `1[3-9]\d{9}`, Type: utils.MaskTypePhone},
+	})
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	logger := log.NewFilter(log.NewStdLogger(buf), FilterMask(policy))
+	msg := wrapperspb.String("13800138000")
+	_ = logger.Log(log.LevelInfo, "phone", "13800138000", "msg", "call 13900139000", "req", msg, "n", 1)
+	out := buf.String()
+	assert.Contains(t, out, "phone=138****8000")
+	assert.Contains(t, out, "msg=call 139****9000")
+	assert.Contains(t, out, "138****8000")
+	assert.NotContains(t, out, "13800138000")
+	// 不修改原消息
+	assert.Equal(t, "13800138000", msg.GetValue())
+
+	l := getLogger(&config.Server{}, log.NewStdLogger(buf), nil, false, WithMask(policy))
+	buf.Reset()
+	_ = l.Log(log.LevelInfo, "phone", "13800138000")
+	assert.Contains(t, buf.String(), "phone=138****8000")
+}
@@ -19,12 +19,12 @@ import (
 )
 
 // GetOtelLogger _
-func GetOtelLogger(conf *config.Config) log.Logger {
+func GetOtelLogger(conf *config.Config, opts ...Option) log.Logger {
 	otelLogger, err := NewOtelLogger(conf, conf.Server)
 	if err != nil {
 		panic(fmt.Sprintf("init otel logger error: %v", err))
 	}
-	return getLogger(conf.Server, otelLogger, conf.Logger, true)
+	return getLogger(conf.Server, otelLogger, conf.Logger, true, opts...)
 }
 
 // OtelLogger _
@@ -12,13 +12,13 @@ import (
 )
 
 // GetTencentLogger _
-func GetTencentLogger(conf *config.Config) log.Logger {
+func GetTencentLogger(conf *config.Config, opts ...Option) log.Logger {
 	tencentLogger, err := NewTencentLogger(conf)
 	if err != nil {
 		panic(fmt.Sprintf("init tencent logger error: %v", err))
 	}
 
-	return getLogger(conf.Server, tencentLogger, conf.Logger, false)
+	return getLogger(conf.Server, tencentLogger, conf.Logger, false, opts...)
 }
 
 // TencentLogger _
@@ -0,0 +1,236 @@
+// Package mask 数据脱敏策略
+// 以字段名、字段名正则、proto 字段选项或值的正则声明脱敏规则, 统一用于接口响应与日志, 拥有权限的用户可查看原值
+package mask
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/yimoka/go/utils"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Rule 脱敏规则 按声明顺序匹配, 第一个匹配的规则生效
+// Fields、FieldPattern、Option 匹配字段, ValuePattern 匹配未命中字段规则的字符串值
+type Rule struct {
+	// 字段名 proto 字段名、JSON 名或全名(如 user.v1.User.phone) 日志中为 key
+	Fields []string
+	// 字段名的正则
+	FieldPattern string
+	// 字段选项的扩展 字段设置了该扩展时匹配, 扩展值为非空字符串时作为脱敏类型
+	Option protoreflect.ExtensionType
+	// 值的正则 匹配的部分按类型脱敏 用于无法按字段识别的文本 如日志消息
+	ValuePattern string
+	// 脱敏类型
+	Type utils.MaskType
+	// 拥有该权限时不脱敏 为空时对所有人脱敏
+	Permission string
+}
+
+type rule struct {
+	*Rule
+	fields  map[string]bool
+	fieldRe *regexp.Regexp
+	valueRe *regexp.Regexp
+}
+
+// matchName 字段名是否匹配
+func (r *rule) matchName(names ...string) bool {
+	for _, name := range names {
+		if r.fields[name] || (r.fieldRe != nil && r.fieldRe.MatchString(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchField 字段是否匹配 返回脱敏类型
+func (r *rule) matchField(fd protoreflect.FieldDescriptor) (utils.MaskType, bool) {
+	if r.matchName(string(fd.Name()), fd.JSONName(), string(fd.FullName())) {
+		return r.Type, true
+	}
+	if r.Option == nil || fd.Options() == nil || !proto.HasExtension(fd.Options(), r.Option) {
+		return "", false
+	}
+	if s, ok := proto.GetExtension(fd.Options(), r.Option).(string); ok && s != "" {
+		return utils.MaskType(s), true
+	}
+	return r.Type, true
+}
+
+// Option 配置
+type Option func(*Policy)
+
+// WithPermissionChecker 设置权限检查 用于拥有规则的 Permission 时查看原值
+func WithPermissionChecker(checker func(ctx context.Context, permission string) bool) Option {
+	return func(p *Policy) {
+		p.checker = checker
+	}
+}
+
+// Policy 脱敏策略 创建后并发安全
+type Policy struct {
+	rules      []*rule
+	valueRules []*rule
+	checker    func(ctx context.Context, permission string) bool
+	// 字段全名 -> *fieldRule
+	fields sync.Map
+}
+
+// fieldRule 字段匹配的规则 rule 为空时表示未匹配
+type fieldRule struct {
+	rule     *rule
+	maskType utils.MaskType
+}
+
+// NewPolicy 创建脱敏策略 正则在创建时编译
+func NewPolicy(rules []Rule, opts ...Option) (*Policy, error) {
+	p := &Policy{}
+	for i := range rules {
+		r := &rule{Rule: &rules[i], fields: map[string]bool{}}
+		for _, f := range r.Fields {
+			r.fields[f] = true
+		}
+		var err error
+		if r.FieldPattern != "" {
+			if r.fieldRe, err = regexp.Compile(r.FieldPattern); err != nil {
+				return nil, fmt.Errorf("mask: invalid field pattern %q: %w", r.FieldPattern, err)
+			}
+		}
+		if r.ValuePattern != "" {
+			if r.valueRe, err = regexp.Compile(r.ValuePattern); err != nil {
+				return nil, fmt.Errorf("mask: invalid value pattern %q: %w", r.ValuePattern, err)
+			}
+			p.valueRules = append(p.valueRules, r)
+		}
+		p.rules = append(p.rules, r)
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p, nil
+}
+
+// canUnmask 当前用户是否可查看原值
+func (p *Policy) canUnmask(ctx context.Context, r *rule) bool {
+	return r.Permission != "" && p.checker != nil && p.checker(ctx, r.Permission)
+}
+
+// MaskField 按字段名脱敏 用于日志等 key/value 结构, 未匹配字段规则时按值的正则脱敏
+func (p *Policy) MaskField(ctx context.Context, name string, value string) string {
+	if value == "" {
+		return value
+	}
+	for _, r := range p.rules {
+		if r.matchName(name) {
+			if p.canUnmask(ctx, r) {
+				return value
+			}
+			return utils.Mask(value, r.Type)
+		}
+	}
+	return p.MaskText(ctx, value)
+}
+
+// MaskText 按值的正则脱敏文本中匹配的部分
+func (p *Policy) MaskText(ctx context.Context, str string) string {
+	for _, r := range p.valueRules {
+		if str == "" || !r.valueRe.MatchString(str) || p.canUnmask(ctx, r) {
+			continue
+		}
+		str = r.valueRe.ReplaceAllStringFunc(str, func(s string) string { return utils.Mask(s, r.Type) })
+	}
+	return str
+}
+
+// Mask 脱敏 proto 消息 直接修改传入的消息, 包括嵌套消息、列表、map 的值与 StringValue
+// 消息可能被共享(如缓存)时请先 proto.Clone
+func (p *Policy) Mask(ctx context.Context, msg proto.Message) {
+	if msg == nil {
+		return
+	}
+	p.maskMessage(ctx, msg.ProtoReflect())
+}
+
+func (p *Policy) maskMessage(ctx context.Context, m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		fr := p.fieldRule(fd)
+		switch {
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				if nv, ok := p.maskValue(ctx, fd, fr, list.Get(i)); ok {
+					list.Set(i, nv)
+				}
+			}
+		case fd.IsMap():
+			mv := v.Map()
+			mv.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				if nv, ok := p.maskValue(ctx, fd.MapValue(), fr, v); ok {
+					mv.Set(k, nv)
+				}
+				return true
+			})
+		default:
+			if nv, ok := p.maskValue(ctx, fd, fr, v); ok {
+				m.Set(fd, nv)
+			}
+		}
+		return true
+	})
+}
+
+// maskValue 脱敏字段的值 返回新值与是否需要设置 消息类型直接修改
+func (p *Policy) maskValue(ctx context.Context, fd protoreflect.FieldDescriptor, fr *fieldRule, v protoreflect.Value) (protoreflect.Value, bool) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		if s, ok := p.maskString(ctx, fr, v.String()); ok {
+			return protoreflect.ValueOfString(s), true
+		}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		msg := v.Message()
+		// StringValue 按所在字段的规则脱敏
+		if fr.rule != nil && msg.Descriptor().FullName() == "google.protobuf.StringValue" {
+			vf := msg.Descriptor().Fields().ByName("value")
+			if s, ok := p.maskString(ctx, fr, msg.Get(vf).String()); ok {
+				msg.Set(vf, protoreflect.ValueOfString(s))
+			}
+			return v, false
+		}
+		p.maskMessage(ctx, msg)
+	}
+	return v, false
+}
+
+func (p *Policy) maskString(ctx context.Context, fr *fieldRule, s string) (string, bool) {
+	if s == "" {
+		return s, false
+	}
+	if fr.rule == nil {
+		masked := p.MaskText(ctx, s)
+		return masked, masked != s
+	}
+	if p.canUnmask(ctx, fr.rule) {
+		return s, false
+	}
+	return utils.Mask(s, fr.maskType), true
+}
+
+// fieldRule 获取字段匹配的规则 按字段全名缓存
+func (p *Policy) fieldRule(fd protoreflect.FieldDescriptor) *fieldRule {
+	if v, ok := p.fields.Load(fd.FullName()); ok {
+		return v.(*fieldRule)
+	}
+	fr := &fieldRule{}
+	for _, r := range p.rules {
+		if t, ok := r.matchField(fd); ok {
+			fr.rule, fr.maskType = r, t
+			break
+		}
+	}
+	p.fields.Store(fd.FullName(), fr)
+	return fr
+}
@@ -0,0 +1,142 @@
+package mask
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/utils"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// newUserDesc 构造测试用的消息 note 字段设置了 (test.mask) = "idCard" 选项
+func newUserDesc(t *testing.T) (protoreflect.MessageDescriptor, protoreflect.ExtensionType) {
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	msg := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+	opt := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	rep := descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("test/mask.proto"),
+		Package:    proto.String("test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/descriptor.proto", "google/protobuf/wrappers.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("User"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("name"), Number: proto.Int32(1), Type: str, Label: opt, JsonName: proto.String("name")},
+					{Name: proto.String("mobile_phone"), Number: proto.Int32(2), Type: str, Label: opt, JsonName: proto.String("mobilePhone")},
+					{Name: proto.String("ips"), Number: proto.Int32(3), Type: str, Label: rep, JsonName: proto.String("ips")},
+					{Name: proto.String("attrs"), Number: proto.Int32(4), Type: msg, Label: rep, TypeName: proto.String(".test.User.AttrsEntry"), JsonName: proto.String("attrs")},
+					{Name: proto.String("addr"), Number: proto.Int32(5), Type: msg, Label: opt, TypeName: proto.String(".test.Address"), JsonName: proto.String("addr")},
+					{Name: proto.String("passport"), Number: proto.Int32(6), Type: msg, Label: opt, TypeName: proto.String(".google.protobuf.StringValue"), JsonName: proto.String("passport")},
+					{Name: proto.String("note"), Number: proto.Int32(7), Type: str, Label: opt, JsonName: proto.String("note")},
+					{Name: proto.String("remark"), Number: proto.Int32(8), Type: str, Label: opt, JsonName: proto.String("remark")},
+				},
+				NestedType: []*descriptorpb.DescriptorProto{{
+					Name: proto.String("AttrsEntry"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						{Name: proto.String("key"), Number: proto.Int32(1), Type: str, Label: opt, JsonName: proto.String("key")},
+						{Name: proto.String("value"), Number: proto.Int32(2), Type: str, Label: opt, JsonName: proto.String("value")},
+					},
+					Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+				}},
+			},
+			{
+				Name: proto.String("Address"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("detail"), Number: proto.Int32(1), Type: str, Label: opt, JsonName: proto.String("detail")},
+				},
+			},
+		},
+		Extension: []*descriptorpb.FieldDescriptorProto{
+			{Name: proto.String("mask"), Number: proto.Int32(50001), Type: str, Label: opt, Extendee: proto.String(".google.protobuf.FieldOptions"), JsonName: proto.String("mask")},
+		},
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	assert.NoError(t, err)
+	xt := dynamicpb.NewExtensionType(fd.Extensions().Get(0))
+
+	options := &descriptorpb.FieldOptions{}
+	proto.SetExtension(options, xt, "idCard")
+	fdp.MessageType[0].Field[6].Options = options
+	fd, err = protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	assert.NoError(t, err)
+	return fd.Messages().ByName("User"), xt
+}
+
+func TestPolicyMask(t *testing.T) {
+	desc, xt := newUserDesc(t)
+	policy, err := NewPolicy([]Rule{
+		{Fields: []string{"name"}, Type: utils.MaskTypeName, Permission: "user.viewName"},
+		{FieldPattern: `(?i)phone$`, Type: utils.MaskTypePhone},
+		{Fields: []string{"test.User.ips"}, Type: utils.MaskTypeIPv4},
+		{Fields: []string{"attrs", "detail"}, Type: utils.MaskTypeAddress},
+		{Fields: []string{"passport"}, Type: utils.MaskTypePassport},
+		{Option: xt, Type: utils.MaskTypeOther},
+		{ValuePattern: `1[3-9]\d{9}`, Type: utils.MaskTypePhone},
+	}, WithPermissionChecker(func(ctx context.Context, permission string) bool {
+		return ctx.Value(permissionKey{}) == permission
+	}))
+	assert.NoError(t, err)
+
+	newUser := func() *dynamicpb.Message {
+		m := dynamicpb.NewMessage(desc)
+		fields := desc.Fields()
+		m.Set(fields.ByName("name"), protoreflect.ValueOfString("张三"))
+		m.Set(fields.ByName("mobile_phone"), protoreflect.ValueOfString("13800138000"))
+		ips := m.Mutable(fields.ByName("ips")).List()
+		ips.Append(protoreflect.ValueOfString("192.168.1.10"))
+		attrs := m.Mutable(fields.ByName("attrs")).Map()
+		attrs.Set(protoreflect.ValueOfString("home").MapKey(), protoreflect.ValueOfString("广东省深圳市南山区科技园"))
+		addr := m.Mutable(fields.ByName("addr")).Message()
+		addr.Set(addr.Descriptor().Fields().ByName("detail"), protoreflect.ValueOfString("北京市海淀区中关村大街"))
+		m.Set(fields.ByName("passport"), protoreflect.ValueOfMessage(wrapperspb.String("E12345678").ProtoReflect()))
+		m.Set(fields.ByName("note"), protoreflect.ValueOfString("440101199001011234"))
+		m.Set(fields.ByName("remark"), protoreflect.ValueOfString("联系 13900139000"))
+		return m
+	}
+
+	m := newUser()
+	policy.Mask(context.Background(), m)
+	fields := desc.Fields()
+	assert.Equal(t, "张*", m.Get(fields.ByName("name")).String())
+	assert.Equal(t, "138****8000", m.Get(fields.ByName("mobile_phone")).String())
+	assert.Equal(t, "192.168.*.*", m.Get(fields.ByName("ips")).List().Get(0).String())
+	assert.Equal(t, "广东省深圳市****", m.Get(fields.ByName("attrs")).Map().Get(protoreflect.ValueOfString("home").MapKey()).String())
+	addr := m.Get(fields.ByName("addr")).Message()
+	assert.Equal(t, "北京市海淀区****", addr.Get(addr.Descriptor().Fields().ByName("detail")).String())
+	passport := m.Get(fields.ByName("passport")).Message()
+	assert.Equal(t, "E*****678", passport.Get(passport.Descriptor().Fields().ByName("value")).String())
+	assert.Equal(t, "4401**********1234", m.Get(fields.ByName("note")).String())
+	assert.Equal(t, "联系 139****9000", m.Get(fields.ByName("remark")).String())
+
+	// 拥有权限时查看原值
+	m = newUser()
+	policy.Mask(context.WithValue(context.Background(), permissionKey{}, "user.viewName"), m)
+	assert.Equal(t, "张三", m.Get(fields.ByName("name")).String())
+	assert.Equal(t, "138****8000", m.Get(fields.ByName("mobile_phone")).String())
+}
+
+type permissionKey struct{}
+
+func TestPolicyMaskField(t *testing.T) {
+	policy, err := NewPolicy([]Rule{
+		{Fields: []string{"phone"}, Type: utils.MaskTypePhone},
+		{ValuePattern: `\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}`, Type: utils.MaskTypeIPv4},
+	})
+	assert.NoError(t, err)
+	ctx := context.Background()
+	assert.Equal(t, "138****8000", policy.MaskField(ctx, "phone", "13800138000"))
+	assert.Equal(t, "from 10.0.*.* ok", policy.MaskField(ctx, "msg", "from 10.0.0.1 ok"))
+	assert.Equal(t, "hello", policy.MaskField(ctx, "msg", "hello"))
+
+	_, err = NewPolicy([]Rule{{FieldPattern: "("}})
+	assert.Error(t, err)
+}
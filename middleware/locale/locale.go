@@ -0,0 +1,65 @@
+// Package locale 提供请求语言协商的中间件
+package locale
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/yimoka/go/lang"
+	"github.com/yimoka/go/middleware/meta"
+	"golang.org/x/text/language"
+)
+
+// Option 配置
+type Option func(*options)
+
+type options struct {
+	supported []language.Tag
+	resolvers []lang.Resolver
+}
+
+// WithSupported 设置支持的语言 第一个为默认语言
+func WithSupported(tags ...language.Tag) Option {
+	return func(o *options) {
+		o.supported = tags
+	}
+}
+
+// WithCommonLang 使用公共语言包支持的语言 在创建中间件时读取
+func WithCommonLang(l *lang.CommonLang) Option {
+	return func(o *options) {
+		o.supported = l.LanguageTags()
+	}
+}
+
+// WithResolvers 设置语言的来源 按顺序解析, 默认为 lang.DefaultResolvers
+func WithResolvers(resolvers ...lang.Resolver) Option {
+	return func(o *options) {
+		o.resolvers = resolvers
+	}
+}
+
+// Server 协商请求的语言 每个请求只协商一次
+// 协商的语言存入 ctx(lang.LocaleFromContext) 与元数据 language, 并设置响应头 Content-Language
+// CommonLang 的消息与字典在未指定语言时使用协商的语言
+func Server(opts ...Option) middleware.Middleware {
+	o := &options{resolvers: lang.DefaultResolvers()}
+	for _, opt := range opts {
+		opt(o)
+	}
+	negotiator := lang.NewNegotiator(o.supported...)
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if _, ok := lang.LocaleFromContext(ctx); ok {
+				return handler(ctx, req)
+			}
+			tag := negotiator.Resolve(ctx, o.resolvers...)
+			ctx = lang.WithLocale(ctx, tag)
+			ctx = meta.SetLanguage(ctx, tag.String())
+			if h, ok := meta.GetReplyHeader(ctx); ok {
+				h.Set("Content-Language", tag.String())
+			}
+			return handler(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package locale
+
+import (
+	"context"
+	nethttp "net/http"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/lang"
+	"github.com/yimoka/go/middleware/meta"
+	"golang.org/x/text/language"
+)
+
+type headerCarrier nethttp.Header
+
+func (h headerCarrier) Get(key string) string { return nethttp.Header(h).Get(key) }
+func (h headerCarrier) Set(key, value string) { nethttp.Header(h).Set(key, value) }
+func (h headerCarrier) Add(key, value string) { nethttp.Header(h).Add(key, value) }
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
+}
+func (h headerCarrier) Values(key string) []string { return nethttp.Header(h).Values(key) }
+
+type testTransport struct {
+	header headerCarrier
+	reply  headerCarrier
+}
+
+func (t *testTransport) Kind() transport.Kind            { return transport.KindGRPC }
+func (t *testTransport) Endpoint() string                { return "" }
+func (t *testTransport) Operation() string               { return "" }
+func (t *testTransport) RequestHeader() transport.Header { return t.header }
+func (t *testTransport) ReplyHeader() transport.Header   { return t.reply }
+
+func TestServer(t *testing.T) {
+	commonLang := lang.NewCommonLang(nil, log.DefaultLogger)
+	tr := &testTransport{header: headerCarrier{}, reply: headerCarrier{}}
+	tr.header.Set("Accept-Language", "ja, zh-CN;q=0.9")
+	newCtx := func() context.Context {
+		return metadata.NewServerContext(transport.NewServerContext(context.Background(), tr), metadata.New())
+	}
+	ctx := newCtx()
+
+	var msg, metaLang string
+	var tag language.Tag
+	var handler = func(ctx context.Context, _ interface{}) (interface{}, error) {
+		tag, _ = lang.LocaleFromContext(ctx)
+		msg = commonLang.GetDataNotFoundMsg(ctx)
+		metaLang, _ = meta.GetLanguage(ctx)
+		return nil, nil
+	}
+	_, err := Server(WithCommonLang(commonLang))(handler)(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, language.Chinese, tag)
+	assert.Equal(t, "找不到数据", msg)
+	assert.Equal(t, "zh", tr.reply.Get("Content-Language"))
+	assert.Equal(t, "zh", metaLang)
+
+	// 已协商的语言不再协商
+	_, err = Server(WithSupported(language.English))(handler)(lang.WithLocale(newCtx(), language.Russian), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, language.Russian, tag)
+
+	_, err = Server(WithSupported(language.English, language.French), WithResolvers(lang.FromHeader()))(handler)(newCtx(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, language.English, tag)
+	assert.Equal(t, "Data not found", msg)
+}
@@ -0,0 +1,29 @@
+// Package mask 提供响应脱敏的中间件
+package mask
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/yimoka/go/mask"
+	"google.golang.org/protobuf/proto"
+)
+
+// Server 按脱敏策略处理 proto 响应
+// 响应先复制再脱敏, 避免修改缓存等共享的数据
+func Server(policy *mask.Policy) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			reply, err := handler(ctx, req)
+			if err != nil {
+				return reply, err
+			}
+			if msg, ok := reply.(proto.Message); ok && msg != nil {
+				msg = proto.Clone(msg)
+				policy.Mask(ctx, msg)
+				return msg, nil
+			}
+			return reply, nil
+		}
+	}
+}
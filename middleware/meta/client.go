@@ -0,0 +1,157 @@
+// Package meta client
+package meta
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+// ClientOption 客户端元数据传递的配置
+type ClientOption func(*clientOptions)
+
+// clientOptions 客户端元数据传递的配置 key 均为不带前缀的名称 如 user-id
+type clientOptions struct {
+	// 允许传递的 key 为空时允许所有 global 元数据
+	allow map[string]struct{}
+	// 禁止传递的 key 优先级高于 allow
+	deny map[string]struct{}
+	// 以 local 范围传递的 key 下游服务可以读取, 但不会再继续向后传递
+	local map[string]struct{}
+}
+
+// WithAllowKeys 设置允许传递的 key, 不设置时传递所有 global 元数据
+func WithAllowKeys(keys ...string) ClientOption {
+	return func(o *clientOptions) {
+		o.allow = toKeySet(o.allow, keys)
+	}
+}
+
+// WithDenyKeys 设置禁止传递的 key
+func WithDenyKeys(keys ...string) ClientOption {
+	return func(o *clientOptions) {
+		o.deny = toKeySet(o.deny, keys)
+	}
+}
+
+// WithLocalKeys 设置以 local 范围传递的 key, 只传递到下一跳服务
+func WithLocalKeys(keys ...string) ClientOption {
+	return func(o *clientOptions) {
+		o.local = toKeySet(o.local, keys)
+	}
+}
+
+func toKeySet(set map[string]struct{}, keys []string) map[string]struct{} {
+	if set == nil {
+		set = make(map[string]struct{}, len(keys))
+	}
+	for _, key := range keys {
+		set[strings.ToLower(key)] = struct{}{}
+	}
+	return set
+}
+
+func newClientOptions(opts ...ClientOption) *clientOptions {
+	o := &clientOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// isAllowed 判断 key 是否允许传递
+func (o *clientOptions) isAllowed(key string) bool {
+	if _, ok := o.deny[key]; ok {
+		return false
+	}
+	if len(o.allow) == 0 {
+		return true
+	}
+	_, ok := o.allow[key]
+	return ok
+}
+
+// isLocal 判断 key 是否以 local 范围传递
+func (o *clientOptions) isLocal(key string) bool {
+	_, ok := o.local[key]
+	return ok
+}
+
+// GetClientMetadata 根据服务端元数据生成调用下游服务时需要传递的元数据
+// 服务端的 global 元数据按 allow/deny 过滤后传递, local 元数据只在本跳有效不再传递
+// 已存在于客户端 ctx 的元数据（如 SetLocalValue/SetClientValue 设置的值）优先级最高
+func GetClientMetadata(ctx context.Context, opts ...ClientOption) metadata.Metadata {
+	return getClientMetadata(ctx, newClientOptions(opts...))
+}
+
+func getClientMetadata(ctx context.Context, o *clientOptions) metadata.Metadata {
+	md := metadata.New()
+	if serverMD, ok := metadata.FromServerContext(ctx); ok {
+		for k, vList := range serverMD {
+			key := strings.ToLower(k)
+			if !strings.HasPrefix(key, globalPrefix) {
+				continue
+			}
+			name := strings.TrimPrefix(key, globalPrefix)
+			if !o.isAllowed(name) {
+				continue
+			}
+			if o.isLocal(name) {
+				key = localPrefix + name
+			}
+			md[key] = append([]string{}, vList...)
+		}
+	}
+	if clientMD, ok := metadata.FromClientContext(ctx); ok {
+		for k, vList := range clientMD {
+			md[strings.ToLower(k)] = append([]string{}, vList...)
+		}
+	}
+	return md
+}
+
+// CopyToClientContext 将服务端元数据复制到客户端 ctx 中
+// 用于不经过 Client 中间件的调用, 如自行构造的客户端或异步任务
+func CopyToClientContext(ctx context.Context, opts ...ClientOption) context.Context {
+	return metadata.NewClientContext(ctx, GetClientMetadata(ctx, opts...))
+}
+
+// SetClientValue 设置只用于调用下游服务的 global 元数据, 不影响当前服务端元数据
+func SetClientValue(ctx context.Context, kv ...string) context.Context {
+	useKV := make([]string, len(kv))
+	for i, v := range kv {
+		if i%2 == 0 {
+			useKV[i] = globalPrefix + v
+		} else {
+			useKV[i] = v
+		}
+	}
+	return metadata.AppendToClientContext(ctx, useKV...)
+}
+
+// Client 客户端元数据中间件 将服务端的 global 元数据按配置传递给下游服务
+// 用于替代 kratos 的 metadata.Client, 两者不要同时使用, 否则元数据会重复
+func Client(opts ...ClientOption) middleware.Middleware {
+	o := newClientOptions(opts...)
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			md := getClientMetadata(ctx, o)
+			if tr, ok := transport.FromClientContext(ctx); ok {
+				header := tr.RequestHeader()
+				for k, vList := range md {
+					for i, v := range vList {
+						if i == 0 {
+							header.Set(k, v)
+							continue
+						}
+						header.Add(k, v)
+					}
+				}
+			}
+			return handler(metadata.NewClientContext(ctx, md), req)
+		}
+	}
+}
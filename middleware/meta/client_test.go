@@ -0,0 +1,72 @@
+package meta
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+func newServerCtx() context.Context {
+	md := metadata.New()
+	md.Set(globalPrefix+userIDKey, "u1")
+	md.Set(globalPrefix+staffIDKey, "s1")
+	md.Set(globalPrefix+languageKey, "zh-CN")
+	md.Set(localPrefix+"hop", "1")
+	md.Set("x-other", "other")
+	return metadata.NewServerContext(context.Background(), md)
+}
+
+func TestGetClientMetadata(t *testing.T) {
+	ctx := newServerCtx()
+
+	md := GetClientMetadata(ctx)
+	assert.Equal(t, "u1", md.Get(globalPrefix+userIDKey))
+	assert.Equal(t, "s1", md.Get(globalPrefix+staffIDKey))
+	assert.Equal(t, "zh-CN", md.Get(globalPrefix+languageKey))
+	// local 与非 x-md 的元数据不再向后传递
+	assert.Equal(t, "", md.Get(localPrefix+"hop"))
+	assert.Equal(t, "", md.Get("x-other"))
+
+	md = GetClientMetadata(ctx, WithAllowKeys(userIDKey, staffIDKey), WithDenyKeys(staffIDKey))
+	assert.Equal(t, "u1", md.Get(globalPrefix+userIDKey))
+	assert.Equal(t, "", md.Get(globalPrefix+staffIDKey))
+	assert.Equal(t, "", md.Get(globalPrefix+languageKey))
+
+	md = GetClientMetadata(ctx, WithLocalKeys(userIDKey))
+	assert.Equal(t, "", md.Get(globalPrefix+userIDKey))
+	assert.Equal(t, "u1", md.Get(localPrefix+userIDKey))
+
+	// 客户端 ctx 中显式设置的值优先
+	ctx = SetClientValue(ctx, userIDKey, "u2")
+	ctx = SetLocalValue(ctx, "hop", "2")
+	md = GetClientMetadata(ctx)
+	assert.Equal(t, "u2", md.Get(globalPrefix+userIDKey))
+	assert.Equal(t, "2", md.Get(localPrefix+"hop"))
+}
+
+func TestGetNewCtx(t *testing.T) {
+	ctx, cancel := context.WithTimeout(newServerCtx(), time.Minute)
+	newCtx := GetNewCtx(ctx)
+	cancel()
+	assert.NoError(t, newCtx.Err())
+	userID, err := GetUserID(newCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, "u1", userID)
+
+	// 修改新 ctx 的元数据不影响原 ctx
+	SetUserID(newCtx, "u3")
+	userID, _ = GetUserID(ctx)
+	assert.Equal(t, "u1", userID)
+
+	ctx, cancel = context.WithTimeout(newServerCtx(), time.Minute)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+	newCtx, newCancel := GetNewCtxWithDeadline(ctx)
+	defer newCancel()
+	newDeadline, ok := newCtx.Deadline()
+	assert.True(t, ok)
+	assert.Equal(t, deadline, newDeadline)
+}
@@ -27,6 +27,26 @@ func SetAuth(ctx context.Context, auth string) context.Context {
 	return SetValue(ctx, authKey, auth)
 }
 
+// GetLanguage 获取语言
+func GetLanguage(ctx context.Context) (string, *errors.Error) {
+	return GetValue(ctx, languageKey)
+}
+
+// SetLanguage 设置语言
+func SetLanguage(ctx context.Context, language string) context.Context {
+	return SetValue(ctx, languageKey, language)
+}
+
+// GetTimezone 获取时区
+func GetTimezone(ctx context.Context) (string, *errors.Error) {
+	return GetValue(ctx, timezoneKey)
+}
+
+// SetTimezone 设置时区
+func SetTimezone(ctx context.Context, timezone string) context.Context {
+	return SetValue(ctx, timezoneKey, timezone)
+}
+
 // GetPlatform 获取平台
 func GetPlatform(ctx context.Context) (string, *errors.Error) {
 	return GetValue(ctx, platformKey)
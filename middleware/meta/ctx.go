@@ -5,14 +5,32 @@ import (
 	"context"
 
 	"github.com/go-kratos/kratos/v2/metadata"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// GetNewCtx _
+// GetNewCtx 获取一个脱离原请求生命周期的 ctx, 通常用于异步任务
+// 保留服务端与客户端元数据（副本）及链路追踪的 SpanContext, 不继承取消信号与截止时间
+// 不保留 transport 等与请求绑定的值, 请求结束后继续使用它们是不安全的
 func GetNewCtx(ctx context.Context) context.Context {
 	newCtx := context.Background()
-	md, ok := metadata.FromServerContext(ctx)
-	if ok {
-		newCtx = metadata.NewServerContext(newCtx, md)
+	if md, ok := metadata.FromServerContext(ctx); ok {
+		newCtx = metadata.NewServerContext(newCtx, md.Clone())
+	}
+	if md, ok := metadata.FromClientContext(ctx); ok {
+		newCtx = metadata.NewClientContext(newCtx, md.Clone())
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		newCtx = trace.ContextWithSpanContext(newCtx, sc)
 	}
 	return newCtx
 }
+
+// GetNewCtxWithDeadline 同 GetNewCtx 并保留原 ctx 的截止时间
+// 适用于需要在原请求超时前完成, 但不希望随原请求提前结束而被取消的任务
+func GetNewCtxWithDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	newCtx := GetNewCtx(ctx)
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(newCtx, deadline)
+	}
+	return context.WithCancel(newCtx)
+}
@@ -3,8 +3,12 @@ package meta
 
 import (
 	"context"
+	"net"
+	"strings"
 
 	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/grpc/peer"
 )
 
 // GetRequestHeader 获取相关
@@ -53,3 +57,32 @@ func GetReplyHeader(ctx context.Context) (transport.Header, bool) {
 	}
 	return t.ReplyHeader(), ok
 }
+
+// GetClientIP 获取客户端 IP
+// 依次从 X-Forwarded-For 的第一个地址、X-Real-IP、HTTP 请求的 RemoteAddr、gRPC 的 peer 中获取
+func GetClientIP(ctx context.Context) string {
+	if forwarded := GetRequestHeaderVal(ctx, "X-Forwarded-For"); forwarded != "" {
+		ip, _, _ := strings.Cut(forwarded, ",")
+		if ip = strings.TrimSpace(ip); ip != "" {
+			return ip
+		}
+	}
+	if ip := strings.TrimSpace(GetRequestHeaderVal(ctx, "X-Real-IP")); ip != "" {
+		return ip
+	}
+	if req, ok := http.RequestFromServerContext(ctx); ok {
+		return hostOf(req.RemoteAddr)
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return hostOf(p.Addr.String())
+	}
+	return ""
+}
+
+// hostOf 去掉地址中的端口
+func hostOf(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
@@ -9,6 +9,12 @@ const (
 const staffIDKey = "staff-id"
 const userIDKey = "user-id"
 
+// 语言
+const languageKey = "language"
+
+// 时区 IANA 名称, 如 Asia/Shanghai
+const timezoneKey = "timezone"
+
 // 客户端 ID
 const clientIDKey = "client-id"
 
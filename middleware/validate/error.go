@@ -0,0 +1,124 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/go-kratos/kratos/v2/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ViolationsKey 错误 metadata 中保存字段详情的 key 值为 FieldViolation 数组的 JSON
+const ViolationsKey = "violations"
+
+// FieldViolation 字段的验证错误详情
+type FieldViolation struct {
+	// 字段路径 如 name、items[0].title
+	Field string `json:"field"`
+	// 字段的本地化名称 未配置字段名称字典时为字段名
+	Label string `json:"label,omitempty"`
+	// 约束 ID 如 string.min_len
+	ConstraintID string `json:"constraintId,omitempty"`
+	// 本地化的错误消息
+	Message string `json:"message"`
+	// 约束规则的值 如 string.min_len 的 2
+	Rule string `json:"rule,omitempty"`
+
+	// name 字段名 用于兼容以字段名为 key 的 metadata
+	name string
+}
+
+// Error 参数验证错误 HTTP 响应使用 metadata, gRPC 响应额外携带 google.rpc.BadRequest 详情
+// 可通过 errors.FromError 获取 *errors.Error
+type Error struct {
+	err        *errors.Error
+	Violations []*FieldViolation
+}
+
+func newError(err *errors.Error, metadata map[string]string, violations []*FieldViolation) *Error {
+	if len(violations) > 0 {
+		if b, jErr := json.Marshal(violations); jErr == nil {
+			metadata[ViolationsKey] = string(b)
+		}
+	}
+	if len(metadata) > 0 {
+		err = err.WithMetadata(metadata)
+	}
+	return &Error{err: err, Violations: violations}
+}
+
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap 返回 *errors.Error
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// GRPCStatus 在 ErrorInfo 之外附加 google.rpc.BadRequest 详情
+func (e *Error) GRPCStatus() *status.Status {
+	s := e.err.GRPCStatus()
+	if len(e.Violations) == 0 {
+		return s
+	}
+	badRequest := &errdetails.BadRequest{}
+	for _, v := range e.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Message,
+			Reason:      v.ConstraintID,
+		})
+	}
+	if ds, err := s.WithDetails(badRequest); err == nil {
+		return ds
+	}
+	return s
+}
+
+// GetViolations 从错误中获取字段的验证错误详情 支持本包的 Error 与经过传输后仅保留 metadata 的 *errors.Error
+func GetViolations(err error) []*FieldViolation {
+	if err == nil {
+		return nil
+	}
+	var vErr *Error
+	if errors.As(err, &vErr) {
+		return vErr.Violations
+	}
+	se := errors.FromError(err)
+	if se == nil || se.Metadata[ViolationsKey] == "" {
+		return nil
+	}
+	var violations []*FieldViolation
+	if json.Unmarshal([]byte(se.Metadata[ViolationsKey]), &violations) != nil {
+		return nil
+	}
+	return violations
+}
+
+// ruleValue 约束规则的值 列表以逗号连接
+func ruleValue(v *protovalidate.Violation) string {
+	if !v.RuleValue.IsValid() {
+		return ""
+	}
+	if v.RuleDescriptor != nil && v.RuleDescriptor.IsList() {
+		list := v.RuleValue.List()
+		items := make([]string, 0, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			items = append(items, valueString(list.Get(i)))
+		}
+		return strings.Join(items, ",")
+	}
+	return valueString(v.RuleValue)
+}
+
+func valueString(v protoreflect.Value) string {
+	if m, ok := v.Interface().(protoreflect.Message); ok {
+		return fmt.Sprint(m.Interface())
+	}
+	return v.String()
+}
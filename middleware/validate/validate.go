@@ -6,9 +6,15 @@ import (
 
 	"github.com/bufbuild/protovalidate-go"
 	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
 	"github.com/yimoka/api/fault"
 	"github.com/yimoka/go/lang"
+	ymetrics "github.com/yimoka/go/metrics"
+	"github.com/yimoka/go/validation"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -29,6 +35,80 @@ type options struct {
 
 	// commonLang 用于处理通用的多语言消息
 	commonLang *lang.CommonLang
+
+	// mode 验证模式 默认返回所有错误
+	mode Mode
+
+	// rules 预定义规则的绑定
+	rules []validation.Binding
+
+	// replyMode 响应的验证模式 默认不验证
+	replyMode    ReplyMode
+	replyCounter metric.Int64Counter
+	logger       log.Logger
+	log          *log.Helper
+}
+
+// WithRules 绑定 validation 包的预定义规则 如中国大陆手机号、身份证号等
+func WithRules(bindings ...validation.Binding) Option {
+	return func(o *options) {
+		o.rules = append(o.rules, bindings...)
+	}
+}
+
+// Mode 验证模式
+type Mode int
+
+const (
+	// ModeAllErrors 返回所有字段的验证错误
+	ModeAllErrors Mode = iota
+	// ModeFirstError 遇到第一个验证错误即返回
+	ModeFirstError
+)
+
+// ReplyMode 响应的验证模式
+type ReplyMode int
+
+const (
+	// ReplyOff 不验证响应
+	ReplyOff ReplyMode = iota
+	// ReplyLog 验证失败时以 warn 级别记录日志 响应照常返回
+	ReplyLog
+	// ReplyMetric 验证失败时累加指标 server_reply_invalid_total 响应照常返回
+	ReplyMetric
+	// ReplyFailClosed 验证失败时记录日志并返回 500 错误 不将数据返回给客户端
+	ReplyFailClosed
+)
+
+// replyCounterName 响应验证失败的指标名称
+const replyCounterName = "server_reply_invalid_total"
+
+// WithReplyMode 设置响应的验证模式 默认不验证
+func WithReplyMode(mode ReplyMode) Option {
+	return func(o *options) {
+		o.replyMode = mode
+	}
+}
+
+// WithLogger 设置响应验证失败时的日志 默认使用全局日志
+func WithLogger(logger log.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithReplyCounter 设置响应验证失败的计数器 ReplyMetric 模式下默认使用全局 MeterProvider 创建
+func WithReplyCounter(counter metric.Int64Counter) Option {
+	return func(o *options) {
+		o.replyCounter = counter
+	}
+}
+
+// WithMode 设置验证模式
+func WithMode(mode Mode) Option {
+	return func(o *options) {
+		o.mode = mode
+	}
 }
 
 // WithCommonLang 设置通用语言处理器
@@ -64,79 +144,202 @@ func getDefaultErrorMsg(v *protovalidate.Violation) string {
 }
 
 // ProtoValidate 返回一个用于协议缓冲区消息验证的中间件
+// 验证失败时返回 400 错误, metadata 中以字段名为 key 保存错误消息, 并在 violations 中保存每个字段的结构化详情(JSON)
+// gRPC 响应同时携带 google.rpc.BadRequest 详情
+// 设置 WithReplyMode 后同时验证响应, 见 ReplyMode
 func ProtoValidate(opts ...Option) middleware.Middleware {
-	options := &options{}
-	for _, opt := range opts {
-		opt(options)
-	}
-
-	validator, err := protovalidate.New()
-	if err != nil {
-		panic(err)
-	}
+	options := newOptions(opts...)
+	validator := options.newValidator()
 
 	return func(handler middleware.Handler) middleware.Handler {
 		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
 			// 检查请求是否为 protobuf 消息
 			if msg, ok := req.(proto.Message); ok {
 				if err := validator.Validate(msg); err != nil {
-					var valErr *protovalidate.ValidationError
-
-					// 获取参数错误的基础消息
-					parameterErrorMsg := "parameter error"
-					if options.commonLang != nil {
-						parameterErrorMsg = options.commonLang.GetParameterErrorMsg(ctx)
-					}
-
-					// 如果不是验证错误，返回基础错误信息
-					if ok := errors.As(err, &valErr); !ok {
-						return nil, fault.ErrorBadRequest("%s: %s", parameterErrorMsg, err.Error())
+					return nil, options.requestError(ctx, err)
+				}
+			}
+			reply, err = handler(ctx, req)
+			if err != nil || options.replyMode == ReplyOff {
+				return reply, err
+			}
+			if msg, ok := reply.(proto.Message); ok {
+				if vErr := validator.Validate(msg); vErr != nil {
+					if rErr := options.replyError(ctx, vErr); rErr != nil {
+						return nil, rErr
 					}
+				}
+			}
+			return reply, nil
+		}
+	}
+}
 
-					// 处理每个验证违规
-					metadata := make(map[string]string)
-					for _, v := range valErr.Violations {
-						elements := v.Proto.Field.Elements
-						field := ""
-						eLen := len(elements)
-						if eLen > 0 {
-							field = *elements[eLen-1].FieldName
-						}
-						constraintID := v.Proto.GetConstraintId()
-						// 如果约束ID为空，则使用默认的错误消息
-						if constraintID == "" {
-							metadata[field] = getDefaultErrorMsg(v)
-							continue
-						}
-
-						// 1. 尝试使用自定义错误消息处理
-						if options.validateMsg != nil {
-							if msg, isMatch := options.validateMsg(ctx, v); isMatch {
-								metadata[field] = msg
-								continue
-							}
-						}
-
-						// 2. 尝试使用通用语言处理器的错误消息
-						if options.commonLang != nil {
-							if msg, isMatch := options.commonLang.GetValidateErrorMsg(ctx, v); isMatch {
-								metadata[field] = msg
-								continue
-							}
-						}
-
-						metadata[field] = getDefaultErrorMsg(v)
-					}
+// ProtoValidateClient 返回客户端的验证中间件 在请求发出前验证, 失败时返回与服务端相同格式的错误且不发起请求
+func ProtoValidateClient(opts ...Option) middleware.Middleware {
+	options := newOptions(opts...)
+	validator := options.newValidator()
 
-					// 构建错误响应
-					err := fault.ErrorBadRequest("%s", parameterErrorMsg)
-					if len(metadata) > 0 {
-						err = err.WithMetadata(metadata)
-					}
-					return nil, err
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if msg, ok := req.(proto.Message); ok {
+				if err := validator.Validate(msg); err != nil {
+					return nil, options.requestError(ctx, err)
 				}
 			}
 			return handler(ctx, req)
 		}
 	}
 }
+
+func newOptions(opts ...Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.replyMode == ReplyOff {
+		return o
+	}
+	if o.logger == nil {
+		o.logger = log.GetLogger()
+	}
+	o.log = log.NewHelper(log.With(o.logger, "middleware", "validate"))
+	if o.replyMode == ReplyMetric && o.replyCounter == nil {
+		counter, err := ymetrics.GetMeter(nil).Int64Counter(replyCounterName,
+			metric.WithDescription("The total number of replies that failed validation"), metric.WithUnit("{call}"))
+		if err != nil {
+			panic(err)
+		}
+		o.replyCounter = counter
+	}
+	return o
+}
+
+// newValidator 创建验证器 创建失败时 panic
+func (o *options) newValidator() protovalidate.Validator {
+	var validatorOpts []protovalidate.ValidatorOption
+	if o.mode == ModeFirstError {
+		validatorOpts = append(validatorOpts, protovalidate.WithFailFast())
+	}
+	validator, err := protovalidate.New(validatorOpts...)
+	if err != nil {
+		panic(err)
+	}
+	if len(o.rules) > 0 {
+		var ruleOpts []validation.Option
+		if o.mode == ModeFirstError {
+			ruleOpts = append(ruleOpts, validation.WithFailFast())
+		}
+		if validator, err = validation.New(validator, o.rules, ruleOpts...); err != nil {
+			panic(err)
+		}
+	}
+	return validator
+}
+
+// requestError 转换请求的验证错误
+func (o *options) requestError(ctx context.Context, err error) error {
+	var valErr *protovalidate.ValidationError
+
+	// 获取参数错误的基础消息
+	parameterErrorMsg := "parameter error"
+	if o.commonLang != nil {
+		parameterErrorMsg = o.commonLang.GetParameterErrorMsg(ctx)
+	}
+
+	// 如果不是验证错误，返回基础错误信息
+	if ok := errors.As(err, &valErr); !ok {
+		return fault.ErrorBadRequest("%s: %s", parameterErrorMsg, err.Error())
+	}
+
+	// 处理每个验证违规
+	violations := valErr.Violations
+	if o.mode == ModeFirstError && len(violations) > 1 {
+		violations = violations[:1]
+	}
+	metadata := make(map[string]string)
+	details := make([]*FieldViolation, 0, len(violations))
+	for _, v := range violations {
+		detail := o.fieldViolation(ctx, v)
+		metadata[detail.name] = detail.Message
+		details = append(details, detail)
+	}
+
+	// 构建错误响应
+	return newError(fault.ErrorBadRequest("%s", parameterErrorMsg), metadata, details)
+}
+
+// replyError 处理响应的验证错误 仅 ReplyFailClosed 返回错误
+func (o *options) replyError(ctx context.Context, err error) error {
+	operation := ""
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		operation = tr.Operation()
+	}
+	// 响应的问题由服务端排查, 记录字段路径与约束 ID 即可, 不做本地化
+	fields := []string{}
+	var valErr *protovalidate.ValidationError
+	if errors.As(err, &valErr) {
+		for _, v := range valErr.Violations {
+			fields = append(fields, protovalidate.FieldPathString(v.Proto.GetField())+":"+v.Proto.GetConstraintId())
+		}
+	} else {
+		fields = append(fields, err.Error())
+	}
+
+	switch o.replyMode {
+	case ReplyLog:
+		o.log.WithContext(ctx).Warnw("msg", "reply validation failed", "operation", operation, "violations", fields)
+	case ReplyMetric:
+		o.replyCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation)))
+	case ReplyFailClosed:
+		o.log.WithContext(ctx).Errorw("msg", "reply validation failed", "operation", operation, "violations", fields)
+		msg := "reply validation error"
+		if o.commonLang != nil {
+			msg = o.commonLang.GetReplyValidationErrorMsg(ctx)
+		}
+		return fault.ErrorInternalServerError("%s", msg)
+	}
+	return nil
+}
+
+// fieldViolation 转换验证违规为字段详情
+func (o *options) fieldViolation(ctx context.Context, v *protovalidate.Violation) *FieldViolation {
+	elements := v.Proto.GetField().GetElements()
+	detail := &FieldViolation{
+		Field:        protovalidate.FieldPathString(v.Proto.GetField()),
+		ConstraintID: v.Proto.GetConstraintId(),
+		Rule:         ruleValue(v),
+	}
+	if len(elements) > 0 {
+		detail.name = elements[len(elements)-1].GetFieldName()
+	}
+	detail.Label = detail.name
+	if o.commonLang != nil {
+		detail.Label = o.commonLang.GetValidateFieldLabel(ctx, v)
+	}
+	detail.Message = o.message(ctx, v)
+	return detail
+}
+
+// message 获取验证违规的错误消息
+func (o *options) message(ctx context.Context, v *protovalidate.Violation) string {
+	// 如果约束ID为空，则使用默认的错误消息
+	if v.Proto.GetConstraintId() == "" {
+		return getDefaultErrorMsg(v)
+	}
+
+	// 1. 尝试使用自定义错误消息处理
+	if o.validateMsg != nil {
+		if msg, isMatch := o.validateMsg(ctx, v); isMatch {
+			return msg
+		}
+	}
+
+	// 2. 尝试使用通用语言处理器的错误消息
+	if o.commonLang != nil {
+		if msg, isMatch := o.commonLang.GetValidateErrorMsg(ctx, v); isMatch {
+			return msg
+		}
+	}
+	return getDefaultErrorMsg(v)
+}
@@ -0,0 +1,241 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/lang"
+	"github.com/yimoka/go/middleware/meta"
+	"github.com/yimoka/go/validation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func stringRules(rules *validate.StringRules) *descriptorpb.FieldOptions {
+	opts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(opts, validate.E_Field, &validate.FieldConstraints{Type: &validate.FieldConstraints_String_{String_: rules}})
+	return opts
+}
+
+// newRequest 创建带验证规则的动态消息 name 最少 2 个字符, email 为邮箱, items[].title 必填
+func newRequest(t *testing.T) protoreflect.MessageDescriptor {
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	msg := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	repeated := descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("validate_test.proto"),
+		Package:    proto.String("test.v1"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"buf/validate/validate.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Item"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("title"), JsonName: proto.String("title"), Number: proto.Int32(1), Type: str, Label: optional, Options: stringRules(&validate.StringRules{MinLen: proto.Uint64(1)})},
+				},
+			},
+			{
+				Name: proto.String("AddRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("name"), JsonName: proto.String("name"), Number: proto.Int32(1), Type: str, Label: optional, Options: stringRules(&validate.StringRules{MinLen: proto.Uint64(2)})},
+					{Name: proto.String("email"), JsonName: proto.String("email"), Number: proto.Int32(2), Type: str, Label: optional, Options: stringRules(&validate.StringRules{WellKnown: &validate.StringRules_Email{Email: true}})},
+					{Name: proto.String("items"), JsonName: proto.String("items"), Number: proto.Int32(3), Type: msg, TypeName: proto.String(".test.v1.Item"), Label: repeated},
+				},
+			},
+		},
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	assert.NoError(t, err)
+	return fd.Messages().ByName("AddRequest")
+}
+
+func newMessage(md protoreflect.MessageDescriptor, name, email string, titles ...string) proto.Message {
+	m := dynamicpb.NewMessage(md)
+	m.Set(md.Fields().ByName("name"), protoreflect.ValueOfString(name))
+	m.Set(md.Fields().ByName("email"), protoreflect.ValueOfString(email))
+	items := m.Mutable(md.Fields().ByName("items")).List()
+	itemMD := md.Fields().ByName("items").Message()
+	for _, title := range titles {
+		item := dynamicpb.NewMessage(itemMD)
+		item.Set(itemMD.Fields().ByName("title"), protoreflect.ValueOfString(title))
+		items.Append(protoreflect.ValueOfMessage(item))
+	}
+	return m
+}
+
+func next(context.Context, interface{}) (interface{}, error) {
+	return "ok", nil
+}
+
+func TestProtoValidate(t *testing.T) {
+	md := newRequest(t)
+	commonLang := lang.NewCommonLang(nil, log.DefaultLogger)
+	assert.NoError(t, commonLang.AddFieldLabels(language.Chinese, map[string]string{"name": "名称", "test.v1.Item.title": "标题"}))
+	h := ProtoValidate(WithCommonLang(commonLang))(next)
+
+	reply, err := h(context.Background(), newMessage(md, "ab", "a@b.com", "t"))
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", reply)
+
+	ctx := context.Background()
+	_, err = h(ctx, newMessage(md, "a", "bad", "t", ""))
+	assert.Error(t, err)
+	se := errors.FromError(err)
+	assert.Equal(t, int32(400), se.Code)
+	assert.NotEmpty(t, se.Metadata["name"])
+	assert.NotEmpty(t, se.Metadata["email"])
+	assert.NotEmpty(t, se.Metadata[ViolationsKey])
+
+	violations := GetViolations(err)
+	assert.Len(t, violations, 3)
+	byField := map[string]*FieldViolation{}
+	for _, v := range violations {
+		byField[v.Field] = v
+	}
+	assert.Equal(t, "string.min_len", byField["name"].ConstraintID)
+	assert.Equal(t, "2", byField["name"].Rule)
+	assert.Equal(t, "Length must be at least 2 characters", byField["name"].Message)
+	assert.Equal(t, "string.email", byField["email"].ConstraintID)
+	assert.Equal(t, "items[1].title", byField["items[1].title"].Field)
+
+	// 经过传输后仅保留 metadata 时仍可解析
+	assert.Len(t, GetViolations(se), 3)
+
+	// gRPC 携带 BadRequest 详情
+	s, ok := status.FromError(err)
+	assert.True(t, ok)
+	var badRequest *errdetails.BadRequest
+	for _, d := range s.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			badRequest = br
+		}
+	}
+	assert.NotNil(t, badRequest)
+	assert.Len(t, badRequest.FieldViolations, 3)
+}
+
+func TestProtoValidateFieldLabel(t *testing.T) {
+	md := newRequest(t)
+	commonLang := lang.NewCommonLang(nil, log.DefaultLogger)
+	assert.NoError(t, commonLang.AddFieldLabels(language.Chinese, map[string]string{"name": "名称", "test.v1.Item.title": "标题"}))
+	assert.NoError(t, commonLang.AddMessages(language.Chinese, &i18n.Message{ID: "validate_string.min_len", Other: "{{.FieldLabel}}至少 {{.Rule}} 个字符"}))
+	h := ProtoValidate(WithCommonLang(commonLang))(next)
+
+	ctx := meta.SetLanguage(metadata.NewServerContext(context.Background(), metadata.New()), "zh")
+	_, err := h(ctx, newMessage(md, "a", "a@b.com", ""))
+	byField := map[string]*FieldViolation{}
+	for _, v := range GetViolations(err) {
+		byField[v.Field] = v
+	}
+	assert.Equal(t, "名称", byField["name"].Label)
+	assert.Equal(t, "名称至少 2 个字符", byField["name"].Message)
+	assert.Equal(t, "标题", byField["items[0].title"].Label)
+}
+
+func TestProtoValidateFirstError(t *testing.T) {
+	md := newRequest(t)
+	h := ProtoValidate(WithMode(ModeFirstError))(next)
+	_, err := h(context.Background(), newMessage(md, "a", "bad", ""))
+	violations := GetViolations(err)
+	assert.Len(t, violations, 1)
+	assert.Len(t, errors.FromError(err).Metadata, 2)
+	assert.NotEmpty(t, violations[0].Message)
+}
+
+func TestProtoValidateRules(t *testing.T) {
+	md := newRequest(t)
+	commonLang := lang.NewCommonLang(nil, log.DefaultLogger)
+	h := ProtoValidate(WithCommonLang(commonLang), WithRules(validation.Binding{Fields: []string{"name"}, Rules: []string{validation.RuleCNMobile}}))(next)
+
+	_, err := h(context.Background(), newMessage(md, "13800138000", "a@b.com"))
+	assert.NoError(t, err)
+
+	ctx := meta.SetLanguage(metadata.NewServerContext(context.Background(), metadata.New()), "zh")
+	_, err = h(ctx, newMessage(md, "12345", "a@b.com"))
+	violations := GetViolations(err)
+	assert.Len(t, violations, 1)
+	assert.Equal(t, "name", violations[0].Field)
+	assert.Equal(t, validation.RuleCNMobile, violations[0].ConstraintID)
+	assert.Equal(t, "必须是有效的手机号", violations[0].Message)
+}
+
+func TestProtoValidateReply(t *testing.T) {
+	md := newRequest(t)
+	valid := newMessage(md, "ab", "a@b.com")
+	invalid := newMessage(md, "a", "a@b.com")
+	reply := func(context.Context, interface{}) (interface{}, error) { return invalid, nil }
+
+	// 默认不验证响应
+	r, err := ProtoValidate()(reply)(context.Background(), valid)
+	assert.NoError(t, err)
+	assert.Equal(t, invalid, r)
+
+	buf := &bytes.Buffer{}
+	r, err = ProtoValidate(WithReplyMode(ReplyLog), WithLogger(log.NewStdLogger(buf)))(reply)(context.Background(), valid)
+	assert.NoError(t, err)
+	assert.Equal(t, invalid, r)
+	assert.Contains(t, buf.String(), "reply validation failed")
+	assert.Contains(t, buf.String(), "name:string.min_len")
+
+	reader := sdkmetric.NewManualReader()
+	counter, err := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test").Int64Counter(replyCounterName)
+	assert.NoError(t, err)
+	h := ProtoValidate(WithReplyMode(ReplyMetric), WithReplyCounter(counter))(reply)
+	for i := 0; i < 2; i++ {
+		r, err = h(context.Background(), valid)
+		assert.NoError(t, err)
+		assert.Equal(t, invalid, r)
+	}
+	rm := metricdata.ResourceMetrics{}
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+	sum := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+	assert.Equal(t, int64(2), sum.DataPoints[0].Value)
+
+	buf.Reset()
+	commonLang := lang.NewCommonLang(nil, log.DefaultLogger)
+	r, err = ProtoValidate(WithReplyMode(ReplyFailClosed), WithCommonLang(commonLang), WithLogger(log.NewStdLogger(buf)))(reply)(context.Background(), valid)
+	assert.Nil(t, r)
+	se := errors.FromError(err)
+	assert.Equal(t, int32(500), se.Code)
+	assert.Equal(t, "Response data is abnormal, please try again later", se.Message)
+	assert.Empty(t, se.Metadata)
+	assert.Contains(t, buf.String(), "name:string.min_len")
+}
+
+func TestProtoValidateClient(t *testing.T) {
+	md := newRequest(t)
+	called := false
+	h := ProtoValidateClient()(func(context.Context, interface{}) (interface{}, error) {
+		called = true
+		return "ok", nil
+	})
+
+	_, err := h(context.Background(), newMessage(md, "a", "a@b.com"))
+	assert.False(t, called)
+	violations := GetViolations(err)
+	assert.Len(t, violations, 1)
+	assert.Equal(t, "name", violations[0].Field)
+	assert.Equal(t, int32(400), errors.FromError(err).Code)
+
+	reply, err := h(context.Background(), newMessage(md, "ab", "a@b.com"))
+	assert.NoError(t, err)
+	assert.True(t, called)
+	assert.Equal(t, "ok", reply)
+}
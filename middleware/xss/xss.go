@@ -0,0 +1,80 @@
+// Package xss 提供请求 xss 过滤的中间件
+package xss
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/yimoka/go/middleware/meta"
+	"github.com/yimoka/go/xss"
+	"google.golang.org/protobuf/proto"
+)
+
+// Report 被过滤的请求信息 用于审计
+type Report struct {
+	// 接口 即 transport 的 Operation
+	Operation string
+	// 被修改的字段路径
+	Fields []string
+	// 客户端 IP
+	ClientIP string
+}
+
+// Option 配置
+type Option func(*options)
+
+type options struct {
+	reporter func(ctx context.Context, report *Report)
+	logger   log.Logger
+}
+
+// WithReporter 设置被过滤时的上报方法 如写入审计日志
+func WithReporter(reporter func(ctx context.Context, report *Report)) Option {
+	return func(o *options) {
+		o.reporter = reporter
+	}
+}
+
+// WithLogger 设置日志 未设置上报方法时以 warn 级别记录被过滤的字段, 默认使用全局日志
+func WithLogger(logger log.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// Server 过滤 proto 请求中标记的字符串字段 在请求进入业务代码前执行
+func Server(sanitizer *xss.Sanitizer, opts ...Option) middleware.Middleware {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.reporter == nil {
+		logger := o.logger
+		if logger == nil {
+			logger = log.GetLogger()
+		}
+		helper := log.NewHelper(log.With(logger, "middleware", "xss"))
+		o.reporter = func(ctx context.Context, report *Report) {
+			helper.WithContext(ctx).Warnw("msg", "xss filtered", "operation", report.Operation,
+				"fields", report.Fields, "clientIP", report.ClientIP)
+		}
+	}
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			msg, ok := req.(proto.Message)
+			if !ok {
+				return handler(ctx, req)
+			}
+			if fields := sanitizer.Sanitize(msg); len(fields) > 0 {
+				report := &Report{Fields: fields, ClientIP: meta.GetClientIP(ctx)}
+				if tr, ok := transport.FromServerContext(ctx); ok {
+					report.Operation = tr.Operation()
+				}
+				o.reporter(ctx, report)
+			}
+			return handler(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package xss
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/utils"
+	"github.com/yimoka/go/xss"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestServer(t *testing.T) {
+	sanitizer, err := xss.New(xss.Rule{Fields: []string{"google.protobuf.StringValue.value"}, Profile: utils.XSSProfileStrict})
+	assert.NoError(t, err)
+
+	var report *Report
+	m := Server(sanitizer, WithReporter(func(_ context.Context, r *Report) { report = r }))
+	handler := m(func(_ context.Context, req interface{}) (interface{}, error) {
+		return req.(*wrapperspb.StringValue).GetValue(), nil
+	})
+
+	reply, err := handler(context.Background(), wrapperspb.String("<script>x</script>hi"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hi", reply)
+	assert.Equal(t, []string{"value"}, report.Fields)
+
+	report = nil
+	reply, err = handler(context.Background(), wrapperspb.String("hi"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hi", reply)
+	assert.Nil(t, report)
+}
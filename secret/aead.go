@@ -0,0 +1,200 @@
+// Package secret aead.go
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Algorithm 认证加密算法
+type Algorithm string
+
+const (
+	// AlgAES256GCM AES-256-GCM
+	AlgAES256GCM Algorithm = "aes256gcm"
+	// AlgChaCha20Poly1305 ChaCha20-Poly1305
+	AlgChaCha20Poly1305 Algorithm = "chacha20poly1305"
+)
+
+// envelopeVersion 密文格式的版本
+const envelopeVersion = "v1"
+
+// envelopeSep 密文格式的分隔符 不会出现在 base64 URL 编码中
+const envelopeSep = "$"
+
+// ErrInvalidEnvelope 密文格式错误
+var ErrInvalidEnvelope = errors.New("secret: invalid envelope")
+
+// Envelope 自描述的密文 格式为 $v1$算法$密钥ID$nonce$密文
+// 算法、版本与密钥 ID 作为附加数据参与认证, 篡改任一部分都会解密失败
+type Envelope struct {
+	Version    string
+	Algorithm  Algorithm
+	KeyID      string
+	Nonce      []byte
+	Ciphertext []byte
+}
+
+// String 序列化为字符串
+func (e *Envelope) String() string {
+	return e.header() + envelopeSep +
+		base64.RawURLEncoding.EncodeToString(e.Nonce) + envelopeSep +
+		base64.RawURLEncoding.EncodeToString(e.Ciphertext)
+}
+
+// header 版本、算法与密钥 ID 部分 用作附加数据
+func (e *Envelope) header() string {
+	return envelopeSep + e.Version + envelopeSep + string(e.Algorithm) + envelopeSep + e.KeyID
+}
+
+// IsEnvelope 判断是否为 Envelope 格式的密文 旧的 CBC 密文以字母数字的 iv 开头
+func IsEnvelope(str string) bool {
+	return strings.HasPrefix(str, envelopeSep+envelopeVersion+envelopeSep)
+}
+
+// ParseEnvelope 解析 Envelope 格式的密文
+func ParseEnvelope(str string) (*Envelope, error) {
+	if !IsEnvelope(str) {
+		return nil, ErrInvalidEnvelope
+	}
+	parts := strings.Split(str[1:], envelopeSep)
+	if len(parts) != 5 {
+		return nil, ErrInvalidEnvelope
+	}
+	nonce, err := base64.RawURLEncoding.DecodeString(parts[3])
+	if err != nil {
+		return nil, ErrInvalidEnvelope
+	}
+	ciphertext, err := base64.RawURLEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, ErrInvalidEnvelope
+	}
+	return &Envelope{
+		Version:    parts[0],
+		Algorithm:  Algorithm(parts[1]),
+		KeyID:      parts[2],
+		Nonce:      nonce,
+		Ciphertext: ciphertext,
+	}, nil
+}
+
+// EncryptGCM 使用 AES-256-GCM 加密 keyID 用于标识密钥 便于轮换 可为空
+func EncryptGCM(str string, secret string, keyID string) (string, error) {
+	return EncryptAEAD(str, secret, AlgAES256GCM, keyID)
+}
+
+// EncryptChaCha20 使用 ChaCha20-Poly1305 加密 keyID 用于标识密钥 便于轮换 可为空
+func EncryptChaCha20(str string, secret string, keyID string) (string, error) {
+	return EncryptAEAD(str, secret, AlgChaCha20Poly1305, keyID)
+}
+
+// EncryptSM4 使用 SM4-GCM 加密 keyID 用于标识密钥 便于轮换 可为空
+func EncryptSM4(str string, secret string, keyID string) (string, error) {
+	return EncryptAEAD(str, secret, AlgSM4GCM, keyID)
+}
+
+// EncryptAEAD 使用认证加密算法加密 返回 Envelope 格式的密文
+// AES 与 ChaCha20 在 secret 为 32 字节时直接作为密钥, 否则使用其 SHA-256; SM4 在 secret 为 16 字节时直接作为密钥, 否则使用其 SM3 摘要的前 16 字节
+func EncryptAEAD(str string, secret string, alg Algorithm, keyID string) (string, error) {
+	if strings.Contains(keyID, envelopeSep) {
+		return "", fmt.Errorf("secret: key id can not contain %q", envelopeSep)
+	}
+	aead, err := newAEAD(alg, secret)
+	if err != nil {
+		return "", err
+	}
+	e := &Envelope{Version: envelopeVersion, Algorithm: alg, KeyID: keyID, Nonce: make([]byte, aead.NonceSize())}
+	if _, err := rand.Read(e.Nonce); err != nil {
+		return "", err
+	}
+	e.Ciphertext = aead.Seal(nil, e.Nonce, []byte(str), []byte(e.header()))
+	return e.String(), nil
+}
+
+// DecryptAEAD 解密 Envelope 格式的密文
+func DecryptAEAD(str string, secret string) (string, error) {
+	e, err := ParseEnvelope(str)
+	if err != nil {
+		return "", err
+	}
+	return e.Open(secret)
+}
+
+// Open 使用密钥解密
+func (e *Envelope) Open(secret string) (string, error) {
+	if e.Version != envelopeVersion {
+		return "", fmt.Errorf("secret: unsupported envelope version %s", e.Version)
+	}
+	aead, err := newAEAD(e.Algorithm, secret)
+	if err != nil {
+		return "", err
+	}
+	if len(e.Nonce) != aead.NonceSize() {
+		return "", ErrInvalidEnvelope
+	}
+	dst, err := aead.Open(nil, e.Nonce, e.Ciphertext, []byte(e.header()))
+	if err != nil {
+		return "", err
+	}
+	return string(dst), nil
+}
+
+// ReEncrypt 将旧密钥或旧格式(CBC)的密文使用新密钥与算法重新加密 用于批量迁移
+func ReEncrypt(str string, oldSecret string, newSecret string, alg Algorithm, keyID string) (string, error) {
+	plain, err := Decrypt(str, oldSecret)
+	if err != nil {
+		return "", err
+	}
+	return EncryptAEAD(plain, newSecret, alg, keyID)
+}
+
+// NeedReEncrypt 判断密文是否需要重新加密 即不是指定算法与密钥 ID 的 Envelope
+func NeedReEncrypt(str string, alg Algorithm, keyID string) bool {
+	e, err := ParseEnvelope(str)
+	if err != nil {
+		return true
+	}
+	return e.Version != envelopeVersion || e.Algorithm != alg || e.KeyID != keyID
+}
+
+func newAEAD(alg Algorithm, secret string) (cipher.AEAD, error) {
+	switch alg {
+	case AlgAES256GCM:
+		block, err := aes.NewCipher(aeadKey(secret))
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+	case AlgChaCha20Poly1305:
+		return chacha20poly1305.New(aeadKey(secret))
+	case AlgSM4GCM, AlgSM4CBC:
+		return newSM4AEAD(alg, secret)
+	}
+	return nil, fmt.Errorf("secret: unsupported algorithm %s", alg)
+}
+
+// ParseAlgorithm 解析算法名称 不支持时返回 false
+func ParseAlgorithm(name string) (Algorithm, bool) {
+	switch alg := Algorithm(name); alg {
+	case AlgAES256GCM, AlgChaCha20Poly1305, AlgSM4GCM, AlgSM4CBC:
+		return alg, true
+	}
+	return "", false
+}
+
+// aeadKey 获取 32 字节的密钥
+func aeadKey(secret string) []byte {
+	if len(secret) == 32 {
+		return []byte(secret)
+	}
+	sum := sha256.Sum256([]byte(secret))
+	return sum[:]
+}
@@ -0,0 +1,52 @@
+package secret
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAEAD(t *testing.T) {
+	for _, alg := range []Algorithm{AlgAES256GCM, AlgChaCha20Poly1305} {
+		enc, err := EncryptAEAD("13800138000", "12345678", alg, "k1")
+		assert.NoError(t, err)
+		assert.True(t, IsEnvelope(enc))
+
+		e, err := ParseEnvelope(enc)
+		assert.NoError(t, err)
+		assert.Equal(t, alg, e.Algorithm)
+		assert.Equal(t, "k1", e.KeyID)
+
+		dec, err := Decrypt(enc, "12345678")
+		assert.NoError(t, err)
+		assert.Equal(t, "13800138000", dec)
+
+		_, err = Decrypt(enc, "87654321")
+		assert.Error(t, err)
+
+		// 篡改密钥 ID 后认证失败
+		_, err = Decrypt(strings.Replace(enc, "$k1$", "$k2$", 1), "12345678")
+		assert.Error(t, err)
+	}
+
+	_, err := EncryptGCM("str", "12345678", "k$1")
+	assert.Error(t, err)
+	_, err = Decrypt("short", "12345678")
+	assert.Error(t, err)
+}
+
+func TestReEncrypt(t *testing.T) {
+	legacy, err := Encrypt("12311231", "1234567812345678")
+	assert.NoError(t, err)
+	assert.True(t, NeedReEncrypt(legacy, AlgAES256GCM, "k2"))
+
+	enc, err := ReEncrypt(legacy, "1234567812345678", "new-secret", AlgAES256GCM, "k2")
+	assert.NoError(t, err)
+	assert.False(t, NeedReEncrypt(enc, AlgAES256GCM, "k2"))
+	assert.True(t, NeedReEncrypt(enc, AlgChaCha20Poly1305, "k2"))
+
+	dec, err := Decrypt(enc, "new-secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "12311231", dec)
+}
@@ -0,0 +1,36 @@
+// Package secret bidx.go
+package secret
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// BlindIndexKeyInfo 派生盲索引密钥时 HKDF 的 info
+const BlindIndexKeyInfo = "bidx"
+
+// DeriveBlindIndexKey 使用 HKDF-SHA256 从加密的密钥派生盲索引的密钥 返回 32 字节密钥的十六进制
+// 盲索引的 HMAC 与加密不使用同一个密钥
+func DeriveBlindIndexKey(key string) string {
+	r := hkdf.New(sha256.New, []byte(key), nil, []byte(BlindIndexKeyInfo))
+	b := make([]byte, sha256.Size)
+	// 输出长度远小于 HKDF 的上限 不会返回错误
+	_, _ = io.ReadFull(r, b)
+	return hex.EncodeToString(b)
+}
+
+// BlindIndex 计算盲索引 即 HMAC-SHA256 的十六进制并截取前 length 个字符
+// 用于对加密字段进行等值查询, length 小于等于 0 或超过 64 时不截取
+func BlindIndex(str string, key string, length int) string {
+	m := hmac.New(sha256.New, []byte(key))
+	m.Write([]byte(str))
+	index := hex.EncodeToString(m.Sum(nil))
+	if length > 0 && length < len(index) {
+		return index[:length]
+	}
+	return index
+}
@@ -0,0 +1,23 @@
+package secret
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlindIndex(t *testing.T) {
+	full := BlindIndex("13800138000", "key", 0)
+	assert.Len(t, full, 64)
+	assert.Equal(t, full[:16], BlindIndex("13800138000", "key", 16))
+	assert.Equal(t, full, BlindIndex("13800138000", "key", 100))
+	assert.NotEqual(t, full, BlindIndex("13800138000", "key2", 0))
+}
+
+func TestDeriveBlindIndexKey(t *testing.T) {
+	key := DeriveBlindIndexKey("key")
+	assert.Len(t, key, 64)
+	assert.Equal(t, key, DeriveBlindIndexKey("key"))
+	assert.NotEqual(t, key, DeriveBlindIndexKey("key2"))
+	assert.NotEqual(t, BlindIndex("13800138000", "key", 0), BlindIndex("13800138000", key, 0))
+}
@@ -10,7 +10,7 @@ import (
 	"github.com/yimoka/go/utils"
 )
 
-// Encrypt 加密字符串
+// Encrypt 加密字符串 AES-CBC 无完整性校验, 新数据建议使用 EncryptGCM
 func Encrypt(str string, secret string) (string, error) {
 	//  生成随机字符串 做为  iv
 	iv := utils.RandomStr(16)
@@ -23,8 +23,14 @@ func Encrypt(str string, secret string) (string, error) {
 	return iv + base64.StdEncoding.EncodeToString(dst), nil
 }
 
-// Decrypt 解密字符串
+// Decrypt 解密字符串 兼容 Envelope 格式的认证加密密文与旧的 CBC 密文
 func Decrypt(str string, secret string) (string, error) {
+	if IsEnvelope(str) {
+		return DecryptAEAD(str, secret)
+	}
+	if len(str) <= 16 {
+		return "", ErrInvalidEnvelope
+	}
 	iv := str[:16]
 	str = str[16:]
 	src, err := base64.StdEncoding.DecodeString(str)
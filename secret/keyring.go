@@ -0,0 +1,317 @@
+// Package secret keyring.go
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/yimoka/go/config"
+)
+
+// KeyStatus 密钥状态
+type KeyStatus string
+
+const (
+	// KeyActive 可用于加密与解密
+	KeyActive KeyStatus = "active"
+	// KeyDecryptOnly 仅用于解密 轮换后等待旧数据重新加密
+	KeyDecryptOnly KeyStatus = "decryptOnly"
+	// KeyRetired 已停用 不可加密与解密
+	KeyRetired KeyStatus = "retired"
+)
+
+// DefaultKeyID Data.secret 的密钥 ID, 未记录密钥 ID 的密文(旧的 CBC 密文或空密钥 ID)使用该密钥
+const DefaultKeyID = "default"
+
+// ErrKeyNotFound 密钥不存在或不可用
+var ErrKeyNotFound = errors.New("secret: key not found")
+
+// Key 密钥 Algorithm 为空时使用密钥环的算法
+type Key struct {
+	ID        string    `json:"id"`
+	Secret    string    `json:"secret"`
+	Status    KeyStatus `json:"status"`
+	Algorithm Algorithm `json:"algorithm,omitempty"`
+}
+
+// ParseKeySecret 解析配置中的密钥 支持以 "算法:" 为前缀指定该密钥的算法, 如 sm4gcm:xxx
+// 前缀不是支持的算法时原样返回
+func ParseKeySecret(value string) (Algorithm, string) {
+	if name, s, ok := strings.Cut(value, ":"); ok {
+		if alg, ok := ParseAlgorithm(name); ok {
+			return alg, s
+		}
+	}
+	return "", value
+}
+
+// newConfigKey 由配置的值创建 active 密钥
+func newConfigKey(id string, value string) *Key {
+	alg, s := ParseKeySecret(value)
+	return &Key{ID: id, Secret: s, Status: KeyActive, Algorithm: alg}
+}
+
+// canDecrypt 是否可用于解密
+func (k *Key) canDecrypt() bool {
+	return k.Status == KeyActive || k.Status == KeyDecryptOnly
+}
+
+// KeySource 密钥来源 可实现该接口对接 KMS 等密钥管理服务
+type KeySource interface {
+	Load(ctx context.Context) ([]*Key, error)
+}
+
+// KeySourceFunc 以函数实现 KeySource
+type KeySourceFunc func(ctx context.Context) ([]*Key, error)
+
+// Load _
+func (f KeySourceFunc) Load(ctx context.Context) ([]*Key, error) {
+	return f(ctx)
+}
+
+// FromConfig 从 Data 配置中加载密钥 secret 的 ID 为 DefaultKeyID, secrets 的 ID 为其 key, 状态均为 active
+// 值可以 "算法:" 为前缀指定算法, 见 ParseKeySecret
+func FromConfig(data *config.Data) KeySource {
+	return KeySourceFunc(func(context.Context) ([]*Key, error) {
+		keys := []*Key{}
+		if data.GetSecret() != "" {
+			keys = append(keys, newConfigKey(DefaultKeyID, data.GetSecret()))
+		}
+		for id, s := range data.GetSecrets() {
+			if s != "" {
+				keys = append(keys, newConfigKey(id, s))
+			}
+		}
+		return keys, nil
+	})
+}
+
+// FromEnv 从环境变量中加载密钥 变量名为 prefix + ID, 状态为 active, 值的格式同 FromConfig
+// 例如 prefix 为 YIMOKA_SECRET_ 时 YIMOKA_SECRET_user=xxx 的密钥 ID 为 user
+func FromEnv(prefix string) KeySource {
+	return KeySourceFunc(func(context.Context) ([]*Key, error) {
+		keys := []*Key{}
+		for _, kv := range os.Environ() {
+			name, value, _ := strings.Cut(kv, "=")
+			if id, ok := strings.CutPrefix(name, prefix); ok && id != "" && value != "" {
+				keys = append(keys, newConfigKey(id, value))
+			}
+		}
+		return keys, nil
+	})
+}
+
+// FromFile 从 JSON 文件中加载密钥 格式为 [{"id":"k1","secret":"xxx","status":"active","algorithm":"sm4gcm"}]
+// status 为空时为 active, algorithm 为空时使用密钥环的算法
+func FromFile(path string) KeySource {
+	return KeySourceFunc(func(context.Context) ([]*Key, error) {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		keys := []*Key{}
+		if err := json.Unmarshal(b, &keys); err != nil {
+			return nil, fmt.Errorf("secret: parse key file %s: %w", path, err)
+		}
+		for _, k := range keys {
+			if k.Status == "" {
+				k.Status = KeyActive
+			}
+		}
+		return keys, nil
+	})
+}
+
+// KeyringOption 配置
+type KeyringOption func(*Keyring)
+
+// WithActiveKey 设置用于加密的密钥 不设置时使用唯一的 active 密钥, 存在多个时优先使用 DefaultKeyID
+func WithActiveKey(id string) KeyringOption {
+	return func(k *Keyring) {
+		k.fixedActiveID = id
+	}
+}
+
+// WithKeyStatus 覆盖密钥的状态 如将轮换前的密钥设置为 decryptOnly
+func WithKeyStatus(id string, status KeyStatus) KeyringOption {
+	return func(k *Keyring) {
+		k.statuses[id] = status
+	}
+}
+
+// WithKeyAlgorithm 设置加密算法 默认 AES-256-GCM, 密钥指定了算法时以密钥的为准
+func WithKeyAlgorithm(alg Algorithm) KeyringOption {
+	return func(k *Keyring) {
+		k.alg = alg
+	}
+}
+
+// Keyring 密钥环 管理多个密钥, 加密时在密文中记录密钥 ID, 解密时根据密钥 ID 选择密钥
+type Keyring struct {
+	sources       []KeySource
+	statuses      map[string]KeyStatus
+	alg           Algorithm
+	fixedActiveID string
+
+	mu       sync.RWMutex
+	keys     map[string]*Key
+	activeID string
+}
+
+// NewKeyring 创建密钥环 后加载的来源覆盖先加载的同 ID 密钥
+func NewKeyring(ctx context.Context, sources []KeySource, opts ...KeyringOption) (*Keyring, error) {
+	k := &Keyring{
+		sources:  sources,
+		statuses: map[string]KeyStatus{},
+		alg:      AlgAES256GCM,
+	}
+	for _, opt := range opts {
+		opt(k)
+	}
+	if err := k.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Reload 重新从来源加载密钥
+func (k *Keyring) Reload(ctx context.Context) error {
+	keys := map[string]*Key{}
+	for _, source := range k.sources {
+		loaded, err := source.Load(ctx)
+		if err != nil {
+			return err
+		}
+		for _, key := range loaded {
+			if strings.Contains(key.ID, envelopeSep) {
+				return fmt.Errorf("secret: key id can not contain %q", envelopeSep)
+			}
+			if key.Algorithm != "" {
+				if _, ok := ParseAlgorithm(string(key.Algorithm)); !ok {
+					return fmt.Errorf("secret: key %s unsupported algorithm %s", key.ID, key.Algorithm)
+				}
+			}
+			key := *key
+			if status, ok := k.statuses[key.ID]; ok {
+				key.Status = status
+			}
+			keys[key.ID] = &key
+		}
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys = keys
+	k.activeID = k.fixedActiveID
+	if k.activeID == "" {
+		k.activeID = defaultActiveID(keys)
+	}
+	return nil
+}
+
+// defaultActiveID 唯一的 active 密钥 存在多个时优先使用 DefaultKeyID
+func defaultActiveID(keys map[string]*Key) string {
+	if key, ok := keys[DefaultKeyID]; ok && key.Status == KeyActive {
+		return DefaultKeyID
+	}
+	activeID := ""
+	for id, key := range keys {
+		if key.Status != KeyActive {
+			continue
+		}
+		if activeID != "" {
+			return ""
+		}
+		activeID = id
+	}
+	return activeID
+}
+
+// ActiveID 用于加密的密钥 ID
+func (k *Keyring) ActiveID() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.activeID
+}
+
+// Key 获取密钥 空 ID 视为 DefaultKeyID
+func (k *Keyring) Key(id string) (*Key, bool) {
+	if id == "" {
+		id = DefaultKeyID
+	}
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	key, ok := k.keys[id]
+	return key, ok
+}
+
+// Encrypt 使用 active 密钥加密
+func (k *Keyring) Encrypt(str string) (string, error) {
+	return k.EncryptWithKey(k.ActiveID(), str)
+}
+
+// EncryptWithKey 使用指定的密钥加密 密钥必须为 active
+func (k *Keyring) EncryptWithKey(id string, str string) (string, error) {
+	key, ok := k.Key(id)
+	if !ok || key.Status != KeyActive {
+		return "", fmt.Errorf("%w: %s is not active", ErrKeyNotFound, id)
+	}
+	return EncryptAEAD(str, key.Secret, k.algorithm(key), key.ID)
+}
+
+// algorithm 密钥使用的算法
+func (k *Keyring) algorithm(key *Key) Algorithm {
+	if key.Algorithm != "" {
+		return key.Algorithm
+	}
+	return k.alg
+}
+
+// Decrypt 根据密文中的密钥 ID 解密 旧的 CBC 密文使用 DefaultKeyID
+func (k *Keyring) Decrypt(str string) (string, error) {
+	id := DefaultKeyID
+	var env *Envelope
+	if IsEnvelope(str) {
+		var err error
+		if env, err = ParseEnvelope(str); err != nil {
+			return "", err
+		}
+		id = env.KeyID
+	}
+	key, ok := k.Key(id)
+	if !ok || !key.canDecrypt() {
+		return "", fmt.Errorf("%w: %s can not decrypt", ErrKeyNotFound, id)
+	}
+	if env != nil {
+		return env.Open(key.Secret)
+	}
+	return Decrypt(str, key.Secret)
+}
+
+// NeedReEncrypt 判断密文是否需要使用指定的密钥重新加密 id 为空时使用 active 密钥
+func (k *Keyring) NeedReEncrypt(str string, id string) bool {
+	if id == "" {
+		id = k.ActiveID()
+	}
+	alg := k.alg
+	if key, ok := k.Key(id); ok {
+		alg = k.algorithm(key)
+	}
+	return NeedReEncrypt(str, alg, id)
+}
+
+// ReEncrypt 使用指定的密钥重新加密 id 为空时使用 active 密钥
+func (k *Keyring) ReEncrypt(str string, id string) (string, error) {
+	plain, err := k.Decrypt(str)
+	if err != nil {
+		return "", err
+	}
+	if id == "" {
+		id = k.ActiveID()
+	}
+	return k.EncryptWithKey(id, plain)
+}
@@ -0,0 +1,67 @@
+package secret
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/config"
+)
+
+func TestKeyring(t *testing.T) {
+	ctx := context.Background()
+	data := &config.Data{Secret: "default-secret", Secrets: map[string]string{"k1": "k1-secret"}}
+	k, err := NewKeyring(ctx, []KeySource{FromConfig(data)})
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultKeyID, k.ActiveID())
+
+	enc, err := k.Encrypt("str")
+	assert.NoError(t, err)
+	e, _ := ParseEnvelope(enc)
+	assert.Equal(t, DefaultKeyID, e.KeyID)
+
+	legacy, err := Encrypt("legacy", "1234567812345678")
+	assert.NoError(t, err)
+
+	// 轮换: default 仅解密 k2 来自文件并为 active
+	file := filepath.Join(t.TempDir(), "keys.json")
+	assert.NoError(t, os.WriteFile(file, []byte(`[{"id":"k2","secret":"k2-secret"},{"id":"k3","secret":"k3","status":"retired"}]`), 0o600))
+	t.Setenv("TEST_SECRET_k4", "k4-secret")
+	k, err = NewKeyring(ctx, []KeySource{FromConfig(data), FromFile(file), FromEnv("TEST_SECRET_")},
+		WithKeyStatus(DefaultKeyID, KeyDecryptOnly), WithKeyStatus("k1", KeyDecryptOnly), WithActiveKey("k2"))
+	assert.NoError(t, err)
+	assert.Equal(t, "k2", k.ActiveID())
+	key, ok := k.Key("k4")
+	assert.True(t, ok)
+	assert.Equal(t, KeyActive, key.Status)
+
+	dec, err := k.Decrypt(enc)
+	assert.NoError(t, err)
+	assert.Equal(t, "str", dec)
+	assert.True(t, k.NeedReEncrypt(enc, ""))
+	_, err = k.EncryptWithKey(DefaultKeyID, "str")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	_, err = k.EncryptWithKey("k3", "str")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+
+	reEnc, err := k.ReEncrypt(enc, "")
+	assert.NoError(t, err)
+	assert.False(t, k.NeedReEncrypt(reEnc, ""))
+	dec, err = k.Decrypt(reEnc)
+	assert.NoError(t, err)
+	assert.Equal(t, "str", dec)
+
+	// 旧的 CBC 密文使用 DefaultKeyID
+	_, err = k.Decrypt(legacy)
+	assert.Error(t, err)
+	k, err = NewKeyring(ctx, []KeySource{KeySourceFunc(func(context.Context) ([]*Key, error) {
+		return []*Key{{ID: DefaultKeyID, Secret: "1234567812345678", Status: KeyDecryptOnly}}, nil
+	})})
+	assert.NoError(t, err)
+	dec, err = k.Decrypt(legacy)
+	assert.NoError(t, err)
+	assert.Equal(t, "legacy", dec)
+	assert.Equal(t, "", k.ActiveID())
+}
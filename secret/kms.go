@@ -0,0 +1,220 @@
+// Package secret kms.go
+package secret
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// KMS 密钥管理服务 KEK(密钥加密密钥)保存在 KMS 中, 仅提供 DEK(数据加密密钥)的包装与解包
+type KMS interface {
+	// WrapKey 使用 KEK 包装 DEK
+	WrapKey(ctx context.Context, kekID string, dek []byte) (string, error)
+	// UnwrapKey 使用 KEK 解包 DEK
+	UnwrapKey(ctx context.Context, kekID string, wrapped string) ([]byte, error)
+}
+
+// dekLen DEK 的长度 用于 AES-256 与 ChaCha20
+const dekLen = 32
+
+// kmsKeyIDPrefix 信封加密的密文中 密钥 ID 的前缀 其后为 KEK ID 与包装后的 DEK
+const kmsKeyIDPrefix = "kms:"
+
+// GenerateDEK 生成 DEK 并使用 KMS 包装 包装后的 DEK 可保存在配置或数据库中
+func GenerateDEK(ctx context.Context, kms KMS, kekID string) ([]byte, string, error) {
+	dek := make([]byte, dekLen)
+	if _, err := rand.Read(dek); err != nil {
+		return nil, "", err
+	}
+	wrapped, err := kms.WrapKey(ctx, kekID, dek)
+	if err != nil {
+		return nil, "", err
+	}
+	return dek, wrapped, nil
+}
+
+// FromKMS 从 KMS 加载密钥 wrapped 为密钥 ID 与包装后的 DEK 配置中不出现明文密钥
+// 通常每张表一个 DEK, 与 ann.Table.SecretKey 对应, 状态均为 active
+func FromKMS(kms KMS, kekID string, wrapped map[string]string) KeySource {
+	return KeySourceFunc(func(ctx context.Context) ([]*Key, error) {
+		keys := make([]*Key, 0, len(wrapped))
+		for id, w := range wrapped {
+			dek, err := kms.UnwrapKey(ctx, kekID, w)
+			if err != nil {
+				return nil, fmt.Errorf("secret: unwrap key %s: %w", id, err)
+			}
+			keys = append(keys, &Key{ID: id, Secret: string(dek), Status: KeyActive})
+		}
+		return keys, nil
+	})
+}
+
+// KMSOption 信封加密的配置
+type KMSOption func(*KMSEncryptor)
+
+// WithDEKTTL 解包后的 DEK 在内存中的缓存时间 同时也是加密时复用同一 DEK 的时间 默认 5 分钟
+// 为 0 时不缓存, 每次加密生成新的 DEK
+func WithDEKTTL(ttl time.Duration) KMSOption {
+	return func(e *KMSEncryptor) {
+		e.cache.ttl = ttl
+	}
+}
+
+// WithKMSAlgorithm 设置加密算法 默认 AES-256-GCM
+func WithKMSAlgorithm(alg Algorithm) KMSOption {
+	return func(e *KMSEncryptor) {
+		e.alg = alg
+	}
+}
+
+// KMSEncryptor 信封加密 每条记录(缓存有效期内复用)使用随机 DEK 加密, DEK 由 KMS 的 KEK 包装后与密文一起保存
+// 密文为 Envelope 格式, 密钥 ID 为 kms:KEK ID:包装后的 DEK (均为 base64 URL 编码)
+type KMSEncryptor struct {
+	kms   KMS
+	kekID string
+	alg   Algorithm
+	cache *dekCache
+
+	mu      sync.Mutex
+	current *currentDEK
+}
+
+type currentDEK struct {
+	dek     []byte
+	keyID   string
+	expires time.Time
+}
+
+// NewKMSEncryptor 创建信封加密
+func NewKMSEncryptor(kms KMS, kekID string, opts ...KMSOption) *KMSEncryptor {
+	e := &KMSEncryptor{
+		kms:   kms,
+		kekID: kekID,
+		alg:   AlgAES256GCM,
+		cache: &dekCache{ttl: 5 * time.Minute, items: map[string]*cachedDEK{}},
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Encrypt 加密
+func (e *KMSEncryptor) Encrypt(ctx context.Context, str string) (string, error) {
+	dek, keyID, err := e.dataKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	return EncryptAEAD(str, string(dek), e.alg, keyID)
+}
+
+// Decrypt 解密 解包 DEK 时优先使用缓存
+func (e *KMSEncryptor) Decrypt(ctx context.Context, str string) (string, error) {
+	env, err := ParseEnvelope(str)
+	if err != nil {
+		return "", err
+	}
+	kekID, wrapped, err := parseKMSKeyID(env.KeyID)
+	if err != nil {
+		return "", err
+	}
+	dek, ok := e.cache.get(env.KeyID)
+	if !ok {
+		if dek, err = e.kms.UnwrapKey(ctx, kekID, wrapped); err != nil {
+			return "", err
+		}
+		e.cache.set(env.KeyID, dek)
+	}
+	return env.Open(string(dek))
+}
+
+// IsKMSEnvelope 判断是否为信封加密的密文
+func IsKMSEnvelope(str string) bool {
+	env, err := ParseEnvelope(str)
+	return err == nil && strings.HasPrefix(env.KeyID, kmsKeyIDPrefix)
+}
+
+// dataKey 获取用于加密的 DEK 缓存有效期内复用
+func (e *KMSEncryptor) dataKey(ctx context.Context) ([]byte, string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.current != nil && time.Now().Before(e.current.expires) {
+		return e.current.dek, e.current.keyID, nil
+	}
+	dek, wrapped, err := GenerateDEK(ctx, e.kms, e.kekID)
+	if err != nil {
+		return nil, "", err
+	}
+	keyID := kmsKeyIDPrefix + base64.RawURLEncoding.EncodeToString([]byte(e.kekID)) + ":" +
+		base64.RawURLEncoding.EncodeToString([]byte(wrapped))
+	e.cache.set(keyID, dek)
+	if e.cache.ttl > 0 {
+		e.current = &currentDEK{dek: dek, keyID: keyID, expires: time.Now().Add(e.cache.ttl)}
+	}
+	return dek, keyID, nil
+}
+
+func parseKMSKeyID(keyID string) (string, string, error) {
+	rest, ok := strings.CutPrefix(keyID, kmsKeyIDPrefix)
+	if !ok {
+		return "", "", ErrInvalidEnvelope
+	}
+	kek, wrapped, ok := strings.Cut(rest, ":")
+	if !ok {
+		return "", "", ErrInvalidEnvelope
+	}
+	kekID, err := base64.RawURLEncoding.DecodeString(kek)
+	if err != nil {
+		return "", "", ErrInvalidEnvelope
+	}
+	w, err := base64.RawURLEncoding.DecodeString(wrapped)
+	if err != nil {
+		return "", "", ErrInvalidEnvelope
+	}
+	return string(kekID), string(w), nil
+}
+
+// dekCache 解包后的 DEK 缓存
+type dekCache struct {
+	ttl   time.Duration
+	mu    sync.Mutex
+	items map[string]*cachedDEK
+}
+
+type cachedDEK struct {
+	dek     []byte
+	expires time.Time
+}
+
+func (c *dekCache) get(key string) ([]byte, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	item, ok := c.items[key]
+	if !ok || time.Now().After(item.expires) {
+		return nil, false
+	}
+	return item.dek, true
+}
+
+func (c *dekCache) set(key string, dek []byte) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for k, item := range c.items {
+		if now.After(item.expires) {
+			delete(c.items, k)
+		}
+	}
+	c.items[key] = &cachedDEK{dek: dek, expires: now.Add(c.ttl)}
+}
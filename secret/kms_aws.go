@@ -0,0 +1,127 @@
+// Package secret kms_aws.go
+package secret
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// AWSKMS AWS KMS 使用 Signature Version 4 签名调用 JSON API
+type AWSKMS struct {
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// 临时凭证的令牌 可为空
+	SessionToken string
+	// 为空时使用 https://kms.{Region}.amazonaws.com
+	Endpoint string
+	// 为空时使用 http.DefaultClient
+	Client *http.Client
+}
+
+type awsKMSResponse struct {
+	CiphertextBlob string `json:"CiphertextBlob"`
+	Plaintext      string `json:"Plaintext"`
+	Type           string `json:"__type"`
+	Message        string `json:"message"`
+}
+
+// WrapKey _
+func (a *AWSKMS) WrapKey(ctx context.Context, kekID string, dek []byte) (string, error) {
+	resp := &awsKMSResponse{}
+	body := map[string]string{"KeyId": kekID, "Plaintext": base64.StdEncoding.EncodeToString(dek)}
+	if err := a.call(ctx, "Encrypt", body, resp); err != nil {
+		return "", err
+	}
+	return resp.CiphertextBlob, nil
+}
+
+// UnwrapKey _
+func (a *AWSKMS) UnwrapKey(ctx context.Context, kekID string, wrapped string) ([]byte, error) {
+	resp := &awsKMSResponse{}
+	if err := a.call(ctx, "Decrypt", map[string]string{"KeyId": kekID, "CiphertextBlob": wrapped}, resp); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(resp.Plaintext)
+}
+
+func (a *AWSKMS) call(ctx context.Context, action string, body interface{}, resp *awsKMSResponse) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	endpoint := a.Endpoint
+	if endpoint == "" {
+		endpoint = "https://kms." + a.Region + ".amazonaws.com"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/x-amz-json-1.1")
+	header.Set("X-Amz-Target", "TrentService."+action)
+	if a.SessionToken != "" {
+		header.Set("X-Amz-Security-Token", a.SessionToken)
+	}
+	a.sign(header, u.Host, payload, time.Now().UTC())
+	return doJSON(ctx, a.Client, endpoint+"/", header, payload, resp, func() string {
+		if resp.Type == "" {
+			return ""
+		}
+		return resp.Type + ": " + resp.Message
+	})
+}
+
+// sign Signature Version 4 签名 请求为 POST / 无查询参数
+func (a *AWSKMS) sign(header http.Header, host string, payload []byte, now time.Time) {
+	const service = "kms"
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	header.Set("X-Amz-Date", amzDate)
+	payloadHash := sha256Hex(payload)
+
+	signedHeaders := "content-type;host;x-amz-date;x-amz-target"
+	canonicalHeaders := "content-type:" + header.Get("Content-Type") + "\n" +
+		"host:" + host + "\n" +
+		"x-amz-date:" + amzDate + "\n" +
+		"x-amz-target:" + header.Get("X-Amz-Target") + "\n"
+	if token := header.Get("X-Amz-Security-Token"); token != "" {
+		signedHeaders = "content-type;host;x-amz-date;x-amz-security-token;x-amz-target"
+		canonicalHeaders = "content-type:" + header.Get("Content-Type") + "\n" +
+			"host:" + host + "\n" +
+			"x-amz-date:" + amzDate + "\n" +
+			"x-amz-security-token:" + token + "\n" +
+			"x-amz-target:" + header.Get("X-Amz-Target") + "\n"
+	}
+	canonicalRequest := "POST\n/\n\n" + canonicalHeaders + "\n" + signedHeaders + "\n" + payloadHash
+	scope := date + "/" + a.Region + "/" + service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+a.SecretAccessKey), date)
+	key = hmacSHA256(key, a.Region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+a.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	m := hmac.New(sha256.New, key)
+	m.Write([]byte(data))
+	return m.Sum(nil)
+}
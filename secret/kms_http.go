@@ -0,0 +1,53 @@
+// Package secret kms_http.go
+package secret
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// postJSON 发送 JSON 请求并解析响应 errMsg 用于获取响应中的错误信息
+func postJSON(ctx context.Context, client *http.Client, url string, header http.Header, body, resp interface{}, errMsg func() string) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return doJSON(ctx, client, url, header, b, resp, errMsg)
+}
+
+// doJSON 发送已序列化的 JSON 请求 签名需要使用原始的请求体
+func doJSON(ctx context.Context, client *http.Client, url string, header http.Header, body []byte, resp interface{}, errMsg func() string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, resp); err != nil && res.StatusCode < http.StatusBadRequest {
+		return fmt.Errorf("secret: kms response: %w", err)
+	}
+	if msg := errMsg(); msg != "" || res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("secret: kms request failed with status %d: %s", res.StatusCode, msg)
+	}
+	return nil
+}
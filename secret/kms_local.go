@@ -0,0 +1,64 @@
+// Package secret kms_local.go
+package secret
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// LocalKMS 基于本地文件的 KMS 仅用于开发与测试
+// 文件为 JSON 格式 {"KEK ID": "base64 编码的 32 字节密钥"}
+type LocalKMS struct {
+	keks map[string][]byte
+}
+
+// NewLocalKMS 从文件加载 KEK
+func NewLocalKMS(path string) (*LocalKMS, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	encoded := map[string]string{}
+	if err := json.Unmarshal(b, &encoded); err != nil {
+		return nil, fmt.Errorf("secret: parse kms file %s: %w", path, err)
+	}
+	keks := make(map[string][]byte, len(encoded))
+	for id, v := range encoded {
+		kek, err := base64.StdEncoding.DecodeString(v)
+		if err != nil || len(kek) != dekLen {
+			return nil, fmt.Errorf("secret: invalid kek %s in %s", id, path)
+		}
+		keks[id] = kek
+	}
+	return NewLocalKMSFromKeys(keks), nil
+}
+
+// NewLocalKMSFromKeys 使用内存中的 KEK 创建
+func NewLocalKMSFromKeys(keks map[string][]byte) *LocalKMS {
+	return &LocalKMS{keks: keks}
+}
+
+// WrapKey _
+func (l *LocalKMS) WrapKey(_ context.Context, kekID string, dek []byte) (string, error) {
+	kek, ok := l.keks[kekID]
+	if !ok {
+		return "", fmt.Errorf("%w: kek %s", ErrKeyNotFound, kekID)
+	}
+	return EncryptAEAD(base64.StdEncoding.EncodeToString(dek), string(kek), AlgAES256GCM, "")
+}
+
+// UnwrapKey _
+func (l *LocalKMS) UnwrapKey(_ context.Context, kekID string, wrapped string) ([]byte, error) {
+	kek, ok := l.keks[kekID]
+	if !ok {
+		return nil, fmt.Errorf("%w: kek %s", ErrKeyNotFound, kekID)
+	}
+	encoded, err := DecryptAEAD(wrapped, string(kek))
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(encoded)
+}
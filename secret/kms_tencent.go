@@ -0,0 +1,108 @@
+// Package secret kms_tencent.go
+package secret
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// TencentKMS 腾讯云 KMS 使用 TC3-HMAC-SHA256 签名调用 API 3.0
+type TencentKMS struct {
+	Region    string
+	SecretID  string
+	SecretKey string
+	// 临时凭证的令牌 可为空
+	Token string
+	// 为空时使用 https://kms.tencentcloudapi.com
+	Endpoint string
+	// 为空时使用 http.DefaultClient
+	Client *http.Client
+}
+
+type tencentKMSResponse struct {
+	Response struct {
+		CiphertextBlob string `json:"CiphertextBlob"`
+		Plaintext      string `json:"Plaintext"`
+		Error          *struct {
+			Code    string `json:"Code"`
+			Message string `json:"Message"`
+		} `json:"Error"`
+	} `json:"Response"`
+}
+
+// WrapKey _
+func (t *TencentKMS) WrapKey(ctx context.Context, kekID string, dek []byte) (string, error) {
+	resp := &tencentKMSResponse{}
+	body := map[string]string{"KeyId": kekID, "Plaintext": base64.StdEncoding.EncodeToString(dek)}
+	if err := t.call(ctx, "Encrypt", body, resp); err != nil {
+		return "", err
+	}
+	return resp.Response.CiphertextBlob, nil
+}
+
+// UnwrapKey 腾讯云 KMS 的密文中包含 KEK 信息 kekID 仅用于接口一致
+func (t *TencentKMS) UnwrapKey(ctx context.Context, _ string, wrapped string) ([]byte, error) {
+	resp := &tencentKMSResponse{}
+	if err := t.call(ctx, "Decrypt", map[string]string{"CiphertextBlob": wrapped}, resp); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(resp.Response.Plaintext)
+}
+
+func (t *TencentKMS) call(ctx context.Context, action string, body interface{}, resp *tencentKMSResponse) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	endpoint := t.Endpoint
+	if endpoint == "" {
+		endpoint = "https://kms.tencentcloudapi.com"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/json; charset=utf-8")
+	header.Set("X-TC-Action", action)
+	header.Set("X-TC-Version", "2019-01-18")
+	header.Set("X-TC-Region", t.Region)
+	if t.Token != "" {
+		header.Set("X-TC-Token", t.Token)
+	}
+	t.sign(header, u.Host, payload, time.Now())
+	return doJSON(ctx, t.Client, endpoint+"/", header, payload, resp, func() string {
+		if resp.Response.Error == nil {
+			return ""
+		}
+		return resp.Response.Error.Code + ": " + resp.Response.Error.Message
+	})
+}
+
+// sign TC3-HMAC-SHA256 签名 请求为 POST / 无查询参数
+func (t *TencentKMS) sign(header http.Header, host string, payload []byte, now time.Time) {
+	const service = "kms"
+	timestamp := now.Unix()
+	date := now.UTC().Format("2006-01-02")
+	header.Set("X-TC-Timestamp", strconv.FormatInt(timestamp, 10))
+
+	signedHeaders := "content-type;host"
+	canonicalHeaders := "content-type:" + header.Get("Content-Type") + "\nhost:" + host + "\n"
+	canonicalRequest := "POST\n/\n\n" + canonicalHeaders + "\n" + signedHeaders + "\n" + sha256Hex(payload)
+	scope := date + "/" + service + "/tc3_request"
+	stringToSign := "TC3-HMAC-SHA256\n" + strconv.FormatInt(timestamp, 10) + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("TC3"+t.SecretKey), date)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "tc3_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	header.Set("Authorization", "TC3-HMAC-SHA256 Credential="+t.SecretID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
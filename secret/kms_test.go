@@ -0,0 +1,162 @@
+package secret
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countKMS 统计解包次数
+type countKMS struct {
+	KMS
+	unwrap int
+}
+
+func (c *countKMS) UnwrapKey(ctx context.Context, kekID string, wrapped string) ([]byte, error) {
+	c.unwrap++
+	return c.KMS.UnwrapKey(ctx, kekID, wrapped)
+}
+
+func newLocalKMS(t *testing.T) *LocalKMS {
+	file := filepath.Join(t.TempDir(), "kms.json")
+	kek := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+	assert.NoError(t, os.WriteFile(file, []byte(`{"kek1":"`+kek+`"}`), 0o600))
+	kms, err := NewLocalKMS(file)
+	assert.NoError(t, err)
+	return kms
+}
+
+func TestKMSEncryptor(t *testing.T) {
+	ctx := context.Background()
+	kms := &countKMS{KMS: newLocalKMS(t)}
+
+	e := NewKMSEncryptor(kms, "kek1")
+	enc1, err := e.Encrypt(ctx, "13800138000")
+	assert.NoError(t, err)
+	enc2, err := e.Encrypt(ctx, "13800138001")
+	assert.NoError(t, err)
+	assert.True(t, IsKMSEnvelope(enc1))
+	env1, _ := ParseEnvelope(enc1)
+	env2, _ := ParseEnvelope(enc2)
+	// 缓存有效期内复用 DEK
+	assert.Equal(t, env1.KeyID, env2.KeyID)
+
+	// 新实例解密需要解包 之后使用缓存
+	d := NewKMSEncryptor(kms, "kek1")
+	for _, c := range []struct{ enc, plain string }{{enc1, "13800138000"}, {enc2, "13800138001"}} {
+		plain, err := d.Decrypt(ctx, c.enc)
+		assert.NoError(t, err)
+		assert.Equal(t, c.plain, plain)
+	}
+	assert.Equal(t, 1, kms.unwrap)
+
+	// 不缓存时每次加密使用新的 DEK
+	n := NewKMSEncryptor(kms, "kek1", WithDEKTTL(0))
+	enc3, _ := n.Encrypt(ctx, "a")
+	enc4, _ := n.Encrypt(ctx, "a")
+	env3, _ := ParseEnvelope(enc3)
+	env4, _ := ParseEnvelope(enc4)
+	assert.NotEqual(t, env3.KeyID, env4.KeyID)
+	plain, err := n.Decrypt(ctx, enc4)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", plain)
+
+	_, err = NewKMSEncryptor(kms, "other").Encrypt(ctx, "a")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestFromKMS(t *testing.T) {
+	ctx := context.Background()
+	kms := newLocalKMS(t)
+	dek, wrapped, err := GenerateDEK(ctx, kms, "kek1")
+	assert.NoError(t, err)
+
+	k, err := NewKeyring(ctx, []KeySource{FromKMS(kms, "kek1", map[string]string{"user": wrapped})})
+	assert.NoError(t, err)
+	key, ok := k.Key("user")
+	assert.True(t, ok)
+	assert.Equal(t, string(dek), key.Secret)
+	assert.Equal(t, "user", k.ActiveID())
+}
+
+func TestVaultTransit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("X-Vault-Token"))
+		body := map[string]string{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/kek1":
+			_, _ = w.Write([]byte(`{"data":{"ciphertext":"vault:v1:` + body["plaintext"] + `"}}`))
+		case "/v1/transit/decrypt/kek1":
+			_, _ = w.Write([]byte(`{"data":{"plaintext":"` + strings.TrimPrefix(body["ciphertext"], "vault:v1:") + `"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":["not found"]}`))
+		}
+	}))
+	defer srv.Close()
+
+	v := &VaultTransit{Addr: srv.URL, Token: "token"}
+	wrapped, err := v.WrapKey(context.Background(), "kek1", []byte("dek"))
+	assert.NoError(t, err)
+	dek, err := v.UnwrapKey(context.Background(), "kek1", wrapped)
+	assert.NoError(t, err)
+	assert.Equal(t, "dek", string(dek))
+	_, err = v.WrapKey(context.Background(), "kek2", []byte("dek"))
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestCloudKMS(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]string{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		auth := r.Header.Get("Authorization")
+		switch {
+		case r.Header.Get("X-Amz-Target") == "TrentService.Encrypt":
+			assert.True(t, strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=ak/"))
+			_, _ = w.Write([]byte(`{"CiphertextBlob":"` + body["Plaintext"] + `"}`))
+		case r.Header.Get("X-Amz-Target") == "TrentService.Decrypt":
+			_, _ = w.Write([]byte(`{"Plaintext":"` + body["CiphertextBlob"] + `"}`))
+		case r.Header.Get("X-TC-Action") == "Encrypt":
+			assert.True(t, strings.HasPrefix(auth, "TC3-HMAC-SHA256 Credential=sid/"))
+			_, _ = w.Write([]byte(`{"Response":{"CiphertextBlob":"` + body["Plaintext"] + `"}}`))
+		case r.Header.Get("X-TC-Action") == "Decrypt":
+			_, _ = w.Write([]byte(`{"Response":{"Error":{"Code":"InvalidParameter","Message":"bad"}}}`))
+		}
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	aws := &AWSKMS{Region: "us-east-1", AccessKeyID: "ak", SecretAccessKey: "sk", Endpoint: srv.URL}
+	wrapped, err := aws.WrapKey(ctx, "kek1", []byte("dek"))
+	assert.NoError(t, err)
+	dek, err := aws.UnwrapKey(ctx, "kek1", wrapped)
+	assert.NoError(t, err)
+	assert.Equal(t, "dek", string(dek))
+
+	tc := &TencentKMS{Region: "ap-guangzhou", SecretID: "sid", SecretKey: "skey", Endpoint: srv.URL}
+	_, err = tc.WrapKey(ctx, "kek1", []byte("dek"))
+	assert.NoError(t, err)
+	_, err = tc.UnwrapKey(ctx, "kek1", "x")
+	assert.ErrorContains(t, err, "InvalidParameter")
+}
+
+func TestAWSSign(t *testing.T) {
+	a := &AWSKMS{Region: "us-east-1", AccessKeyID: "ak", SecretAccessKey: "sk"}
+	h1, h2 := http.Header{}, http.Header{}
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	a.sign(h1, "kms.us-east-1.amazonaws.com", []byte("{}"), now)
+	a.sign(h2, "kms.us-east-1.amazonaws.com", []byte(`{"a":1}`), now)
+	assert.Equal(t, "20240102T030405Z", h1.Get("X-Amz-Date"))
+	assert.Contains(t, h1.Get("Authorization"), "Credential=ak/20240102/us-east-1/kms/aws4_request")
+	assert.NotEqual(t, h1.Get("Authorization"), h2.Get("Authorization"))
+}
@@ -0,0 +1,63 @@
+// Package secret kms_vault.go
+package secret
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+)
+
+// VaultTransit HashiCorp Vault 的 Transit 引擎
+type VaultTransit struct {
+	// Vault 地址 如 https://vault.example.com:8200
+	Addr string
+	// 访问令牌
+	Token string
+	// Transit 引擎的挂载路径 默认 transit
+	Mount string
+	// 命名空间 企业版使用
+	Namespace string
+	// 为空时使用 http.DefaultClient
+	Client *http.Client
+}
+
+type vaultResponse struct {
+	Data struct {
+		Ciphertext string `json:"ciphertext"`
+		Plaintext  string `json:"plaintext"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+// WrapKey _
+func (v *VaultTransit) WrapKey(ctx context.Context, kekID string, dek []byte) (string, error) {
+	resp := &vaultResponse{}
+	body := map[string]string{"plaintext": base64.StdEncoding.EncodeToString(dek)}
+	if err := v.call(ctx, "encrypt", kekID, body, resp); err != nil {
+		return "", err
+	}
+	return resp.Data.Ciphertext, nil
+}
+
+// UnwrapKey _
+func (v *VaultTransit) UnwrapKey(ctx context.Context, kekID string, wrapped string) ([]byte, error) {
+	resp := &vaultResponse{}
+	if err := v.call(ctx, "decrypt", kekID, map[string]string{"ciphertext": wrapped}, resp); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(resp.Data.Plaintext)
+}
+
+func (v *VaultTransit) call(ctx context.Context, op, kekID string, body interface{}, resp *vaultResponse) error {
+	mount := v.Mount
+	if mount == "" {
+		mount = "transit"
+	}
+	url := strings.TrimSuffix(v.Addr, "/") + "/v1/" + mount + "/" + op + "/" + kekID
+	header := http.Header{"X-Vault-Token": {v.Token}}
+	if v.Namespace != "" {
+		header.Set("X-Vault-Namespace", v.Namespace)
+	}
+	return postJSON(ctx, v.Client, url, header, body, resp, func() string { return strings.Join(resp.Errors, "; ") })
+}
@@ -0,0 +1,292 @@
+// Package secret password.go
+package secret
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/emmansun/gmsm/sm3"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// PasswordAlgorithm 密码哈希算法
+type PasswordAlgorithm string
+
+const (
+	// PasswordArgon2id argon2id 默认
+	PasswordArgon2id PasswordAlgorithm = "argon2id"
+	// PasswordBcrypt bcrypt
+	PasswordBcrypt PasswordAlgorithm = "bcrypt"
+	// PasswordScrypt scrypt
+	PasswordScrypt PasswordAlgorithm = "scrypt"
+	// PasswordPBKDF2SM3 以 SM3 为哈希函数的 PBKDF2 用于需要国密算法的场景
+	PasswordPBKDF2SM3 PasswordAlgorithm = "pbkdf2-sm3"
+)
+
+// ErrInvalidPasswordHash 密码哈希格式错误
+var ErrInvalidPasswordHash = errors.New("secret: invalid password hash")
+
+// PasswordOption 配置
+type PasswordOption func(*PasswordHasher)
+
+// WithPasswordAlgorithm 设置哈希算法 默认 argon2id
+func WithPasswordAlgorithm(alg PasswordAlgorithm) PasswordOption {
+	return func(h *PasswordHasher) {
+		h.alg = alg
+	}
+}
+
+// WithArgon2Params 设置 argon2id 的参数 time 为迭代次数 memory 单位为 KiB threads 为并行度
+func WithArgon2Params(time, memory uint32, threads uint8) PasswordOption {
+	return func(h *PasswordHasher) {
+		h.argonTime, h.argonMemory, h.argonThreads = time, memory, threads
+	}
+}
+
+// WithBcryptCost 设置 bcrypt 的 cost
+func WithBcryptCost(cost int) PasswordOption {
+	return func(h *PasswordHasher) {
+		h.bcryptCost = cost
+	}
+}
+
+// WithScryptParams 设置 scrypt 的参数 logN 为 N 的以 2 为底的对数
+func WithScryptParams(logN uint8, r, p int) PasswordOption {
+	return func(h *PasswordHasher) {
+		h.scryptLogN, h.scryptR, h.scryptP = logN, r, p
+	}
+}
+
+// WithPBKDF2Iterations 设置 pbkdf2-sm3 的迭代次数
+func WithPBKDF2Iterations(iterations int) PasswordOption {
+	return func(h *PasswordHasher) {
+		h.pbkdf2Iter = iterations
+	}
+}
+
+// WithLegacySecret 设置旧的 nonce/sha256 方案(IrreversibleEncrypt)的密钥 用于验证旧的密码哈希
+func WithLegacySecret(secret string) PasswordOption {
+	return func(h *PasswordHasher) {
+		h.legacySecret = secret
+	}
+}
+
+// PasswordHasher 密码哈希 生成 PHC 格式的哈希
+// argon2id: $argon2id$v=19$m=65536,t=3,p=2$salt$hash
+// scrypt:   $scrypt$ln=15,r=8,p=1$salt$hash
+// pbkdf2-sm3: $pbkdf2-sm3$i=100000$salt$hash
+// bcrypt:   $2a$10$...
+type PasswordHasher struct {
+	alg          PasswordAlgorithm
+	argonTime    uint32
+	argonMemory  uint32
+	argonThreads uint8
+	bcryptCost   int
+	scryptLogN   uint8
+	scryptR      int
+	scryptP      int
+	pbkdf2Iter   int
+	legacySecret string
+}
+
+const (
+	passwordSaltLen = 16
+	passwordKeyLen  = 32
+)
+
+// NewPasswordHasher 创建密码哈希
+func NewPasswordHasher(opts ...PasswordOption) *PasswordHasher {
+	h := &PasswordHasher{
+		alg:          PasswordArgon2id,
+		argonTime:    3,
+		argonMemory:  64 * 1024,
+		argonThreads: 2,
+		bcryptCost:   bcrypt.DefaultCost,
+		scryptLogN:   15,
+		scryptR:      8,
+		scryptP:      1,
+		pbkdf2Iter:   100000,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Hash 生成密码哈希
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	switch h.alg {
+	case PasswordArgon2id:
+		salt, err := randomBytes(passwordSaltLen)
+		if err != nil {
+			return "", err
+		}
+		key := argon2.IDKey([]byte(password), salt, h.argonTime, h.argonMemory, h.argonThreads, passwordKeyLen)
+		return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, h.argonMemory, h.argonTime, h.argonThreads,
+			b64(salt), b64(key)), nil
+	case PasswordBcrypt:
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.bcryptCost)
+		return string(hash), err
+	case PasswordScrypt:
+		salt, err := randomBytes(passwordSaltLen)
+		if err != nil {
+			return "", err
+		}
+		key, err := scrypt.Key([]byte(password), salt, 1<<h.scryptLogN, h.scryptR, h.scryptP, passwordKeyLen)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("$scrypt$ln=%d,r=%d,p=%d$%s$%s", h.scryptLogN, h.scryptR, h.scryptP, b64(salt), b64(key)), nil
+	case PasswordPBKDF2SM3:
+		salt, err := randomBytes(passwordSaltLen)
+		if err != nil {
+			return "", err
+		}
+		key := pbkdf2.Key([]byte(password), salt, h.pbkdf2Iter, passwordKeyLen, sm3.New)
+		return fmt.Sprintf("$%s$i=%d$%s$%s", PasswordPBKDF2SM3, h.pbkdf2Iter, b64(salt), b64(key)), nil
+	}
+	return "", fmt.Errorf("secret: unsupported password algorithm %s", h.alg)
+}
+
+// Verify 验证密码 nonce 仅用于旧的 nonce/sha256 方案, 新的哈希传空即可
+func (h *PasswordHasher) Verify(password, encoded, nonce string) bool {
+	if password == "" || encoded == "" {
+		return false
+	}
+	if isLegacyPasswordHash(encoded) {
+		return VerifyIrreversible(encoded, password, nonce, h.legacySecret)
+	}
+	if isBcryptHash(encoded) {
+		return bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)) == nil
+	}
+	p, err := parsePHC(encoded)
+	if err != nil {
+		return false
+	}
+	var key []byte
+	switch p.alg {
+	case PasswordArgon2id:
+		key = argon2.IDKey([]byte(password), p.salt, uint32(p.params["t"]), uint32(p.params["m"]), uint8(p.params["p"]), uint32(len(p.hash)))
+	case PasswordScrypt:
+		if p.params["ln"] >= 32 {
+			return false
+		}
+		key, err = scrypt.Key([]byte(password), p.salt, 1<<p.params["ln"], p.params["r"], p.params["p"], len(p.hash))
+		if err != nil {
+			return false
+		}
+	case PasswordPBKDF2SM3:
+		key = pbkdf2.Key([]byte(password), p.salt, p.params["i"], len(p.hash), sm3.New)
+	default:
+		return false
+	}
+	return subtle.ConstantTimeCompare(key, p.hash) == 1
+}
+
+// NeedsRehash 判断哈希是否需要使用当前的算法与参数重新生成 如旧的 nonce/sha256 方案或参数已调整
+// 通常在登录验证成功后检查, 使用明文密码重新生成哈希
+func (h *PasswordHasher) NeedsRehash(encoded string) bool {
+	if isLegacyPasswordHash(encoded) {
+		return true
+	}
+	if isBcryptHash(encoded) {
+		if h.alg != PasswordBcrypt {
+			return true
+		}
+		cost, err := bcrypt.Cost([]byte(encoded))
+		return err != nil || cost != h.bcryptCost
+	}
+	p, err := parsePHC(encoded)
+	if err != nil || p.alg != h.alg {
+		return true
+	}
+	switch p.alg {
+	case PasswordArgon2id:
+		return p.version != argon2.Version || p.params["m"] != int(h.argonMemory) ||
+			p.params["t"] != int(h.argonTime) || p.params["p"] != int(h.argonThreads)
+	case PasswordScrypt:
+		return p.params["ln"] != int(h.scryptLogN) || p.params["r"] != h.scryptR || p.params["p"] != h.scryptP
+	case PasswordPBKDF2SM3:
+		return p.params["i"] != h.pbkdf2Iter
+	}
+	return true
+}
+
+// isLegacyPasswordHash 旧的 nonce/sha256 方案 为 64 位十六进制
+func isLegacyPasswordHash(encoded string) bool {
+	if len(encoded) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(encoded)
+	return err == nil
+}
+
+func isBcryptHash(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
+
+type phc struct {
+	alg     PasswordAlgorithm
+	version int
+	params  map[string]int
+	salt    []byte
+	hash    []byte
+}
+
+// parsePHC 解析 PHC 格式 $alg[$v=version]$params$salt$hash
+func parsePHC(encoded string) (*phc, error) {
+	parts := strings.Split(strings.TrimPrefix(encoded, "$"), "$")
+	if len(parts) == 5 && strings.HasPrefix(parts[1], "v=") {
+		version := 0
+		if _, err := fmt.Sscanf(parts[1], "v=%d", &version); err != nil {
+			return nil, ErrInvalidPasswordHash
+		}
+		p, err := parsePHC("$" + parts[0] + "$" + strings.Join(parts[2:], "$"))
+		if err != nil {
+			return nil, err
+		}
+		p.version = version
+		return p, nil
+	}
+	if len(parts) != 4 {
+		return nil, ErrInvalidPasswordHash
+	}
+	p := &phc{alg: PasswordAlgorithm(parts[0]), params: map[string]int{}}
+	for _, kv := range strings.Split(parts[1], ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, ErrInvalidPasswordHash
+		}
+		n := 0
+		if _, err := fmt.Sscanf(v, "%d", &n); err != nil || n <= 0 {
+			return nil, ErrInvalidPasswordHash
+		}
+		p.params[k] = n
+	}
+	var err error
+	if p.salt, err = base64.RawStdEncoding.DecodeString(parts[2]); err != nil {
+		return nil, ErrInvalidPasswordHash
+	}
+	if p.hash, err = base64.RawStdEncoding.DecodeString(parts[3]); err != nil || len(p.hash) == 0 {
+		return nil, ErrInvalidPasswordHash
+	}
+	return p, nil
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	return b, err
+}
+
+func b64(b []byte) string {
+	return base64.RawStdEncoding.EncodeToString(b)
+}
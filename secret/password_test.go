@@ -0,0 +1,53 @@
+package secret
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordHasher(t *testing.T) {
+	hashers := []*PasswordHasher{
+		NewPasswordHasher(WithArgon2Params(1, 1024, 1)),
+		NewPasswordHasher(WithPasswordAlgorithm(PasswordBcrypt), WithBcryptCost(4)),
+		NewPasswordHasher(WithPasswordAlgorithm(PasswordScrypt), WithScryptParams(10, 8, 1)),
+	}
+	for _, h := range hashers {
+		hash, err := h.Hash("123456")
+		assert.NoError(t, err)
+		assert.True(t, h.Verify("123456", hash, ""), hash)
+		assert.False(t, h.Verify("1234567", hash, ""), hash)
+		assert.False(t, h.NeedsRehash(hash), hash)
+	}
+
+	argon, _ := hashers[0].Hash("123456")
+	assert.Regexp(t, `^\$argon2id\$v=19\$m=1024,t=1,p=1\$`, argon)
+	// 参数或算法变化时需要重新生成
+	assert.True(t, NewPasswordHasher(WithArgon2Params(2, 1024, 1)).NeedsRehash(argon))
+	assert.True(t, hashers[1].NeedsRehash(argon))
+	// 其他算法的哈希仍可验证
+	assert.True(t, hashers[1].Verify("123456", argon, ""))
+
+	assert.False(t, hashers[0].Verify("123456", "$argon2id$bad", ""))
+	assert.False(t, hashers[0].Verify("", argon, ""))
+}
+
+func TestPasswordHasherLegacy(t *testing.T) {
+	nonce, legacy := IrreversibleEncrypt("123456", "legacy-secret")
+	h := NewPasswordHasher(WithLegacySecret("legacy-secret"))
+	assert.True(t, h.Verify("123456", legacy, nonce))
+	assert.False(t, h.Verify("1234567", legacy, nonce))
+	assert.True(t, h.NeedsRehash(legacy))
+}
+
+func TestPasswordPBKDF2SM3(t *testing.T) {
+	h := NewPasswordHasher(WithPasswordAlgorithm(PasswordPBKDF2SM3), WithPBKDF2Iterations(1000))
+	hash, err := h.Hash("password")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$pbkdf2-sm3$i=1000$"))
+	assert.True(t, h.Verify("password", hash, ""))
+	assert.False(t, h.Verify("password2", hash, ""))
+	assert.False(t, h.NeedsRehash(hash))
+	assert.True(t, NewPasswordHasher(WithPasswordAlgorithm(PasswordPBKDF2SM3)).NeedsRehash(hash))
+}
@@ -0,0 +1,126 @@
+// Package secret sm.go
+package secret
+
+import (
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+
+	"github.com/emmansun/gmsm/padding"
+	"github.com/emmansun/gmsm/sm3"
+	"github.com/emmansun/gmsm/sm4"
+	"github.com/yimoka/go/utils"
+)
+
+const (
+	// AlgSM4GCM SM4-GCM 国密认证加密
+	AlgSM4GCM Algorithm = "sm4gcm"
+	// AlgSM4CBC SM4-CBC 使用 HMAC-SM3 保证完整性(先加密后 MAC), 用于要求 CBC 模式的场景
+	AlgSM4CBC Algorithm = "sm4cbc"
+)
+
+// SM3 计算 SM3 摘要 返回十六进制字符串
+func SM3(str string) string {
+	sum := sm3.Sum([]byte(str))
+	return hex.EncodeToString(sum[:])
+}
+
+// HmacSM3 计算 HMAC-SM3 返回十六进制字符串
+func HmacSM3(str string, secret string) string {
+	return hex.EncodeToString(hmacSM3([]byte(secret), []byte(str)))
+}
+
+// IrreversibleEncryptSM3 使用 SM3 的不可逆加密 与 IrreversibleEncrypt 相同返回 nonce 与摘要
+func IrreversibleEncryptSM3(str string, secret string) (string, string) {
+	nonce := utils.RandomStr(8)
+	return nonce, irreversibleSM3(str, nonce, secret)
+}
+
+func irreversibleSM3(str string, nonce string, secret string) string {
+	return SM3(nonce + str + secret)
+}
+
+// VerifyIrreversibleSM3 验证 SM3 不可逆加密
+func VerifyIrreversibleSM3(cipher string, str string, nonce string, secret string) bool {
+	if cipher == "" || str == "" || nonce == "" || secret == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(cipher), []byte(irreversibleSM3(str, nonce, secret))) == 1
+}
+
+func hmacSM3(key []byte, data ...[]byte) []byte {
+	m := hmac.New(sm3.New, key)
+	for _, d := range data {
+		m.Write(d)
+	}
+	return m.Sum(nil)
+}
+
+// sm4Key 获取 16 字节的 SM4 密钥 secret 为 16 字节时直接使用, 否则使用其 SM3 摘要的前 16 字节
+func sm4Key(secret string) []byte {
+	if len(secret) == sm4.BlockSize {
+		return []byte(secret)
+	}
+	sum := sm3.Sum([]byte(secret))
+	return sum[:sm4.BlockSize]
+}
+
+func newSM4AEAD(alg Algorithm, secret string) (cipher.AEAD, error) {
+	if alg == AlgSM4CBC {
+		// 加密与 MAC 使用不同的派生密钥
+		master := []byte(secret)
+		block, err := sm4.NewCipher(hmacSM3(master, []byte("enc"))[:sm4.BlockSize])
+		if err != nil {
+			return nil, err
+		}
+		return &cbcHMAC{block: block, macKey: hmacSM3(master, []byte("mac"))}, nil
+	}
+	block, err := sm4.NewCipher(sm4Key(secret))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+var errCBCAuth = errors.New("secret: message authentication failed")
+
+// cbcHMAC 以 AEAD 接口封装 CBC + HMAC-SM3 nonce 为 iv, 附加数据参与 MAC
+type cbcHMAC struct {
+	block  cipher.Block
+	macKey []byte
+}
+
+func (c *cbcHMAC) NonceSize() int { return c.block.BlockSize() }
+
+func (c *cbcHMAC) Overhead() int { return c.block.BlockSize() + sm3.Size }
+
+func (c *cbcHMAC) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	src := padding.NewPKCS7Padding(uint(c.block.BlockSize())).Pad(plaintext)
+	ct := make([]byte, len(src))
+	cipher.NewCBCEncrypter(c.block, nonce).CryptBlocks(ct, src)
+	return append(append(dst, ct...), c.mac(nonce, ct, additionalData)...)
+}
+
+func (c *cbcHMAC) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	bs := c.block.BlockSize()
+	if len(ciphertext) < bs+sm3.Size || (len(ciphertext)-sm3.Size)%bs != 0 {
+		return nil, errCBCAuth
+	}
+	ct, tag := ciphertext[:len(ciphertext)-sm3.Size], ciphertext[len(ciphertext)-sm3.Size:]
+	if !hmac.Equal(tag, c.mac(nonce, ct, additionalData)) {
+		return nil, errCBCAuth
+	}
+	src := make([]byte, len(ct))
+	cipher.NewCBCDecrypter(c.block, nonce).CryptBlocks(src, ct)
+	plain, err := padding.NewPKCS7Padding(uint(bs)).Unpad(src)
+	if err != nil {
+		return nil, errCBCAuth
+	}
+	return append(dst, plain...), nil
+}
+
+func (c *cbcHMAC) mac(nonce, ct, additionalData []byte) []byte {
+	return hmacSM3(c.macKey, additionalData, nonce, ct)
+}
@@ -0,0 +1,171 @@
+// Package secret sm2.go
+package secret
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/emmansun/gmsm/sm2"
+	"github.com/emmansun/gmsm/smx509"
+)
+
+// ErrInvalidSM2Key SM2 密钥格式错误
+var ErrInvalidSM2Key = errors.New("secret: invalid sm2 key")
+
+// GenerateSM2Key 生成 SM2 密钥对 返回 PEM 格式(PKCS#8 私钥与 PKIX 公钥)
+func GenerateSM2Key() (string, string, error) {
+	priv, err := sm2.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	privDER, err := smx509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return "", "", err
+	}
+	pubDER, err := smx509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		return "", "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})), nil
+}
+
+// ParseSM2PrivateKey 解析 PEM 格式的 SM2 私钥
+func ParseSM2PrivateKey(privPEM string) (*sm2.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privPEM))
+	if block == nil {
+		return nil, ErrInvalidSM2Key
+	}
+	key, err := smx509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSM2Key, err)
+	}
+	priv, ok := key.(*sm2.PrivateKey)
+	if !ok {
+		return nil, ErrInvalidSM2Key
+	}
+	return priv, nil
+}
+
+// ParseSM2PublicKey 解析 PEM 格式的 SM2 公钥
+func ParseSM2PublicKey(pubPEM string) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(pubPEM))
+	if block == nil {
+		return nil, ErrInvalidSM2Key
+	}
+	key, err := smx509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSM2Key, err)
+	}
+	pub, ok := key.(*ecdsa.PublicKey)
+	if !ok || !sm2.IsSM2PublicKey(pub) {
+		return nil, ErrInvalidSM2Key
+	}
+	return pub, nil
+}
+
+// SM2Sign 使用 SM2 私钥签名(默认 UID, GB/T 32918.2) 返回 base64 编码的 ASN.1 签名
+func SM2Sign(data []byte, privPEM string) (string, error) {
+	priv, err := ParseSM2PrivateKey(privPEM)
+	if err != nil {
+		return "", err
+	}
+	sig, err := priv.Sign(rand.Reader, data, sm2.NewSM2SignerOption(true, nil))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// SM2Verify 使用 SM2 公钥验证签名
+func SM2Verify(data []byte, sig string, pubPEM string) bool {
+	pub, err := ParseSM2PublicKey(pubPEM)
+	if err != nil {
+		return false
+	}
+	b, err := base64.StdEncoding.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	return sm2.VerifyASN1WithSM2(pub, nil, data, b)
+}
+
+// SM2Encrypt 使用 SM2 公钥加密 返回 base64 编码的 ASN.1 密文
+func SM2Encrypt(str string, pubPEM string) (string, error) {
+	pub, err := ParseSM2PublicKey(pubPEM)
+	if err != nil {
+		return "", err
+	}
+	ct, err := sm2.EncryptASN1(rand.Reader, pub, []byte(str))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(ct), nil
+}
+
+// SM2Decrypt 使用 SM2 私钥解密
+func SM2Decrypt(str string, privPEM string) (string, error) {
+	priv, err := ParseSM2PrivateKey(privPEM)
+	if err != nil {
+		return "", err
+	}
+	ct, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		return "", err
+	}
+	plain, err := sm2.Decrypt(priv, ct)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// SM2KMS 使用 SM2 密钥对包装 DEK 的 KMS 配合 KMSEncryptor 与 FromKMS 使用
+// 适用于要求全链路国密算法且未接入外部 KMS 的场景
+type SM2KMS struct {
+	keys map[string]*sm2.PrivateKey
+}
+
+// NewSM2KMS 创建 keys 为 KEK ID 与 PEM 格式的私钥
+func NewSM2KMS(keys map[string]string) (*SM2KMS, error) {
+	k := &SM2KMS{keys: make(map[string]*sm2.PrivateKey, len(keys))}
+	for id, privPEM := range keys {
+		priv, err := ParseSM2PrivateKey(privPEM)
+		if err != nil {
+			return nil, fmt.Errorf("secret: kek %s: %w", id, err)
+		}
+		k.keys[id] = priv
+	}
+	return k, nil
+}
+
+// WrapKey _
+func (k *SM2KMS) WrapKey(_ context.Context, kekID string, dek []byte) (string, error) {
+	priv, ok := k.keys[kekID]
+	if !ok {
+		return "", fmt.Errorf("%w: kek %s", ErrKeyNotFound, kekID)
+	}
+	ct, err := sm2.EncryptASN1(rand.Reader, &priv.PublicKey, dek)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(ct), nil
+}
+
+// UnwrapKey _
+func (k *SM2KMS) UnwrapKey(_ context.Context, kekID string, wrapped string) ([]byte, error) {
+	priv, ok := k.keys[kekID]
+	if !ok {
+		return nil, fmt.Errorf("%w: kek %s", ErrKeyNotFound, kekID)
+	}
+	ct, err := base64.StdEncoding.DecodeString(wrapped)
+	if err != nil {
+		return nil, err
+	}
+	return sm2.Decrypt(priv, ct)
+}
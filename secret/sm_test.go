@@ -0,0 +1,110 @@
+package secret
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/config"
+)
+
+func TestSM3(t *testing.T) {
+	// GB/T 32905 示例
+	assert.Equal(t, "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0", SM3("abc"))
+	assert.Len(t, HmacSM3("abc", "key"), 64)
+	assert.NotEqual(t, HmacSM3("abc", "key"), HmacSM3("abc", "key2"))
+
+	nonce, cipher := IrreversibleEncryptSM3("password", "secret")
+	assert.True(t, VerifyIrreversibleSM3(cipher, "password", nonce, "secret"))
+	assert.False(t, VerifyIrreversibleSM3(cipher, "password2", nonce, "secret"))
+	assert.False(t, VerifyIrreversibleSM3(cipher, "password", nonce, ""))
+}
+
+func TestSM4(t *testing.T) {
+	for _, alg := range []Algorithm{AlgSM4GCM, AlgSM4CBC} {
+		for _, key := range []string{"1234567890123456", "any length secret"} {
+			enc, err := EncryptAEAD("13800138000", key, alg, "k1")
+			assert.NoError(t, err)
+			e, _ := ParseEnvelope(enc)
+			assert.Equal(t, alg, e.Algorithm)
+
+			plain, err := Decrypt(enc, key)
+			assert.NoError(t, err)
+			assert.Equal(t, "13800138000", plain)
+
+			_, err = DecryptAEAD(enc, "wrong")
+			assert.Error(t, err)
+			// 篡改密钥 ID
+			_, err = DecryptAEAD(strings.Replace(enc, "$k1$", "$k2$", 1), key)
+			assert.Error(t, err)
+		}
+	}
+	enc, err := EncryptSM4("", "secret", "")
+	assert.NoError(t, err)
+	plain, err := DecryptAEAD(enc, "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "", plain)
+}
+
+func TestSM2(t *testing.T) {
+	priv, pub, err := GenerateSM2Key()
+	assert.NoError(t, err)
+
+	sig, err := SM2Sign([]byte("data"), priv)
+	assert.NoError(t, err)
+	assert.True(t, SM2Verify([]byte("data"), sig, pub))
+	assert.False(t, SM2Verify([]byte("data2"), sig, pub))
+
+	enc, err := SM2Encrypt("13800138000", pub)
+	assert.NoError(t, err)
+	plain, err := SM2Decrypt(enc, priv)
+	assert.NoError(t, err)
+	assert.Equal(t, "13800138000", plain)
+
+	_, err = ParseSM2PrivateKey(pub)
+	assert.ErrorIs(t, err, ErrInvalidSM2Key)
+
+	kms, err := NewSM2KMS(map[string]string{"gm": priv})
+	assert.NoError(t, err)
+	e := NewKMSEncryptor(kms, "gm", WithKMSAlgorithm(AlgSM4GCM))
+	enc, err = e.Encrypt(context.Background(), "13800138000")
+	assert.NoError(t, err)
+	env, _ := ParseEnvelope(enc)
+	assert.Equal(t, AlgSM4GCM, env.Algorithm)
+	plain, err = NewKMSEncryptor(kms, "gm").Decrypt(context.Background(), enc)
+	assert.NoError(t, err)
+	assert.Equal(t, "13800138000", plain)
+}
+
+func TestKeyringAlgorithmFromConfig(t *testing.T) {
+	alg, s := ParseKeySecret("sm4gcm:abc:def")
+	assert.Equal(t, AlgSM4GCM, alg)
+	assert.Equal(t, "abc:def", s)
+	alg, s = ParseKeySecret("plain:abc")
+	assert.Equal(t, Algorithm(""), alg)
+	assert.Equal(t, "plain:abc", s)
+
+	data := &config.Data{Secret: "12345678901234567890123456789012", Secrets: map[string]string{"user": "sm4cbc:1234567890123456"}}
+	k, err := NewKeyring(context.Background(), []KeySource{FromConfig(data)})
+	assert.NoError(t, err)
+
+	enc, err := k.EncryptWithKey("user", "13800138000")
+	assert.NoError(t, err)
+	e, _ := ParseEnvelope(enc)
+	assert.Equal(t, AlgSM4CBC, e.Algorithm)
+	assert.False(t, k.NeedReEncrypt(enc, "user"))
+	plain, err := k.Decrypt(enc)
+	assert.NoError(t, err)
+	assert.Equal(t, "13800138000", plain)
+
+	enc, _ = k.Encrypt("a")
+	e, _ = ParseEnvelope(enc)
+	assert.Equal(t, AlgAES256GCM, e.Algorithm)
+	assert.True(t, k.NeedReEncrypt(enc, "user"))
+
+	_, err = NewKeyring(context.Background(), []KeySource{KeySourceFunc(func(context.Context) ([]*Key, error) {
+		return []*Key{{ID: "k", Secret: "s", Status: KeyActive, Algorithm: "des"}}, nil
+	})})
+	assert.Error(t, err)
+}
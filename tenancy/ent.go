@@ -0,0 +1,89 @@
+// Package tenancy ent
+package tenancy
+
+import (
+	"context"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/yimoka/go/ent/customsql"
+)
+
+// isolation 数据隔离的值 租户或应用
+type isolation struct {
+	from     func(ctx context.Context) (string, bool)
+	missing  *errors.Error
+	mismatch *errors.Error
+}
+
+var (
+	tenantIsolation = isolation{from: FromContext, missing: ErrMissingTenant, mismatch: ErrTenantMismatch}
+	appIsolation    = isolation{from: AppFromContext, missing: ErrMissingApp, mismatch: ErrAppMismatch}
+)
+
+// Interceptor 查询时自动添加 field = 租户 ID 的条件
+// field 为租户字段的数据库列名 即生成代码中的 FieldXxx 常量, 如 tenant_id, 而不是 Schema 中的字段名 tenantID
+// ctx 中没有租户且未调用 SkipTenant 时返回 ErrMissingTenant
+func Interceptor(field string) ent.Interceptor {
+	return tenantIsolation.interceptor(field)
+}
+
+// Hook 创建时自动设置租户 ID, 更新与删除时自动添加 field = 租户 ID 的条件
+// field 与 Interceptor 相同为数据库列名, 生成的 Mutation 的 Field 与 SetField 使用列名
+// ctx 中没有租户且未调用 SkipTenant 时返回 ErrMissingTenant
+func Hook(field string) ent.Hook {
+	return tenantIsolation.hook(field)
+}
+
+// AppInterceptor 查询时自动添加 field = 应用 ID 的条件 应用由 NewAppContext 存入 ctx
+// ctx 中没有应用且未调用 SkipTenant 时返回 ErrMissingApp
+func AppInterceptor(field string) ent.Interceptor {
+	return appIsolation.interceptor(field)
+}
+
+// AppHook 创建时自动设置应用 ID, 更新与删除时自动添加 field = 应用 ID 的条件
+// ctx 中没有应用且未调用 SkipTenant 时返回 ErrMissingApp
+func AppHook(field string) ent.Hook {
+	return appIsolation.hook(field)
+}
+
+func (i isolation) interceptor(field string) ent.Interceptor {
+	return ent.TraverseFunc(func(ctx context.Context, q ent.Query) error {
+		if IsSkipTenant(ctx) {
+			return nil
+		}
+		id, ok := i.from(ctx)
+		if !ok {
+			return i.missing
+		}
+		return customsql.WhereP(q, sql.FieldEQ(field, id))
+	})
+}
+
+func (i isolation) hook(field string) ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if IsSkipTenant(ctx) {
+				return next.Mutate(ctx, m)
+			}
+			id, ok := i.from(ctx)
+			if !ok {
+				return nil, i.missing
+			}
+			if m.Op().Is(ent.OpCreate) {
+				if v, exists := m.Field(field); exists && v != id {
+					return nil, i.mismatch
+				}
+				if err := m.SetField(field, id); err != nil {
+					return nil, err
+				}
+				return next.Mutate(ctx, m)
+			}
+			if err := customsql.WhereP(m, sql.FieldEQ(field, id)); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package tenancy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/ent/mixin"
+	"github.com/yimoka/go/internal/entfixture"
+	"github.com/yimoka/go/internal/entfixture/ent/user"
+	"github.com/yimoka/go/tenancy"
+)
+
+func TestEnt(t *testing.T) {
+	client, drv := entfixture.NewClient()
+	ctx := tenancy.NewContext(context.Background(), "t1")
+
+	_, err := client.User.Query().All(context.Background())
+	assert.True(t, errors.Is(err, tenancy.ErrMissingTenant))
+	assert.Equal(t, int32(400), kerrors.FromError(err).Code)
+	err = client.User.Create().SetName("n").Exec(context.Background())
+	assert.True(t, errors.Is(err, tenancy.ErrMissingTenant))
+
+	_, err = client.User.Query().Where(user.Name("n")).All(ctx)
+	assert.NoError(t, err)
+	stmt, _ := drv.Last("SELECT")
+	assert.Contains(t, stmt.Query, "`users`.`tenant_id` = ?")
+	assert.Contains(t, stmt.Args, "t1")
+
+	assert.NoError(t, client.User.Create().SetName("n").Exec(ctx))
+	stmt, _ = drv.Last("INSERT")
+	assert.Contains(t, stmt.Query, "INSERT INTO `users` (`tenant_id`,")
+	assert.Equal(t, "t1", stmt.Args[0])
+	err = client.User.Create().SetTenantID("t2").SetName("n").Exec(ctx)
+	assert.True(t, errors.Is(err, tenancy.ErrTenantMismatch))
+	assert.False(t, errors.Is(err, tenancy.ErrMissingTenant))
+
+	_, err = client.User.Update().SetName("m").Save(ctx)
+	assert.NoError(t, err)
+	stmt, _ = drv.Last("UPDATE")
+	assert.Contains(t, stmt.Query, "WHERE `users`.`tenant_id` = ?")
+
+	// 跳过租户隔离
+	_, err = client.User.Query().All(tenancy.SkipTenant(context.Background()))
+	assert.NoError(t, err)
+	stmt, _ = drv.Last("SELECT")
+	assert.NotContains(t, stmt.Query, "tenant_id` = ?")
+
+	// OnlyPortal 的 mixin 需自行注册
+	_, err = client.Contact.Query().All(context.Background())
+	assert.NoError(t, err)
+	client.Contact.Intercept(tenancy.Interceptor(mixin.TenantIDColumn))
+	client.Contact.Use(tenancy.Hook(mixin.TenantIDColumn))
+	_, err = client.Contact.Query().All(ctx)
+	assert.NoError(t, err)
+	stmt, _ = drv.Last("SELECT")
+	assert.Contains(t, stmt.Query, "`contacts`.`tenant_id` = ?")
+	assert.NoError(t, client.Contact.Create().Exec(ctx))
+	stmt, _ = drv.Last("INSERT")
+	assert.Equal(t, []any{"t1"}, stmt.Args)
+
+	// 应用与租户相互独立
+	_, err = client.Article.Query().All(ctx)
+	assert.True(t, errors.Is(err, tenancy.ErrMissingApp))
+	appCtx := tenancy.NewAppContext(ctx, "a1")
+	_, err = client.Article.Query().All(appCtx)
+	assert.NoError(t, err)
+	stmt, _ = drv.Last("SELECT")
+	assert.Contains(t, stmt.Query, "`articles`.`tenant_id` = ?")
+	assert.Contains(t, stmt.Query, "`articles`.`appid` = ?")
+	assert.Equal(t, []any{"t1", "a1"}, stmt.Args)
+	assert.NoError(t, client.Article.Create().SetTitle("t").Exec(appCtx))
+	stmt, _ = drv.Last("INSERT")
+	assert.Equal(t, "t1", stmt.Values()["tenant_id"])
+	assert.Equal(t, "a1", stmt.Values()["appid"])
+	err = client.Article.Create().SetAppid("a2").Exec(appCtx)
+	assert.True(t, errors.Is(err, tenancy.ErrAppMismatch))
+}
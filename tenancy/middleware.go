@@ -0,0 +1,104 @@
+// Package tenancy middleware
+package tenancy
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/yimoka/api/fault"
+	"github.com/yimoka/go/lang"
+	"github.com/yimoka/go/middleware/meta"
+)
+
+// Option 是租户中间件的配置选项函数类型
+type Option func(*options)
+
+// options 包含租户中间件的配置选项
+type options struct {
+	// 按顺序解析 第一个解析到的租户生效
+	resolvers []Resolver
+	// 应用的解析器 为空时不解析应用
+	appResolvers []Resolver
+	// 不需要租户的接口 如健康检查、登录前的公共配置
+	skipOperations map[string]struct{}
+	// commonLang 用于处理通用的多语言消息
+	commonLang *lang.CommonLang
+}
+
+// WithResolvers 设置租户解析器 按顺序解析
+func WithResolvers(resolvers ...Resolver) Option {
+	return func(o *options) {
+		o.resolvers = resolvers
+	}
+}
+
+// WithAppResolvers 设置应用(appid)解析器 按顺序解析, 解析到时存入 ctx 与元数据 app-id
+// 解析不到时不拒绝请求, 使用 Appid mixin 的 Schema 在数据层返回 ErrMissingApp
+func WithAppResolvers(resolvers ...Resolver) Option {
+	return func(o *options) {
+		o.appResolvers = resolvers
+	}
+}
+
+// WithSkipOperations 设置不需要租户的接口 值为 transport 的 Operation
+func WithSkipOperations(operations ...string) Option {
+	return func(o *options) {
+		for _, op := range operations {
+			o.skipOperations[op] = struct{}{}
+		}
+	}
+}
+
+// WithCommonLang 设置通用语言处理器
+func WithCommonLang(l *lang.CommonLang) Option {
+	return func(o *options) {
+		o.commonLang = l
+	}
+}
+
+// Resolve 按顺序使用解析器解析租户 ID
+func Resolve(ctx context.Context, resolvers ...Resolver) (string, bool) {
+	for _, resolver := range resolvers {
+		if tenantID, ok := resolver(ctx); ok {
+			return tenantID, true
+		}
+	}
+	return "", false
+}
+
+// Server 租户中间件 解析租户并存入 ctx 与元数据 解析不到租户时拒绝请求
+// 默认依次从元数据 tenant-id 与请求头 X-Tenant-Id 中解析
+func Server(opts ...Option) middleware.Middleware {
+	o := &options{
+		resolvers:      []Resolver{FromMeta(MetaKey), FromHeader("X-Tenant-Id")},
+		skipOperations: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tenantID, ok := Resolve(ctx, o.resolvers...)
+			if !ok {
+				if tr, trOk := transport.FromServerContext(ctx); trOk {
+					if _, skip := o.skipOperations[tr.Operation()]; skip {
+						return handler(ctx, req)
+					}
+				}
+				msg := "Parameter tenant cannot be empty"
+				if o.commonLang != nil {
+					msg = o.commonLang.GetParamCanNotEmptyMsg(ctx, "tenant")
+				}
+				return nil, fault.ErrorBadRequest("%s", msg)
+			}
+			ctx = NewContext(ctx, tenantID)
+			ctx = meta.SetValue(ctx, MetaKey, tenantID)
+			if appid, ok := Resolve(ctx, o.appResolvers...); ok {
+				ctx = NewAppContext(ctx, appid)
+				ctx = meta.SetValue(ctx, AppMetaKey, appid)
+			}
+			return handler(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Package tenancy resolver
+package tenancy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/yimoka/go/middleware/meta"
+)
+
+// Resolver 从请求中解析租户 ID 解析不到时返回 false
+type Resolver func(ctx context.Context) (string, bool)
+
+// ClaimsFunc 获取已验证的 JWT claims 由业务方根据所使用的 jwt 中间件提供
+// 例如 kratos jwt: claims, ok := jwt.FromContext(ctx); m, ok := claims.(jwtv5.MapClaims)
+type ClaimsFunc func(ctx context.Context) (map[string]interface{}, bool)
+
+// FromMeta 从元数据中获取租户 ID
+func FromMeta(key string) Resolver {
+	return func(ctx context.Context) (string, bool) {
+		tenantID, err := meta.GetValue(ctx, key)
+		return tenantID, err == nil && tenantID != ""
+	}
+}
+
+// FromHeader 从请求头中获取租户 ID
+func FromHeader(name string) Resolver {
+	return func(ctx context.Context) (string, bool) {
+		tenantID := meta.GetRequestHeaderVal(ctx, name)
+		return tenantID, tenantID != ""
+	}
+}
+
+// FromSubdomain 从 HTTP 请求的子域名中获取租户 ID
+// 例如 baseDomain 为 example.com 时 acme.example.com 解析为 acme
+func FromSubdomain(baseDomain string) Resolver {
+	suffix := "." + strings.TrimPrefix(strings.ToLower(baseDomain), ".")
+	return func(ctx context.Context) (string, bool) {
+		req, ok := http.RequestFromServerContext(ctx)
+		if !ok {
+			return "", false
+		}
+		return subdomain(req.Host, suffix)
+	}
+}
+
+// subdomain 解析 host 中 suffix 之前的一级子域名
+// 只取最靠近主域名的一级 如 suffix 为 .example.com 时 www.acme.example.com 解析为 acme
+func subdomain(host string, suffix string) (string, bool) {
+	host = strings.ToLower(host)
+	if i := strings.LastIndex(host, ":"); i > strings.LastIndex(host, "]") {
+		host = host[:i]
+	}
+	if !strings.HasSuffix(host, suffix) {
+		return "", false
+	}
+	sub := strings.TrimSuffix(host, suffix)
+	if i := strings.LastIndex(sub, "."); i >= 0 {
+		sub = sub[i+1:]
+	}
+	return sub, sub != ""
+}
+
+// FromClaim 从已验证的 JWT claims 中获取租户 ID
+func FromClaim(claims ClaimsFunc, claim string) Resolver {
+	return func(ctx context.Context) (string, bool) {
+		m, ok := claims(ctx)
+		if !ok || m == nil {
+			return "", false
+		}
+		v, ok := m[claim]
+		if !ok || v == nil {
+			return "", false
+		}
+		tenantID := fmt.Sprint(v)
+		return tenantID, tenantID != ""
+	}
+}
@@ -0,0 +1,83 @@
+// Package tenancy 多租户上下文 解析请求所属的租户并在数据层自动隔离
+package tenancy
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/yimoka/api/fault"
+)
+
+// MetaKey 租户在元数据中的 key 通过 meta 在服务间传递
+const MetaKey = "tenant-id"
+
+// AppMetaKey 应用(appid)在元数据中的 key 通过 meta 在服务间传递
+const AppMetaKey = "app-id"
+
+const (
+	// ReasonMissingTenant 缺少租户的错误原因
+	ReasonMissingTenant = "TENANT_MISSING"
+	// ReasonTenantMismatch 租户不一致的错误原因
+	ReasonTenantMismatch = "TENANT_MISMATCH"
+	// ReasonMissingApp 缺少应用的错误原因
+	ReasonMissingApp = "APP_MISSING"
+	// ReasonAppMismatch 应用不一致的错误原因
+	ReasonAppMismatch = "APP_MISMATCH"
+)
+
+var (
+	// ErrMissingTenant 上下文中缺少租户 为参数错误(400), 可通过 errors.Is 判断
+	ErrMissingTenant = newError(ReasonMissingTenant, "tenancy: missing tenant in context")
+	// ErrTenantMismatch 写入的租户与上下文中的租户不一致 为参数错误(400)
+	ErrTenantMismatch = newError(ReasonTenantMismatch, "tenancy: tenant mismatch")
+	// ErrMissingApp 上下文中缺少应用 为参数错误(400)
+	ErrMissingApp = newError(ReasonMissingApp, "tenancy: missing app in context")
+	// ErrAppMismatch 写入的应用与上下文中的应用不一致 为参数错误(400)
+	ErrAppMismatch = newError(ReasonAppMismatch, "tenancy: app mismatch")
+)
+
+// newError 创建参数错误 kratos 的错误以 code 与 reason 判断是否相同
+func newError(reason, msg string) *errors.Error {
+	err := fault.ErrorBadRequest("%s", msg)
+	err.Reason = reason
+	return err
+}
+
+type tenantKey struct{}
+
+type appKey struct{}
+
+type skipKey struct{}
+
+// NewContext 将租户 ID 存入 ctx
+func NewContext(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// FromContext 从 ctx 中获取租户 ID
+func FromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantKey{}).(string)
+	return tenantID, ok && tenantID != ""
+}
+
+// NewAppContext 将应用 ID(appid) 存入 ctx 与租户相互独立
+func NewAppContext(ctx context.Context, appid string) context.Context {
+	return context.WithValue(ctx, appKey{}, appid)
+}
+
+// AppFromContext 从 ctx 中获取应用 ID(appid)
+func AppFromContext(ctx context.Context) (string, bool) {
+	appid, ok := ctx.Value(appKey{}).(string)
+	return appid, ok && appid != ""
+}
+
+// SkipTenant 返回跳过租户与应用隔离的 ctx 仅用于有特权的场景 如跨租户的管理与统计任务
+func SkipTenant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipKey{}, true)
+}
+
+// IsSkipTenant 判断 ctx 是否跳过租户隔离
+func IsSkipTenant(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipKey{}).(bool)
+	return skip
+}
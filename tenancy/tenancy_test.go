@@ -0,0 +1,130 @@
+package tenancy
+
+import (
+	"context"
+	nethttp "net/http"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/middleware/meta"
+)
+
+type headerCarrier nethttp.Header
+
+func (h headerCarrier) Get(key string) string { return nethttp.Header(h).Get(key) }
+func (h headerCarrier) Set(key, value string) { nethttp.Header(h).Set(key, value) }
+func (h headerCarrier) Add(key, value string) { nethttp.Header(h).Add(key, value) }
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
+}
+func (h headerCarrier) Values(key string) []string { return nethttp.Header(h).Values(key) }
+
+type testTransport struct {
+	operation string
+	header    headerCarrier
+}
+
+func (t *testTransport) Kind() transport.Kind            { return transport.KindHTTP }
+func (t *testTransport) Endpoint() string                { return "" }
+func (t *testTransport) Operation() string               { return t.operation }
+func (t *testTransport) RequestHeader() transport.Header { return t.header }
+func (t *testTransport) ReplyHeader() transport.Header   { return headerCarrier{} }
+
+func newTestCtx(operation string, header map[string]string) context.Context {
+	h := headerCarrier{}
+	for k, v := range header {
+		h.Set(k, v)
+	}
+	ctx := transport.NewServerContext(context.Background(), &testTransport{operation: operation, header: h})
+	return metadata.NewServerContext(ctx, metadata.New())
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	_, ok := FromContext(ctx)
+	assert.False(t, ok)
+	assert.False(t, IsSkipTenant(ctx))
+
+	ctx = NewContext(ctx, "t1")
+	tenantID, ok := FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "t1", tenantID)
+	assert.True(t, IsSkipTenant(SkipTenant(ctx)))
+	_, ok = AppFromContext(ctx)
+	assert.False(t, ok)
+	appid, ok := AppFromContext(NewAppContext(ctx, "a1"))
+	assert.True(t, ok)
+	assert.Equal(t, "a1", appid)
+}
+
+func TestResolvers(t *testing.T) {
+	ctx := newTestCtx("", map[string]string{"X-Tenant-Id": "h1"})
+	tenantID, ok := FromHeader("X-Tenant-Id")(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "h1", tenantID)
+
+	ctx = meta.SetValue(ctx, MetaKey, "m1")
+	tenantID, ok = Resolve(ctx, FromMeta(MetaKey), FromHeader("X-Tenant-Id"))
+	assert.True(t, ok)
+	assert.Equal(t, "m1", tenantID)
+
+	claims := func(context.Context) (map[string]interface{}, bool) {
+		return map[string]interface{}{"tid": 1001}, true
+	}
+	tenantID, ok = FromClaim(claims, "tid")(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "1001", tenantID)
+	_, ok = FromClaim(claims, "other")(ctx)
+	assert.False(t, ok)
+
+	for host, expected := range map[string]string{
+		"acme.example.com":      "acme",
+		"acme.example.com:8080": "acme",
+		"www.acme.example.com":  "acme",
+		"example.com":           "",
+		"acme.other.com":        "",
+	} {
+		tenantID, ok = subdomain(host, ".example.com")
+		assert.Equal(t, expected != "", ok, host)
+		assert.Equal(t, expected, tenantID, host)
+	}
+}
+
+func TestServer(t *testing.T) {
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		tenantID, _ := FromContext(ctx)
+		metaTenantID, _ := meta.GetValue(ctx, MetaKey)
+		return tenantID + "/" + metaTenantID, nil
+	}
+	m := Server(WithSkipOperations("/health"))
+
+	reply, err := m(handler)(newTestCtx("/api", map[string]string{"X-Tenant-Id": "h1"}), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "h1/h1", reply)
+
+	_, err = m(handler)(newTestCtx("/api", nil), nil)
+	assert.Error(t, err)
+
+	reply, err = m(handler)(newTestCtx("/health", nil), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "/", reply)
+
+	appHandler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		appid, _ := AppFromContext(ctx)
+		metaAppid, _ := meta.GetValue(ctx, AppMetaKey)
+		return appid + "/" + metaAppid, nil
+	}
+	m = Server(WithAppResolvers(FromHeader("X-App-Id")))
+	reply, err = m(appHandler)(newTestCtx("/api", map[string]string{"X-Tenant-Id": "h1", "X-App-Id": "a1"}), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "a1/a1", reply)
+	reply, err = m(appHandler)(newTestCtx("/api", map[string]string{"X-Tenant-Id": "h1"}), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "/", reply)
+}
@@ -0,0 +1,97 @@
+// Package utils check.go
+package utils
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var cnMobileRe = regexp.MustCompile(`^(?:\+?86)?1[3-9]\d{9}$`)
+
+// IsCNMobile 是否为中国大陆手机号 允许 +86 或 86 前缀
+func IsCNMobile(s string) bool {
+	return cnMobileRe.MatchString(s)
+}
+
+var (
+	idCardWeights = []int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+	idCardCodes   = "10X98765432"
+)
+
+// IsCNIDCard 是否为 18 位中国居民身份证号 校验出生日期与校验码(GB 11643-1999), 末位 x 不区分大小写
+func IsCNIDCard(s string) bool {
+	if len(s) != 18 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 17; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+		sum += int(s[i]-'0') * idCardWeights[i]
+	}
+	if birth, err := time.Parse("20060102", s[6:14]); err != nil || birth.Year() < 1800 || birth.After(time.Now()) {
+		return false
+	}
+	return strings.ToUpper(s[17:]) == string(idCardCodes[sum%11])
+}
+
+var (
+	usccChars   = "0123456789ABCDEFGHJKLMNPQRTUWXY"
+	usccWeights = []int{1, 3, 9, 27, 19, 26, 16, 17, 20, 29, 25, 13, 8, 24, 10, 30, 28}
+)
+
+// IsCNUSCC 是否为统一社会信用代码 校验字符集与校验码(GB 32100-2015)
+func IsCNUSCC(s string) bool {
+	if len(s) != 18 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 17; i++ {
+		v := strings.IndexByte(usccChars, s[i])
+		if v < 0 {
+			return false
+		}
+		sum += v * usccWeights[i]
+	}
+	return s[17] == usccChars[(31-sum%31)%31]
+}
+
+// IsLuhn 数字串是否通过 Luhn 校验 用于银行卡号等
+func IsLuhn(s string) bool {
+	if len(s) < 2 {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+		d := int(s[i] - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// IsBankCard 是否为银行卡号 12 到 19 位数字且通过 Luhn 校验
+func IsBankCard(s string) bool {
+	return len(s) >= 12 && len(s) <= 19 && IsLuhn(s)
+}
+
+// IsSonyflakeID 是否为 Sonyflake ID 的格式 与 ent/mixin.GenerateID 的编码一致: 8 字节大端序的 base64url(无填充), 最高位为 0
+func IsSonyflakeID(s string) bool {
+	if len(s) != 11 {
+		return false
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	return err == nil && len(b) == 8 && b[0]&0x80 == 0
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsCNMobile(t *testing.T) {
+	assert.True(t, IsCNMobile("13800138000"))
+	assert.True(t, IsCNMobile("+8619912345678"))
+	assert.False(t, IsCNMobile("12800138000"))
+	assert.False(t, IsCNMobile("1380013800"))
+	assert.False(t, IsCNMobile(""))
+}
+
+func TestIsCNIDCard(t *testing.T) {
+	assert.True(t, IsCNIDCard("11010519491231002X"))
+	assert.True(t, IsCNIDCard("11010519491231002x"))
+	assert.False(t, IsCNIDCard("110105194912310021"))
+	// 出生日期无效
+	assert.False(t, IsCNIDCard("110105194913310028"))
+	assert.False(t, IsCNIDCard("1101051949123100"))
+}
+
+func TestIsCNUSCC(t *testing.T) {
+	assert.True(t, IsCNUSCC("91350100M000100Y43"))
+	assert.True(t, IsCNUSCC("91110108551385082Q"))
+	assert.False(t, IsCNUSCC("91350100M000100Y44"))
+	// I、O、S、V、Z 不在字符集内
+	assert.False(t, IsCNUSCC("91350100O000100Y43"))
+	assert.False(t, IsCNUSCC("913501"))
+}
+
+func TestIsLuhn(t *testing.T) {
+	assert.True(t, IsLuhn("4111111111111111"))
+	assert.True(t, IsBankCard("4111111111111111"))
+	assert.False(t, IsLuhn("4111111111111112"))
+	assert.False(t, IsLuhn("41111a1111111111"))
+	assert.False(t, IsBankCard("18"))
+}
+
+func TestIsSonyflakeID(t *testing.T) {
+	assert.True(t, IsSonyflakeID("AXvFn3VQAAE"))
+	assert.False(t, IsSonyflakeID("_3vFn3VQAAE"))
+	assert.False(t, IsSonyflakeID("AXvFn3VQAA"))
+	assert.False(t, IsSonyflakeID("AXvFn3VQAA*"))
+}
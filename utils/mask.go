@@ -3,7 +3,9 @@ package utils
 
 import (
 	"math"
+	"net"
 	"strings"
+	"unicode/utf8"
 )
 
 // MaskType 敏感数据类型
@@ -20,6 +22,18 @@ const (
 	MaskTypeBankCard MaskType = "bankCard"
 	// MaskTypeOther 用于其他敏感数据
 	MaskTypeOther MaskType = "other"
+	// MaskTypeName 用于姓名
+	MaskTypeName MaskType = "name"
+	// MaskTypeAddress 用于地址
+	MaskTypeAddress MaskType = "address"
+	// MaskTypePassport 用于护照号
+	MaskTypePassport MaskType = "passport"
+	// MaskTypeIPv4 用于 IPv4 地址
+	MaskTypeIPv4 MaskType = "ipv4"
+	// MaskTypeIPv6 用于 IPv6 地址
+	MaskTypeIPv6 MaskType = "ipv6"
+	// MaskTypeLicensePlate 用于车牌号
+	MaskTypeLicensePlate MaskType = "licensePlate"
 )
 
 // Mask 敏感数据加星
@@ -33,6 +47,18 @@ func Mask(str string, maskType MaskType) string {
 		return MaskIDCard(str)
 	case MaskTypeBankCard:
 		return MaskBankCard(str)
+	case MaskTypeName:
+		return MaskName(str)
+	case MaskTypeAddress:
+		return MaskAddress(str)
+	case MaskTypePassport:
+		return MaskPassport(str)
+	case MaskTypeIPv4:
+		return MaskIPv4(str)
+	case MaskTypeIPv6:
+		return MaskIPv6(str)
+	case MaskTypeLicensePlate:
+		return MaskLicensePlate(str)
 	default:
 		return MaskOther(str)
 	}
@@ -107,3 +133,65 @@ func MaskOther(str string) string {
 	}
 	return str[:2] + strings.Repeat("*", strLen-4) + str[strLen-2:]
 }
+
+// MaskName 姓名加星 按字符处理 两个字保留姓 如 张* 多个字保留首尾 如 欧**娜
+func MaskName(name string) string {
+	runes := []rune(name)
+	switch n := len(runes); {
+	case n <= 1:
+		return strings.Repeat("*", n)
+	case n == 2:
+		return string(runes[0]) + "*"
+	default:
+		return string(runes[0]) + strings.Repeat("*", n-2) + string(runes[n-1])
+	}
+}
+
+// MaskAddress 地址加星 保留前 6 个字符(通常为省市) 其余替换为 ****
+func MaskAddress(address string) string {
+	n := utf8.RuneCountInString(address)
+	if n <= 6 {
+		return MaskOther(address)
+	}
+	return string([]rune(address)[:6]) + "****"
+}
+
+// MaskPassport 护照号加星 保留首字母与后 3 位 如 E*****678
+func MaskPassport(passport string) string {
+	strLen := len(passport)
+	if strLen <= 4 {
+		return strings.Repeat("*", strLen)
+	}
+	return passport[:1] + strings.Repeat("*", strLen-4) + passport[strLen-3:]
+}
+
+// MaskIPv4 IPv4 地址加星 保留前两段 如 192.168.*.*
+func MaskIPv4(ip string) string {
+	parts := strings.Split(ip, ".")
+	if len(parts) != 4 {
+		return MaskOther(ip)
+	}
+	return parts[0] + "." + parts[1] + ".*.*"
+}
+
+// MaskIPv6 IPv6 地址加星 保留前两组 如 2001:db8:*
+func MaskIPv6(ip string) string {
+	if parsed := net.ParseIP(ip); parsed == nil || parsed.To4() != nil {
+		return MaskOther(ip)
+	}
+	parts := strings.Split(ip, ":")
+	if len(parts) < 3 || parts[0] == "" || parts[1] == "" {
+		return "*"
+	}
+	return parts[0] + ":" + parts[1] + ":*"
+}
+
+// MaskLicensePlate 车牌号加星 按字符处理 保留前 2 位(省份与发牌机关)与后 2 位 如 京A***45
+func MaskLicensePlate(plate string) string {
+	runes := []rune(plate)
+	n := len(runes)
+	if n <= 4 {
+		return strings.Repeat("*", n)
+	}
+	return string(runes[:2]) + strings.Repeat("*", n-4) + string(runes[n-2:])
+}
@@ -31,3 +31,18 @@ func TestMaskMail(t *testing.T) {
 	assert.Equal(t, MaskMail("ixxx@163.qq.com"), "i***@***.com")
 	assert.Equal(t, MaskMail("ixxx@163163"), "i***@***")
 }
+
+func TestMaskExtendedTypes(t *testing.T) {
+	assert.Equal(t, "张*", MaskName("张三"))
+	assert.Equal(t, "欧**娜", MaskName("欧阳娜娜"))
+	assert.Equal(t, "*", MaskName("张"))
+	assert.Equal(t, "广东省深圳市****", MaskAddress("广东省深圳市南山区科技园"))
+	assert.Equal(t, "E*****678", MaskPassport("E12345678"))
+	assert.Equal(t, "192.168.*.*", MaskIPv4("192.168.1.10"))
+	assert.Equal(t, "2001:db8:*", MaskIPv6("2001:db8::1"))
+	assert.Equal(t, "*", MaskIPv6("::1"))
+	assert.Equal(t, "京A***45", MaskLicensePlate("京A12345"))
+	assert.Equal(t, "粤B****67", MaskLicensePlate("粤BD12367"))
+	assert.Equal(t, "192.168.*.*", Mask("192.168.1.10", MaskTypeIPv4))
+	assert.Equal(t, "张*", Mask("张三", MaskTypeName))
+}
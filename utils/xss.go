@@ -2,11 +2,173 @@
 package utils
 
 import (
+	"fmt"
+	"regexp"
+	"sync"
+
 	"github.com/microcosm-cc/bluemonday"
 )
 
-// XSS 过滤
+// XSSProfile 净化配置的名称
+type XSSProfile string
+
+const (
+	// XSSProfileStrict 纯文本 移除所有标签 如标题、名称
+	XSSProfileStrict XSSProfile = "strict"
+	// XSSProfileBasic 基础富文本 仅允许文本格式、链接与列表 如评论、简介
+	XSSProfileBasic XSSProfile = "basic"
+	// XSSProfileArticle 完整文章 允许媒体、表格与样式 XSS 的默认配置
+	XSSProfileArticle XSSProfile = "article"
+	// XSSProfileMarkdown markdown 渲染后的 HTML 允许代码块的语言 class
+	XSSProfileMarkdown XSSProfile = "markdown"
+)
+
+// xssProfiles 已编译的净化配置 builders 用于以已有配置为基础扩展
+var xssProfiles = struct {
+	sync.RWMutex
+	policies map[XSSProfile]*bluemonday.Policy
+	builders map[XSSProfile]func() *bluemonday.Policy
+}{
+	policies: map[XSSProfile]*bluemonday.Policy{},
+	builders: map[XSSProfile]func() *bluemonday.Policy{},
+}
+
+func init() {
+	registerXSSBuilder(XSSProfileStrict, bluemonday.StrictPolicy)
+	registerXSSBuilder(XSSProfileBasic, newBasicPolicy)
+	registerXSSBuilder(XSSProfileArticle, newArticlePolicy)
+	registerXSSBuilder(XSSProfileMarkdown, newMarkdownPolicy)
+}
+
+func registerXSSBuilder(name XSSProfile, builder func() *bluemonday.Policy) {
+	xssProfiles.Lock()
+	defer xssProfiles.Unlock()
+	xssProfiles.builders[name] = builder
+	xssProfiles.policies[name] = builder()
+}
+
+// RegisterXSSProfile 注册自定义的净化配置 同名时覆盖, 注册后不可修改 policy
+func RegisterXSSProfile(name XSSProfile, policy *bluemonday.Policy) {
+	xssProfiles.Lock()
+	defer xssProfiles.Unlock()
+	delete(xssProfiles.builders, name)
+	xssProfiles.policies[name] = policy
+}
+
+// GetXSSProfile 获取净化配置
+func GetXSSProfile(name XSSProfile) (*bluemonday.Policy, bool) {
+	xssProfiles.RLock()
+	defer xssProfiles.RUnlock()
+	p, ok := xssProfiles.policies[name]
+	return p, ok
+}
+
+// XSS 过滤 使用 XSSProfileArticle
 func XSS(s string) string {
+	return XSSWithProfile(s, XSSProfileArticle)
+}
+
+// XSSWithProfile 使用指定的净化配置过滤 配置不存在时使用 XSSProfileStrict
+func XSSWithProfile(s string, name XSSProfile) string {
+	p, ok := GetXSSProfile(name)
+	if !ok {
+		p, _ = GetXSSProfile(XSSProfileStrict)
+	}
+	return p.Sanitize(s)
+}
+
+// XSSProfileConfig 自定义净化配置 可从配置文件读取后通过 RegisterXSSProfileConfig 注册
+type XSSProfileConfig struct {
+	// 基础配置 在其上扩展, 为空时从空白开始, 只能是内置或通过配置注册的配置
+	Base XSSProfile `json:"base" yaml:"base"`
+	// 允许的标签
+	Elements []string `json:"elements" yaml:"elements"`
+	// 允许的属性 key 为标签, "*" 表示所有标签
+	Attrs map[string][]string `json:"attrs" yaml:"attrs"`
+	// 所有标签允许的样式
+	Styles []string `json:"styles" yaml:"styles"`
+	// 允许的 URL 协议 如 https、mailto
+	URLSchemes []string `json:"urlSchemes" yaml:"urlSchemes"`
+	// 允许相对 URL
+	RelativeURLs bool `json:"relativeURLs" yaml:"relativeURLs"`
+	// 允许的 class 的正则 如 ^language-[a-z]+$
+	ClassPattern string `json:"classPattern" yaml:"classPattern"`
+}
+
+// RegisterXSSProfileConfig 使用配置注册净化配置 在注册时编译
+func RegisterXSSProfileConfig(name XSSProfile, conf *XSSProfileConfig) error {
+	base := bluemonday.NewPolicy
+	if conf.Base != "" {
+		xssProfiles.RLock()
+		builder, ok := xssProfiles.builders[conf.Base]
+		xssProfiles.RUnlock()
+		if !ok {
+			return fmt.Errorf("xss: base profile %s can not be extended", conf.Base)
+		}
+		base = builder
+	}
+	var classRe *regexp.Regexp
+	if conf.ClassPattern != "" {
+		var err error
+		if classRe, err = regexp.Compile(conf.ClassPattern); err != nil {
+			return fmt.Errorf("xss: profile %s invalid class pattern: %w", name, err)
+		}
+	}
+	registerXSSBuilder(name, func() *bluemonday.Policy {
+		p := base()
+		if len(conf.Elements) > 0 {
+			p.AllowElements(conf.Elements...)
+		}
+		for element, attrs := range conf.Attrs {
+			if element == "*" {
+				p.AllowAttrs(attrs...).Globally()
+				continue
+			}
+			p.AllowAttrs(attrs...).OnElements(element)
+		}
+		if len(conf.Styles) > 0 {
+			p.AllowStyles(conf.Styles...).Globally()
+		}
+		if len(conf.URLSchemes) > 0 {
+			p.AllowURLSchemes(conf.URLSchemes...)
+		}
+		if conf.RelativeURLs {
+			p.AllowRelativeURLs(true)
+		}
+		if classRe != nil {
+			p.AllowAttrs("class").Matching(classRe).Globally()
+		}
+		return p
+	})
+	return nil
+}
+
+// newBasicPolicy 基础富文本
+func newBasicPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements("p", "br", "span", "b", "strong", "i", "em", "u", "del", "s", "sub", "sup", "blockquote", "code", "pre")
+	p.AllowLists()
+	p.AllowAttrs("href").OnElements("a")
+	p.AllowStandardURLs()
+	p.RequireNoFollowOnLinks(true)
+	return p
+}
+
+// markdownClassRe markdown 渲染的代码块语言 class
+var markdownClassRe = regexp.MustCompile(`^language-[\w+#-]+$`)
+
+// newMarkdownPolicy markdown 渲染后的 HTML
+func newMarkdownPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("class").Matching(markdownClassRe).OnElements("code", "pre")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowElements("input")
+	return p
+}
+
+// newArticlePolicy 完整文章 允许常见的标签、媒体、表格与样式
+func newArticlePolicy() *bluemonday.Policy {
 	p := bluemonday.NewPolicy()
 	// 允许常见的标签
 	p.AllowElements(
@@ -52,5 +214,5 @@ func XSS(s string) string {
 		"z-index",
 	).Globally()
 
-	return p.Sanitize(s)
+	return p
 }
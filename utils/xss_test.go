@@ -19,3 +19,28 @@ func TestXSS(t *testing.T) {
 	// 样式
 	assert.Equal(t, XSS("<p style='color: red'>Hello, World!</p>"), "<p style=\"color: red\">Hello, World!</p>")
 }
+
+func TestXSSProfile(t *testing.T) {
+	input := `<p style="color: red">Hi <b>there</b> <img src="a.png"> <script>x</script></p>`
+	assert.Equal(t, "Hi there  ", XSSWithProfile(input, XSSProfileStrict))
+	assert.Equal(t, "<p>Hi <b>there</b>  </p>", XSSWithProfile(input, XSSProfileBasic))
+	assert.Equal(t, XSS(input), XSSWithProfile(input, XSSProfileArticle))
+	assert.Equal(t, `<pre><code class="language-go">x</code></pre>`, XSSWithProfile(`<pre><code class="language-go" onclick="x()">x</code></pre>`, XSSProfileMarkdown))
+	// 不存在的配置使用 strict
+	assert.Equal(t, "Hi there  ", XSSWithProfile(input, "unknown"))
+
+	err := RegisterXSSProfileConfig("comment", &XSSProfileConfig{
+		Base:   XSSProfileBasic,
+		Attrs:  map[string][]string{"img": {"src"}},
+		Styles: []string{"color"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `<p style="color: red">Hi <b>there</b> <img src="a.png"> </p>`, XSSWithProfile(input, "comment"))
+
+	err = RegisterXSSProfileConfig("title", &XSSProfileConfig{Elements: []string{"em"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "<em>a</em>b", XSSWithProfile("<em>a</em><b>b</b>", "title"))
+
+	assert.Error(t, RegisterXSSProfileConfig("bad", &XSSProfileConfig{Base: "unknown"}))
+	assert.Error(t, RegisterXSSProfileConfig("bad", &XSSProfileConfig{ClassPattern: "("}))
+}
@@ -0,0 +1,153 @@
+// Package validation 可复用的 CEL 校验函数与预定义规则
+// 函数与规则在启动时注册, 通过 Binding 按字段名、字段名正则或 proto 字段选项绑定到字段, 与 protovalidate 的验证结果合并
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/yimoka/go/utils"
+)
+
+// Rule 预定义规则
+type Rule struct {
+	// 约束 ID 作为验证错误的 constraint_id, 对应语言包中 validate_<ID> 的消息 如 string.cn_mobile
+	ID string
+	// CEL 表达式 字段的值为 this, 返回 bool(false 为不通过) 或 string(非空为不通过, 并作为错误消息)
+	Expression string
+	// 默认错误消息 表达式返回 false 时使用
+	Message string
+}
+
+var (
+	mu    sync.RWMutex
+	funcs = map[string]func(string) bool{}
+	rules = map[string]Rule{}
+)
+
+// 内置的函数与规则 空字符串视为未填写, 需要必填时组合 required 约束
+func init() {
+	RegisterFunc("isCNMobile", utils.IsCNMobile)
+	RegisterFunc("isCNIDCard", utils.IsCNIDCard)
+	RegisterFunc("isCNUSCC", utils.IsCNUSCC)
+	RegisterFunc("isLuhn", utils.IsLuhn)
+	RegisterFunc("isBankCard", utils.IsBankCard)
+	RegisterFunc("isSonyflakeID", utils.IsSonyflakeID)
+
+	for _, r := range []Rule{
+		{ID: RuleCNMobile, Expression: "this == '' || this.isCNMobile()", Message: "value must be a valid mobile phone number"},
+		{ID: RuleCNIDCard, Expression: "this == '' || this.isCNIDCard()", Message: "value must be a valid ID card number"},
+		{ID: RuleCNUSCC, Expression: "this == '' || this.isCNUSCC()", Message: "value must be a valid unified social credit code"},
+		{ID: RuleBankCard, Expression: "this == '' || this.isBankCard()", Message: "value must be a valid bank card number"},
+		{ID: RuleSonyflakeID, Expression: "this == '' || this.isSonyflakeID()", Message: "value must be a valid ID"},
+	} {
+		if err := RegisterRule(r); err != nil {
+			panic(err)
+		}
+	}
+}
+
+const (
+	// RuleCNMobile 中国大陆手机号
+	RuleCNMobile = "string.cn_mobile"
+	// RuleCNIDCard 中国居民身份证号
+	RuleCNIDCard = "string.cn_id_card"
+	// RuleCNUSCC 统一社会信用代码
+	RuleCNUSCC = "string.cn_uscc"
+	// RuleBankCard 银行卡号 长度与 Luhn 校验
+	RuleBankCard = "string.bank_card"
+	// RuleSonyflakeID Sonyflake ID 格式
+	RuleSonyflakeID = "string.sonyflake_id"
+)
+
+// RegisterFunc 注册字符串校验函数 在 CEL 中以 this.<name>() 调用, 同名覆盖 应在启动时调用
+func RegisterFunc(name string, fn func(string) bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	funcs[name] = fn
+}
+
+// RegisterRule 注册预定义规则 同 ID 覆盖, 表达式须能使用已注册的函数编译 应在启动时调用
+func RegisterRule(rule Rule) error {
+	if rule.ID == "" {
+		return fmt.Errorf("validation: rule id is empty")
+	}
+	env, err := NewEnv()
+	if err != nil {
+		return err
+	}
+	if _, err := compile(env, rule.Expression); err != nil {
+		return fmt.Errorf("validation: rule %s: %w", rule.ID, err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	rules[rule.ID] = rule
+	return nil
+}
+
+// GetRule 获取预定义规则
+func GetRule(id string) (Rule, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	r, ok := rules[id]
+	return r, ok
+}
+
+// Library 返回包含所有已注册函数的 CEL 环境选项 可用于自建的 CEL 环境
+func Library() cel.EnvOption {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(funcs))
+	for name := range funcs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	opts := make([]cel.EnvOption, 0, len(names))
+	for _, name := range names {
+		fn := funcs[name]
+		opts = append(opts, cel.Function(name,
+			cel.MemberOverload("string_"+name, []*cel.Type{cel.StringType}, cel.BoolType,
+				cel.UnaryBinding(func(v ref.Val) ref.Val {
+					s, ok := v.(types.String)
+					if !ok {
+						return types.MaybeNoSuchOverloadErr(v)
+					}
+					return types.Bool(fn(string(s)))
+				}),
+			),
+		))
+	}
+	return cel.Lib(&library{opts: opts})
+}
+
+type library struct {
+	opts []cel.EnvOption
+}
+
+func (l *library) CompileOptions() []cel.EnvOption {
+	return l.opts
+}
+
+func (l *library) ProgramOptions() []cel.ProgramOption {
+	return nil
+}
+
+// NewEnv 创建包含已注册函数与 this(string) 变量的 CEL 环境
+func NewEnv() (*cel.Env, error) {
+	return cel.NewEnv(cel.Variable("this", cel.StringType), Library())
+}
+
+func compile(env *cel.Env, expr string) (cel.Program, error) {
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	if t := ast.OutputType(); !t.IsExactType(cel.BoolType) && !t.IsExactType(cel.StringType) {
+		return nil, fmt.Errorf("expression must return bool or string, got %s", t)
+	}
+	return env.Program(ast)
+}
@@ -0,0 +1,158 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/google/cel-go/cel"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// newRequestDesc 构造测试用的请求 Company.code 字段设置了 (test.rules) = "string.cn_uscc" 选项
+func newRequestDesc(t *testing.T) (protoreflect.MessageDescriptor, protoreflect.ExtensionType) {
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	msg := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+	opt := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	rep := descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	field := func(name string, number int32, typ *descriptorpb.FieldDescriptorProto_Type, label *descriptorpb.FieldDescriptorProto_Label, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{Name: proto.String(name), Number: proto.Int32(number), Type: typ, Label: label, JsonName: proto.String(name)}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("test/validation.proto"),
+		Package:    proto.String("test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/descriptor.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Request"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("phone", 1, str, opt, ""),
+					field("id_card", 2, str, opt, ""),
+					field("cards", 3, str, rep, ""),
+					field("company", 4, msg, opt, ".test.Company"),
+					field("contacts", 5, msg, rep, ".test.Request.ContactsEntry"),
+				},
+				NestedType: []*descriptorpb.DescriptorProto{{
+					Name:    proto.String("ContactsEntry"),
+					Field:   []*descriptorpb.FieldDescriptorProto{field("key", 1, str, opt, ""), field("value", 2, str, opt, "")},
+					Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+				}},
+			},
+			{
+				Name:  proto.String("Company"),
+				Field: []*descriptorpb.FieldDescriptorProto{field("code", 1, str, opt, "")},
+			},
+		},
+		Extension: []*descriptorpb.FieldDescriptorProto{
+			{Name: proto.String("rules"), Number: proto.Int32(50003), Type: str, Label: opt,
+				Extendee: proto.String(".google.protobuf.FieldOptions"), JsonName: proto.String("rules")},
+		},
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	assert.NoError(t, err)
+	xt := dynamicpb.NewExtensionType(fd.Extensions().Get(0))
+	options := &descriptorpb.FieldOptions{}
+	proto.SetExtension(options, xt, RuleCNUSCC)
+	fdp.MessageType[1].Field[0].Options = options
+	fd, err = protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	assert.NoError(t, err)
+	return fd.Messages().ByName("Request"), xt
+}
+
+func newRequest(desc protoreflect.MessageDescriptor, phone, idCard, code string, cards []string, contacts map[string]string) proto.Message {
+	m := dynamicpb.NewMessage(desc)
+	fields := desc.Fields()
+	m.Set(fields.ByName("phone"), protoreflect.ValueOfString(phone))
+	m.Set(fields.ByName("id_card"), protoreflect.ValueOfString(idCard))
+	list := m.Mutable(fields.ByName("cards")).List()
+	for _, c := range cards {
+		list.Append(protoreflect.ValueOfString(c))
+	}
+	company := dynamicpb.NewMessage(fields.ByName("company").Message())
+	company.Set(company.Descriptor().Fields().ByName("code"), protoreflect.ValueOfString(code))
+	m.Set(fields.ByName("company"), protoreflect.ValueOfMessage(company))
+	mv := m.Mutable(fields.ByName("contacts")).Map()
+	for k, v := range contacts {
+		mv.Set(protoreflect.ValueOfString(k).MapKey(), protoreflect.ValueOfString(v))
+	}
+	return m
+}
+
+func violations(t *testing.T, err error) map[string]string {
+	var valErr *protovalidate.ValidationError
+	assert.True(t, errors.As(err, &valErr))
+	res := map[string]string{}
+	for _, v := range valErr.Violations {
+		res[protovalidate.FieldPathString(v.Proto.GetField())] = v.Proto.GetConstraintId()
+	}
+	return res
+}
+
+func TestValidator(t *testing.T) {
+	desc, xt := newRequestDesc(t)
+	v, err := New(nil, []Binding{
+		{Fields: []string{"phone", "contacts"}, Rules: []string{RuleCNMobile}},
+		{FieldPattern: `(?i)id_?card$`, Rules: []string{RuleCNIDCard}},
+		{Fields: []string{"test.Request.cards"}, Rules: []string{RuleBankCard}},
+		{Option: xt},
+	})
+	assert.NoError(t, err)
+
+	valid := newRequest(desc, "13800138000", "11010519491231002X", "91350100M000100Y43", []string{"4111111111111111"}, map[string]string{"a": "+8613800138000"})
+	assert.NoError(t, v.Validate(valid))
+	// 空字符串视为未填写
+	assert.NoError(t, v.Validate(newRequest(desc, "", "", "", nil, nil)))
+
+	invalid := newRequest(desc, "12345", "110105194912310021", "91350100M000100Y44", []string{"4111111111111111", "4111111111111112"}, map[string]string{"a": "1"})
+	assert.Equal(t, map[string]string{
+		"phone":         RuleCNMobile,
+		"id_card":       RuleCNIDCard,
+		"cards[1]":      RuleBankCard,
+		"company.code":  RuleCNUSCC,
+		`contacts["a"]`: RuleCNMobile,
+	}, violations(t, v.Validate(invalid)))
+
+	ff, err := New(nil, []Binding{{Fields: []string{"phone", "id_card"}, Rules: []string{RuleCNMobile, RuleCNIDCard}}}, WithFailFast())
+	assert.NoError(t, err)
+	assert.Len(t, violations(t, ff.Validate(invalid)), 1)
+
+	_, err = New(nil, []Binding{{Fields: []string{"phone"}, Rules: []string{"string.unknown"}}})
+	assert.Error(t, err)
+}
+
+func TestRegister(t *testing.T) {
+	RegisterFunc("isEven", func(s string) bool { return len(s)%2 == 0 })
+	assert.NoError(t, RegisterRule(Rule{ID: "string.even", Expression: "this.isEven() ? '' : 'length must be even'"}))
+	assert.Error(t, RegisterRule(Rule{ID: "string.bad", Expression: "this.notExists()"}))
+	assert.Error(t, RegisterRule(Rule{ID: "string.int", Expression: "size(this)"}))
+
+	desc, _ := newRequestDesc(t)
+	v, err := New(nil, []Binding{{Fields: []string{"phone"}, Rules: []string{"string.even"}}})
+	assert.NoError(t, err)
+	err = v.Validate(newRequest(desc, "123", "", "", nil, nil))
+	var valErr *protovalidate.ValidationError
+	assert.True(t, errors.As(err, &valErr))
+	assert.Equal(t, "length must be even", valErr.Violations[0].Proto.GetMessage())
+
+	// 自建的 CEL 环境可使用已注册的函数
+	env, err := cel.NewEnv(Library())
+	assert.NoError(t, err)
+	ast, iss := env.Compile("'13800138000'.isCNMobile() && '1234'.isEven()")
+	assert.NoError(t, iss.Err())
+	prg, err := env.Program(ast)
+	assert.NoError(t, err)
+	out, _, err := prg.Eval(map[string]any{})
+	assert.NoError(t, err)
+	assert.Equal(t, true, out.Value())
+}
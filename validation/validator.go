@@ -0,0 +1,303 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/google/cel-go/cel"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Binding 规则与字段的绑定 按声明顺序匹配, 第一个匹配的绑定生效
+type Binding struct {
+	// 字段名 proto 字段名、JSON 名或全名(如 user.v1.AddRequest.phone)
+	Fields []string
+	// 字段名的正则
+	FieldPattern string
+	// 字段选项的扩展 值为规则 ID, 多个以逗号分隔 值为空时使用 Rules
+	Option protoreflect.ExtensionType
+	// 规则 ID 如 RuleCNMobile
+	Rules []string
+}
+
+type binding struct {
+	Binding
+	fields  map[string]bool
+	fieldRe *regexp.Regexp
+}
+
+// match 字段是否匹配 返回规则 ID
+func (b *binding) match(fd protoreflect.FieldDescriptor) ([]string, bool) {
+	for _, name := range []string{string(fd.Name()), fd.JSONName(), string(fd.FullName())} {
+		if b.fields[name] || (b.fieldRe != nil && b.fieldRe.MatchString(name)) {
+			return b.Rules, true
+		}
+	}
+	if b.Option == nil || fd.Options() == nil || !proto.HasExtension(fd.Options(), b.Option) {
+		return nil, false
+	}
+	if s, ok := proto.GetExtension(fd.Options(), b.Option).(string); ok && s != "" {
+		ids := []string{}
+		for _, id := range strings.Split(s, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+		return ids, true
+	}
+	return b.Rules, true
+}
+
+// Option 配置
+type Option func(*Validator)
+
+// WithFailFast 遇到第一个验证错误即返回
+func WithFailFast() Option {
+	return func(v *Validator) {
+		v.failFast = true
+	}
+}
+
+// program 编译后的规则
+type program struct {
+	Rule
+	prg cel.Program
+}
+
+// Validator 在 protovalidate 的验证之后按绑定执行预定义规则 实现 protovalidate.Validator, 创建后并发安全
+// 违反的规则与 protovalidate 的结果合并为 *protovalidate.ValidationError, constraint_id 为规则 ID
+type Validator struct {
+	base     protovalidate.Validator
+	bindings []*binding
+	env      *cel.Env
+	failFast bool
+	// 规则 ID -> *program
+	programs sync.Map
+	// 字段全名 -> []*program
+	fields sync.Map
+}
+
+// New 创建验证器 base 为空时使用默认的 protovalidate 验证器, 绑定中的规则须已注册
+func New(base protovalidate.Validator, bindings []Binding, opts ...Option) (*Validator, error) {
+	v := &Validator{base: base}
+	for _, opt := range opts {
+		opt(v)
+	}
+	if v.base == nil {
+		var pvOpts []protovalidate.ValidatorOption
+		if v.failFast {
+			pvOpts = append(pvOpts, protovalidate.WithFailFast())
+		}
+		var err error
+		if v.base, err = protovalidate.New(pvOpts...); err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	if v.env, err = NewEnv(); err != nil {
+		return nil, err
+	}
+	for _, conf := range bindings {
+		b := &binding{Binding: conf, fields: map[string]bool{}}
+		for _, f := range b.Fields {
+			b.fields[f] = true
+		}
+		if b.FieldPattern != "" {
+			if b.fieldRe, err = regexp.Compile(b.FieldPattern); err != nil {
+				return nil, fmt.Errorf("validation: invalid field pattern %q: %w", b.FieldPattern, err)
+			}
+		}
+		for _, id := range b.Rules {
+			if _, err := v.program(id); err != nil {
+				return nil, err
+			}
+		}
+		v.bindings = append(v.bindings, b)
+	}
+	return v, nil
+}
+
+// Validate 验证消息 先执行 protovalidate 的约束, 再执行绑定的预定义规则
+func (v *Validator) Validate(msg proto.Message) error {
+	err := v.base.Validate(msg)
+	var valErr *protovalidate.ValidationError
+	if err != nil && (!errors.As(err, &valErr) || v.failFast) {
+		return err
+	}
+	if msg == nil || len(v.bindings) == 0 {
+		return err
+	}
+	if valErr == nil {
+		valErr = &protovalidate.ValidationError{}
+	}
+	if wErr := v.validateMessage(msg.ProtoReflect(), nil, valErr); wErr != nil {
+		return wErr
+	}
+	if len(valErr.Violations) == 0 {
+		return nil
+	}
+	return valErr
+}
+
+func (v *Validator) validateMessage(m protoreflect.Message, path []*validate.FieldPathElement, valErr *protovalidate.ValidationError) error {
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		programs, pErr := v.fieldPrograms(fd)
+		if pErr != nil {
+			err = pErr
+			return false
+		}
+		switch {
+		case fd.IsList():
+			list := value.List()
+			for i := 0; i < list.Len() && err == nil; i++ {
+				el := pathElement(fd)
+				el.Subscript = &validate.FieldPathElement_Index{Index: uint64(i)}
+				err = v.validateValue(fd, programs, list.Get(i), append(slices.Clone(path), el), valErr)
+			}
+		case fd.IsMap():
+			value.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+				el := pathElement(fd)
+				setMapKey(el, fd.MapKey(), k)
+				err = v.validateValue(fd.MapValue(), programs, mv, append(slices.Clone(path), el), valErr)
+				return err == nil
+			})
+		default:
+			err = v.validateValue(fd, programs, value, append(slices.Clone(path), pathElement(fd)), valErr)
+		}
+		return err == nil && !v.stop(valErr)
+	})
+	return err
+}
+
+func (v *Validator) validateValue(fd protoreflect.FieldDescriptor, programs []*program, value protoreflect.Value, path []*validate.FieldPathElement, valErr *protovalidate.ValidationError) error {
+	if v.stop(valErr) {
+		return nil
+	}
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return v.eval(fd, programs, value.String(), path, valErr)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		msg := value.Message()
+		// StringValue 按所在字段的规则验证
+		if msg.Descriptor().FullName() == "google.protobuf.StringValue" {
+			return v.eval(fd, programs, msg.Get(msg.Descriptor().Fields().ByName("value")).String(), path, valErr)
+		}
+		return v.validateMessage(msg, path, valErr)
+	}
+	return nil
+}
+
+// eval 执行字段的规则 不通过时添加违规
+func (v *Validator) eval(fd protoreflect.FieldDescriptor, programs []*program, str string, path []*validate.FieldPathElement, valErr *protovalidate.ValidationError) error {
+	for _, p := range programs {
+		out, _, err := p.prg.Eval(map[string]any{"this": str})
+		if err != nil {
+			return fmt.Errorf("validation: rule %s: %w", p.ID, err)
+		}
+		msg := ""
+		switch r := out.Value().(type) {
+		case bool:
+			if r {
+				continue
+			}
+			msg = p.Message
+		case string:
+			if r == "" {
+				continue
+			}
+			msg = r
+		}
+		valErr.Violations = append(valErr.Violations, &protovalidate.Violation{
+			Proto: &validate.Violation{
+				Field:        &validate.FieldPath{Elements: path},
+				ConstraintId: proto.String(p.ID),
+				Message:      proto.String(msg),
+			},
+			FieldValue:      protoreflect.ValueOfString(str),
+			FieldDescriptor: fd,
+		})
+		if v.failFast {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (v *Validator) stop(valErr *protovalidate.ValidationError) bool {
+	return v.failFast && len(valErr.Violations) > 0
+}
+
+// fieldPrograms 获取字段的规则 按字段全名缓存
+func (v *Validator) fieldPrograms(fd protoreflect.FieldDescriptor) ([]*program, error) {
+	if p, ok := v.fields.Load(fd.FullName()); ok {
+		return p.([]*program), nil
+	}
+	var programs []*program
+	for _, b := range v.bindings {
+		ids, ok := b.match(fd)
+		if !ok {
+			continue
+		}
+		for _, id := range ids {
+			p, err := v.program(id)
+			if err != nil {
+				return nil, err
+			}
+			programs = append(programs, p)
+		}
+		break
+	}
+	v.fields.Store(fd.FullName(), programs)
+	return programs, nil
+}
+
+// program 获取编译后的规则 按规则 ID 缓存
+func (v *Validator) program(id string) (*program, error) {
+	if p, ok := v.programs.Load(id); ok {
+		return p.(*program), nil
+	}
+	rule, ok := GetRule(id)
+	if !ok {
+		return nil, fmt.Errorf("validation: rule %s is not registered", id)
+	}
+	prg, err := compile(v.env, rule.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("validation: rule %s: %w", id, err)
+	}
+	p := &program{Rule: rule, prg: prg}
+	v.programs.Store(id, p)
+	return p, nil
+}
+
+func pathElement(fd protoreflect.FieldDescriptor) *validate.FieldPathElement {
+	return &validate.FieldPathElement{
+		FieldNumber: proto.Int32(int32(fd.Number())),
+		FieldName:   proto.String(fd.TextName()),
+		FieldType:   descriptorpb.FieldDescriptorProto_Type(fd.Kind()).Enum(),
+	}
+}
+
+// setMapKey 设置 map 的 key 作为路径的下标
+func setMapKey(el *validate.FieldPathElement, kd protoreflect.FieldDescriptor, k protoreflect.MapKey) {
+	el.KeyType = descriptorpb.FieldDescriptorProto_Type(kd.Kind()).Enum()
+	switch kd.Kind() {
+	case protoreflect.BoolKind:
+		el.Subscript = &validate.FieldPathElement_BoolKey{BoolKey: k.Bool()}
+	case protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Sint32Kind, protoreflect.Sint64Kind,
+		protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		el.Subscript = &validate.FieldPathElement_IntKey{IntKey: k.Int()}
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		el.Subscript = &validate.FieldPathElement_UintKey{UintKey: k.Uint()}
+	default:
+		el.Subscript = &validate.FieldPathElement_StringKey{StringKey: k.String()}
+	}
+}
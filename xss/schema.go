@@ -0,0 +1,32 @@
+// Package xss schema
+package xss
+
+import (
+	"sort"
+
+	"entgo.io/ent"
+	"github.com/yimoka/go/ent/ann"
+	"github.com/yimoka/go/utils"
+)
+
+// SchemaRules 由 ent Schema 中 ann.Field 的 XSSProfile 与 XSSFilter 生成过滤规则 每个净化配置一条规则
+// 规则按 Schema 的字段名匹配, 与生成的 proto 字段名或 JSON 名一致, 可放在自定义规则之后作为默认配置
+func SchemaRules(schemas ...ent.Interface) ([]Rule, error) {
+	fields := map[utils.XSSProfile][]string{}
+	for _, schema := range schemas {
+		node, err := ann.LoadType(schema)
+		if err != nil {
+			return nil, err
+		}
+		for name, profile := range ann.GetXSSFields(node) {
+			fields[profile] = append(fields[profile], name)
+		}
+	}
+	rules := make([]Rule, 0, len(fields))
+	for profile, names := range fields {
+		sort.Strings(names)
+		rules = append(rules, Rule{Fields: names, Profile: profile})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Profile < rules[j].Profile })
+	return rules, nil
+}
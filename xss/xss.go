@@ -0,0 +1,171 @@
+// Package xss 请求消息的 xss 过滤
+// 按字段名、字段名的正则或 proto 字段选项标记需要过滤的字段及其净化配置, 在请求进入业务代码前过滤
+package xss
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/yimoka/go/utils"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Rule 过滤规则 按声明顺序匹配, 第一个匹配的规则生效
+type Rule struct {
+	// 字段名 proto 字段名、JSON 名或全名(如 article.v1.AddRequest.content)
+	Fields []string
+	// 字段名的正则 匹配字段名或全名 如 (?i)(content|desc)$
+	FieldPattern string
+	// 字段选项的扩展 值为 true 时使用 Profile, 为非空字符串时作为净化配置的名称
+	Option protoreflect.ExtensionType
+	// 净化配置 为空时使用 utils.XSSProfileArticle
+	Profile utils.XSSProfile
+}
+
+type rule struct {
+	Rule
+	fields  map[string]bool
+	fieldRe *regexp.Regexp
+}
+
+// match 字段是否匹配 返回净化配置
+func (r *rule) match(fd protoreflect.FieldDescriptor) (utils.XSSProfile, bool) {
+	for _, name := range []string{string(fd.Name()), fd.JSONName(), string(fd.FullName())} {
+		if r.fields[name] || (r.fieldRe != nil && r.fieldRe.MatchString(name)) {
+			return r.Profile, true
+		}
+	}
+	if r.Option == nil || fd.Options() == nil || !proto.HasExtension(fd.Options(), r.Option) {
+		return "", false
+	}
+	switch v := proto.GetExtension(fd.Options(), r.Option).(type) {
+	case bool:
+		return r.Profile, v
+	case string:
+		if v != "" {
+			return utils.XSSProfile(v), true
+		}
+	}
+	return r.Profile, true
+}
+
+// Sanitizer 请求消息的过滤器 创建后并发安全
+type Sanitizer struct {
+	rules []*rule
+	// 字段全名 -> utils.XSSProfile 空字符串表示不过滤
+	cache sync.Map
+}
+
+// New 创建过滤器 规则中的净化配置须已注册, 正则在创建时编译
+func New(rules ...Rule) (*Sanitizer, error) {
+	s := &Sanitizer{}
+	for _, conf := range rules {
+		r := &rule{Rule: conf, fields: map[string]bool{}}
+		if r.Profile == "" {
+			r.Profile = utils.XSSProfileArticle
+		}
+		if _, ok := utils.GetXSSProfile(r.Profile); !ok {
+			return nil, fmt.Errorf("xss: profile %s is not registered", r.Profile)
+		}
+		for _, f := range r.Fields {
+			r.fields[f] = true
+		}
+		if r.FieldPattern != "" {
+			var err error
+			if r.fieldRe, err = regexp.Compile(r.FieldPattern); err != nil {
+				return nil, fmt.Errorf("xss: invalid field pattern %q: %w", r.FieldPattern, err)
+			}
+		}
+		s.rules = append(s.rules, r)
+	}
+	return s, nil
+}
+
+// Sanitize 过滤消息中标记的字符串字段 直接修改传入的消息
+// 包括嵌套消息、列表、map 的值与 StringValue, 返回被修改的字段路径 如 content、items[0].title、attrs["k"]
+func (s *Sanitizer) Sanitize(msg proto.Message) []string {
+	if msg == nil {
+		return nil
+	}
+	changed := []string{}
+	s.sanitizeMessage(msg.ProtoReflect(), "", &changed)
+	return changed
+}
+
+func (s *Sanitizer) sanitizeMessage(m protoreflect.Message, prefix string, changed *[]string) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		profile := s.profile(fd)
+		path := prefix + string(fd.Name())
+		switch {
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				if nv, ok := s.sanitizeValue(fd, profile, list.Get(i), path+"["+strconv.Itoa(i)+"]", changed); ok {
+					list.Set(i, nv)
+				}
+			}
+		case fd.IsMap():
+			mv := v.Map()
+			mv.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				if nv, ok := s.sanitizeValue(fd.MapValue(), profile, v, path+"["+strconv.Quote(k.String())+"]", changed); ok {
+					mv.Set(k, nv)
+				}
+				return true
+			})
+		default:
+			if nv, ok := s.sanitizeValue(fd, profile, v, path, changed); ok {
+				m.Set(fd, nv)
+			}
+		}
+		return true
+	})
+}
+
+// sanitizeValue 过滤字段的值 返回新值与是否需要设置 消息类型直接修改
+func (s *Sanitizer) sanitizeValue(fd protoreflect.FieldDescriptor, profile utils.XSSProfile, v protoreflect.Value, path string, changed *[]string) (protoreflect.Value, bool) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		if profile == "" {
+			return v, false
+		}
+		if str := utils.XSSWithProfile(v.String(), profile); str != v.String() {
+			*changed = append(*changed, path)
+			return protoreflect.ValueOfString(str), true
+		}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		msg := v.Message()
+		// StringValue 按所在字段的配置过滤
+		if msg.Descriptor().FullName() == "google.protobuf.StringValue" {
+			if profile == "" {
+				return v, false
+			}
+			vf := msg.Descriptor().Fields().ByName("value")
+			if str := utils.XSSWithProfile(msg.Get(vf).String(), profile); str != msg.Get(vf).String() {
+				*changed = append(*changed, path)
+				msg.Set(vf, protoreflect.ValueOfString(str))
+			}
+			return v, false
+		}
+		s.sanitizeMessage(msg, path+".", changed)
+	}
+	return v, false
+}
+
+// profile 获取字段的净化配置 按字段全名缓存
+func (s *Sanitizer) profile(fd protoreflect.FieldDescriptor) utils.XSSProfile {
+	if v, ok := s.cache.Load(fd.FullName()); ok {
+		return v.(utils.XSSProfile)
+	}
+	var profile utils.XSSProfile
+	for _, r := range s.rules {
+		if p, ok := r.match(fd); ok {
+			profile = p
+			break
+		}
+	}
+	s.cache.Store(fd.FullName(), profile)
+	return profile
+}
@@ -0,0 +1,160 @@
+package xss
+
+import (
+	"testing"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"github.com/stretchr/testify/assert"
+	"github.com/yimoka/go/ent/ann"
+	"github.com/yimoka/go/utils"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// newRequestDesc 构造测试用的请求 Item.text 字段设置了 (test.xss) = true 选项
+func newRequestDesc(t *testing.T) (protoreflect.MessageDescriptor, protoreflect.ExtensionType) {
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	msg := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+	opt := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	rep := descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	field := func(name string, number int32, typ *descriptorpb.FieldDescriptorProto_Type, label *descriptorpb.FieldDescriptorProto_Label, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{Name: proto.String(name), Number: proto.Int32(number), Type: typ, Label: label, JsonName: proto.String(name)}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("test/xss.proto"),
+		Package:    proto.String("test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/descriptor.proto", "google/protobuf/wrappers.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Request"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("title", 1, str, opt, ""),
+					field("content", 2, str, opt, ""),
+					field("items", 3, msg, rep, ".test.Item"),
+					field("tags", 4, msg, rep, ".test.Request.TagsEntry"),
+					field("note", 5, msg, opt, ".google.protobuf.StringValue"),
+					field("raw", 6, str, opt, ""),
+				},
+				NestedType: []*descriptorpb.DescriptorProto{{
+					Name:    proto.String("TagsEntry"),
+					Field:   []*descriptorpb.FieldDescriptorProto{field("key", 1, str, opt, ""), field("value", 2, str, opt, "")},
+					Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+				}},
+			},
+			{
+				Name:  proto.String("Item"),
+				Field: []*descriptorpb.FieldDescriptorProto{field("text", 1, str, opt, "")},
+			},
+		},
+		Extension: []*descriptorpb.FieldDescriptorProto{
+			{Name: proto.String("xss"), Number: proto.Int32(50002), Type: descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum(), Label: opt,
+				Extendee: proto.String(".google.protobuf.FieldOptions"), JsonName: proto.String("xss")},
+		},
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	assert.NoError(t, err)
+	xt := dynamicpb.NewExtensionType(fd.Extensions().Get(0))
+	options := &descriptorpb.FieldOptions{}
+	proto.SetExtension(options, xt, true)
+	fdp.MessageType[1].Field[0].Options = options
+	fd, err = protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	assert.NoError(t, err)
+	return fd.Messages().ByName("Request"), xt
+}
+
+func TestSanitize(t *testing.T) {
+	desc, xt := newRequestDesc(t)
+	s, err := New(
+		Rule{Fields: []string{"title", "note"}, Profile: utils.XSSProfileStrict},
+		Rule{Fields: []string{"test.Request.content"}},
+		Rule{Fields: []string{"tags"}, Profile: utils.XSSProfileBasic},
+		Rule{Option: xt, Profile: utils.XSSProfileStrict},
+	)
+	assert.NoError(t, err)
+
+	fields := desc.Fields()
+	m := dynamicpb.NewMessage(desc)
+	m.Set(fields.ByName("title"), protoreflect.ValueOfString("<b>title</b>"))
+	m.Set(fields.ByName("content"), protoreflect.ValueOfString(`<p onclick="x()">content</p><script>x</script>`))
+	items := m.Mutable(fields.ByName("items")).List()
+	item := items.NewElement()
+	item.Message().Set(item.Message().Descriptor().Fields().ByName("text"), protoreflect.ValueOfString("ok"))
+	items.Append(item)
+	item = items.NewElement()
+	item.Message().Set(item.Message().Descriptor().Fields().ByName("text"), protoreflect.ValueOfString("<i>x</i>"))
+	items.Append(item)
+	m.Mutable(fields.ByName("tags")).Map().Set(protoreflect.ValueOfString("k").MapKey(), protoreflect.ValueOfString(`<a href="javascript:x()">a</a>`))
+	m.Set(fields.ByName("note"), protoreflect.ValueOfMessage(wrapperspb.String("<b>n</b>").ProtoReflect()))
+	m.Set(fields.ByName("raw"), protoreflect.ValueOfString("<b>raw</b>"))
+
+	changed := s.Sanitize(m)
+	assert.ElementsMatch(t, []string{"title", "content", "items[1].text", `tags["k"]`, "note"}, changed)
+	assert.Equal(t, "title", m.Get(fields.ByName("title")).String())
+	assert.Equal(t, "<p>content</p>", m.Get(fields.ByName("content")).String())
+	text := m.Get(fields.ByName("items")).List().Get(1).Message()
+	assert.Equal(t, "x", text.Get(text.Descriptor().Fields().ByName("text")).String())
+	assert.Equal(t, "a", m.Get(fields.ByName("tags")).Map().Get(protoreflect.ValueOfString("k").MapKey()).String())
+	note := m.Get(fields.ByName("note")).Message()
+	assert.Equal(t, "n", note.Get(note.Descriptor().Fields().ByName("value")).String())
+	assert.Equal(t, "<b>raw</b>", m.Get(fields.ByName("raw")).String())
+
+	// 字段名的正则
+	p, err := New(Rule{FieldPattern: `^(title|raw)$`, Profile: utils.XSSProfileStrict})
+	assert.NoError(t, err)
+	m = dynamicpb.NewMessage(desc)
+	m.Set(fields.ByName("raw"), protoreflect.ValueOfString("<b>raw</b>"))
+	m.Set(fields.ByName("content"), protoreflect.ValueOfString("<b>content</b>"))
+	assert.Equal(t, []string{"raw"}, p.Sanitize(m))
+	assert.Equal(t, "<b>content</b>", m.Get(fields.ByName("content")).String())
+
+	_, err = New(Rule{FieldPattern: "("})
+	assert.Error(t, err)
+	_, err = New(Rule{Fields: []string{"title"}, Profile: "unknown"})
+	assert.Error(t, err)
+}
+
+// article 测试使用的 ent Schema
+type article struct {
+	ent.Schema
+}
+
+func (article) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("title").Annotations(ann.Field{XSSProfile: utils.XSSProfileStrict}),
+		field.String("content").Annotations(ann.Field{XSSFilter: true}),
+		field.String("raw"),
+	}
+}
+
+func TestSchemaRules(t *testing.T) {
+	rules, err := SchemaRules(article{})
+	assert.NoError(t, err)
+	assert.Equal(t, []Rule{
+		{Fields: []string{"content"}, Profile: utils.XSSProfileArticle},
+		{Fields: []string{"title"}, Profile: utils.XSSProfileStrict},
+	}, rules)
+
+	desc, _ := newRequestDesc(t)
+	s, err := New(rules...)
+	assert.NoError(t, err)
+	fields := desc.Fields()
+	m := dynamicpb.NewMessage(desc)
+	m.Set(fields.ByName("title"), protoreflect.ValueOfString("<b>title</b>"))
+	m.Set(fields.ByName("content"), protoreflect.ValueOfString("<b>content</b><script>x</script>"))
+	m.Set(fields.ByName("raw"), protoreflect.ValueOfString("<b>raw</b>"))
+	assert.ElementsMatch(t, []string{"title", "content"}, s.Sanitize(m))
+	assert.Equal(t, "title", m.Get(fields.ByName("title")).String())
+	assert.Equal(t, "<b>content</b>", m.Get(fields.ByName("content")).String())
+	assert.Equal(t, "<b>raw</b>", m.Get(fields.ByName("raw")).String())
+}